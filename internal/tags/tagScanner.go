@@ -23,6 +23,7 @@ func TagScanner(tagString string) (tags map[string]string, err error) {
 	var c byte
 	var ok bool
 	var esc bool
+	var quote byte
 	var key []byte
 	var val []byte
 
@@ -83,14 +84,15 @@ equal:
 
 	c = data[i]
 	switch {
-	case c > ' ' && c != '`' && c != '=':
+	case c > ' ' && c != '`' && c != '"' && c != '=':
 		m = i
 		i++
 		goto ivalue
-	case c == '`':
+	case c == '`' || c == '"':
 		m = i
 		i++
 		esc = false
+		quote = c
 		goto qvalue
 	default:
 		if key != nil {
@@ -111,7 +113,7 @@ ivalue:
 
 	c = data[i]
 	switch {
-	case c > ' ' && c != '`' && c != '=':
+	case c > ' ' && c != '`':
 		i++
 		goto ivalue
 	default:
@@ -130,12 +132,12 @@ qvalue:
 	}
 
 	c = data[i]
-	switch c {
-	case '\\':
+	switch {
+	case c == '\\':
 		i += 2
 		esc = true
 		goto qvalue
-	case '`':
+	case c == quote:
 		i++
 		val = data[m:i]
 		if esc {
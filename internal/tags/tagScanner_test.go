@@ -0,0 +1,41 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package tags
+
+import (
+	"errors"
+	"maps"
+	"testing"
+)
+
+func TestTagScanner(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr error
+	}{
+		{name: "flag", value: "log", want: map[string]string{"log": ""}},
+		{name: "key value", value: "http-method=GET log", want: map[string]string{"http-method": "GET", "log": ""}},
+		{name: "equal sign in value", value: "http-cache=max-age=60", want: map[string]string{"http-cache": "max-age=60"}},
+		{name: "equal sign and comma in value", value: "http-cache=public,max-age=60 etag", want: map[string]string{"http-cache": "public,max-age=60", "etag": ""}},
+		{name: "quoted value", value: `deprecated="use CreateV2" version=2`, want: map[string]string{"deprecated": "use CreateV2", "version": "2"}},
+		{name: "backquoted value", value: "summary=`list users`", want: map[string]string{"summary": "list users"}},
+		{name: "escaped quotes", value: `deprecated="use \"CreateV2\" instead"`, want: map[string]string{"deprecated": `use "CreateV2" instead`}},
+		{name: "empty quoted value", value: `deprecated=""`, want: map[string]string{"deprecated": ""}},
+		{name: "unterminated quote", value: `deprecated="use CreateV2`, want: map[string]string{}, wantErr: ErrUnterminatedString},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TagScanner(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TagScanner(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("TagScanner(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -37,8 +37,13 @@ type DocOptions struct {
 	FilePath string // Полный путь к файлу документации (пусто = outDir/README.md)
 }
 
+// ModuleOptions содержит опции генерации клиента как отдельного модуля
+type ModuleOptions struct {
+	Path string // Путь модуля клиента (пусто = клиент генерируется как пакет модуля проекта)
+}
+
 // GenerateClient генерирует клиент для всех контрактов.
-func GenerateClient(project *core.Project, outDir, projectRoot string, docOpts DocOptions) error {
+func GenerateClient(project *core.Project, outDir, projectRoot string, docOpts DocOptions, moduleOpts ModuleOptions) error {
 
 	logger := core.GetLogger()
 	logger.Info(fmt.Sprintf("generating Go client: outDir=%s", outDir))
@@ -47,7 +52,7 @@ func GenerateClient(project *core.Project, outDir, projectRoot string, docOpts D
 		project:     project,
 		outDir:      outDir,
 		projectRoot: projectRoot,
-		renderer:    renderer.NewClientRenderer(project, outDir, projectRoot),
+		renderer:    renderer.NewClientRenderer(project, outDir, projectRoot, moduleOpts.Path),
 	}
 
 	if err := gen.generate(docOpts); err != nil {
@@ -77,6 +82,13 @@ func (g *generator) contains(m map[string]string, key string) bool {
 
 func (g *generator) generate(docOpts DocOptions) error {
 
+	// go.mod модуля клиента создаётся до остальных файлов, чтобы импорты группировались по модулю клиента
+	if g.renderer.IsModule() {
+		if err := g.renderer.RenderGoMod(false); err != nil {
+			return err
+		}
+	}
+
 	// Генерируем базовые файлы клиента один раз для всех контрактов
 	if g.renderer.HasJsonRPC() || g.renderer.HasHTTP() {
 		if err := g.renderer.RenderClientOptions(); err != nil {
@@ -96,6 +108,16 @@ func (g *generator) generate(docOpts DocOptions) error {
 				return err
 			}
 		}
+		if g.renderer.HasTrace() {
+			if err := g.renderer.RenderClientTracing(); err != nil {
+				return err
+			}
+		}
+		if g.renderer.HasMultipart() {
+			if err := g.renderer.RenderClientMultipart(); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Собираем typeID типов из всех контрактов перед генерацией
@@ -144,5 +166,15 @@ func (g *generator) generate(docOpts DocOptions) error {
 		}
 	}
 
+	// Зависимости модуля клиента известны только после генерации всех файлов
+	if g.renderer.IsModule() {
+		if err := g.renderer.RenderGoMod(true); err != nil {
+			return err
+		}
+		if err := g.renderer.RenderLicense(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
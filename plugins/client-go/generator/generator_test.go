@@ -0,0 +1,314 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/core"
+)
+
+// testLogger пишет сообщения генератора в лог теста.
+type testLogger struct {
+	t *testing.T
+}
+
+func (l testLogger) Debug(string)     {}
+func (l testLogger) Info(string)      {}
+func (l testLogger) Warn(msg string)  { l.t.Log("WARN", msg) }
+func (l testLogger) Error(msg string) { l.t.Log("ERROR", msg) }
+
+const testModulePath = "example.com/svc"
+
+// testContract - исходный код контракта, соответствующий testProject.
+const testContract = `package contracts
+
+import "context"
+
+type Pair struct {
+	A int
+	B int
+}
+
+type Svc interface {
+	Ping(ctx context.Context) (err error)
+	Sum(ctx context.Context, pair Pair) (c int, err error)
+}
+`
+
+// testProject возвращает проект с JSON-RPC контрактом Svc с аннотациями contractTags.
+func testProject(contractTags map[string]string) *core.Project {
+
+	contractID := testModulePath + "/contracts:Svc"
+	pairTypeID := testModulePath + "/contracts:Pair"
+	annotations := map[string]string{"jsonRPC-server": ""}
+	for tag, value := range contractTags {
+		annotations[tag] = value
+	}
+	ctxArg := &core.Variable{Name: "ctx", TypeID: "context:Context"}
+	errResult := &core.Variable{Name: "err", TypeID: "error"}
+	return &core.Project{
+		Version:      "v0.0.1",
+		ModulePath:   testModulePath,
+		ContractsDir: "contracts",
+		Contracts: []*core.Contract{{
+			Name:        "Svc",
+			PkgPath:     testModulePath + "/contracts",
+			FilePath:    "contracts/svc.go",
+			ID:          contractID,
+			Annotations: annotations,
+			Methods: []*core.Method{
+				{
+					Name:        "Ping",
+					ContractID:  contractID,
+					Args:        []*core.Variable{ctxArg},
+					Results:     []*core.Variable{errResult},
+					Annotations: map[string]string{},
+				},
+				{
+					Name:        "Sum",
+					ContractID:  contractID,
+					Args:        []*core.Variable{ctxArg, {Name: "pair", TypeID: pairTypeID}},
+					Results:     []*core.Variable{{Name: "c", TypeID: "int"}, errResult},
+					Annotations: map[string]string{},
+				},
+			},
+		}},
+		Types: map[string]*core.Type{
+			"context:Context": {Kind: core.TypeKindInterface, TypeName: "Context", ImportPkgPath: "context", PkgName: "context"},
+			pairTypeID: {
+				Kind:          core.TypeKindStruct,
+				TypeName:      "Pair",
+				ImportPkgPath: testModulePath + "/contracts",
+				PkgName:       "contracts",
+				StructFields: []*core.StructField{
+					{Name: "A", TypeID: "int", Tags: map[string][]string{"json": {"a"}}},
+					{Name: "B", TypeID: "int", Tags: map[string][]string{"json": {"b"}}},
+				},
+			},
+		},
+	}
+}
+
+// newTestModule создаёт модуль проекта с контрактом testContract и делает его текущей директорией.
+func newTestModule(t *testing.T) string {
+
+	core.SetLogger(testLogger{t: t})
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module "+testModulePath+"\n\ngo 1.23\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "contracts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "contracts", "svc.go"), []byte(testContract), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(root)
+	return root
+}
+
+// buildModule собирает пакеты модуля в директории dir без доступа к сети.
+func buildModule(t *testing.T, dir string) {
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found")
+	}
+	build := exec.Command(goBin, "build", "./...")
+	build.Dir = dir
+	build.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, buildErr := build.CombinedOutput(); buildErr != nil {
+		t.Fatalf("build generated client: %v\n%s", buildErr, output)
+	}
+}
+
+// testBatchChunks - тест сгенерированного клиента: Batch делит запросы на пакеты не больше WithMaxBatchSize.
+const testBatchChunks = `package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestBatchChunks(t *testing.T) {
+
+	var mu sync.Mutex
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []struct {
+			ID json.RawMessage ` + "`json:\"id\"`" + `
+		}
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			t.Errorf("decode batch: %v", err)
+		}
+		mu.Lock()
+		sizes = append(sizes, len(requests))
+		mu.Unlock()
+		responses := make([]map[string]any, 0, len(requests))
+		for _, request := range requests {
+			responses = append(responses, map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": map[string]any{}})
+		}
+		_ = json.NewEncoder(w).Encode(responses)
+	}))
+	defer server.Close()
+
+	cli := New(server.URL, WithMaxBatchSize(2))
+	var order []int
+	requests := make([]RequestRPC, 0, 5)
+	for i := range 5 {
+		requests = append(requests, cli.Svc().ReqPing(func(err error) {
+			if err != nil {
+				t.Errorf("request %d: %v", i, err)
+			}
+			order = append(order, i)
+		}))
+	}
+	cli.Batch(context.Background(), requests...)
+	if !reflect.DeepEqual(sizes, []int{2, 2, 1}) {
+		t.Errorf("batch sizes = %v, want [2 2 1]", sizes)
+	}
+	if !reflect.DeepEqual(order, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("handlers order = %v, want requests order", order)
+	}
+}
+`
+
+// testModuleDir запускает тесты пакетов модуля в директории dir без доступа к сети.
+func testModuleDir(t *testing.T, dir string) {
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found")
+	}
+	test := exec.Command(goBin, "test", "-count=1", "./...")
+	test.Dir = dir
+	test.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, testErr := test.CombinedOutput(); testErr != nil {
+		t.Fatalf("test generated client: %v\n%s", testErr, output)
+	}
+}
+
+// generateAndBuild генерирует клиент проекта пакетом модуля проекта и собирает его.
+func generateAndBuild(t *testing.T, project *core.Project) {
+
+	root := newTestModule(t)
+	if err := GenerateClient(project, "pkg/client", ".", DocOptions{}, ModuleOptions{}); err != nil {
+		t.Fatalf("generate client: %v", err)
+	}
+	buildModule(t, root)
+}
+
+func TestGenerateClient_WithoutTrace(t *testing.T) {
+
+	generateAndBuild(t, testProject(nil))
+}
+
+func TestGenerateClient_WithoutTraceIdempotent(t *testing.T) {
+
+	project := testProject(nil)
+	project.Contracts[0].Methods[0].Annotations = map[string]string{"idempotent": ""}
+	generateAndBuild(t, project)
+}
+
+func TestGenerateClient_Module(t *testing.T) {
+
+	root := newTestModule(t)
+	if err := GenerateClient(testProject(nil), "client", ".", DocOptions{Enabled: true}, ModuleOptions{Path: "example.com/svc-client"}); err != nil {
+		t.Fatalf("generate client: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "client", "types")); err != nil {
+		t.Errorf("module types package is not generated: %v", err)
+	}
+	readme, err := os.ReadFile(filepath.Join(root, "client", "readme.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(readme), "go mod tidy") {
+		t.Error("module readme must tell to run go mod tidy")
+	}
+	if strings.Contains(string(readme), "dto.") {
+		t.Error("module readme must reference types package instead of dto")
+	}
+	buildModule(t, filepath.Join(root, "client"))
+}
+
+func TestGenerateClient_ModuleUnresolvedImport(t *testing.T) {
+
+	newTestModule(t)
+	// trace импортирует OpenTelemetry, которого нет в go.mod проекта
+	err := GenerateClient(testProject(map[string]string{"trace": ""}), "client", ".", DocOptions{}, ModuleOptions{Path: "example.com/svc-client"})
+	if err == nil || !strings.Contains(err.Error(), "go.opentelemetry.io/otel") {
+		t.Fatalf("expected unresolved dependency error, got %v", err)
+	}
+}
+
+func TestGenerateClient_BatchChunks(t *testing.T) {
+
+	root := newTestModule(t)
+	if err := GenerateClient(testProject(nil), "pkg/client", ".", DocOptions{}, ModuleOptions{}); err != nil {
+		t.Fatalf("generate client: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "pkg", "client", "batch_test.go"), []byte(testBatchChunks), 0600); err != nil {
+		t.Fatal(err)
+	}
+	testModuleDir(t, filepath.Join(root, "pkg", "client"))
+}
+
+// warnLogger запоминает предупреждения генератора.
+type warnLogger struct {
+	testLogger
+	warns *[]string
+}
+
+func (l warnLogger) Warn(msg string) { *l.warns = append(*l.warns, msg) }
+
+func TestGenerateClient_SharedErrorCode(t *testing.T) {
+
+	project := testProject(nil)
+	errPkg := testModulePath + "/contracts"
+	project.Contracts[0].Methods[1].Errors = []*core.ErrorInfo{
+		{PkgPath: errPkg, TypeName: "ErrNotFound", HTTPCode: 404, HTTPCodeText: "Not Found"},
+		{PkgPath: errPkg, TypeName: "ErrDeleted", HTTPCode: 404, HTTPCodeText: "Not Found"},
+		{PkgPath: errPkg, TypeName: "ErrConflict", HTTPCode: 409, HTTPCodeText: "Conflict"},
+	}
+	root := newTestModule(t)
+	var warns []string
+	core.SetLogger(warnLogger{testLogger: testLogger{t: t}, warns: &warns})
+	if err := GenerateClient(project, "pkg/client", ".", DocOptions{Enabled: true}, ModuleOptions{}); err != nil {
+		t.Fatalf("generate client: %v", err)
+	}
+	buildModule(t, root)
+
+	source, err := os.ReadFile(filepath.Join(root, "pkg", "client", "error.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(source), "type ErrConflict struct") {
+		t.Error("error with unique code must be decoded into typed error")
+	}
+	if strings.Contains(string(source), "type ErrNotFound struct") || strings.Contains(string(source), "type ErrDeleted struct") {
+		t.Error("errors sharing a code must not be decoded into typed errors")
+	}
+	if len(warns) != 1 || !strings.Contains(warns[0], "code=404") {
+		t.Errorf("warnings = %q, want one warning about code 404", warns)
+	}
+	readme, err := os.ReadFile(filepath.Join(root, "pkg", "client", "readme.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"ErrNotFound", "ErrDeleted"} {
+		if !strings.Contains(string(readme), "`"+name+"` - не декодируется в тип") {
+			t.Errorf("readme must mark %s as not decoded", name)
+		}
+	}
+}
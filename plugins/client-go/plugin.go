@@ -44,6 +44,12 @@ func (p *ClientGoPlugin) Info() core.PluginInfo {
 						Description: translate("Comma-separated list of contracts for filtering (e.g., \"Contract1,Contract2\")"),
 						Required:    false,
 					},
+					{
+						Name:        "module",
+						Type:        "string",
+						Description: translate("Module path to generate the client as a standalone Go module in the output directory (e.g., \"github.com/acme/svc-client\")"),
+						Required:    false,
+					},
 					{
 						Name:        "doc-file",
 						Type:        "string",
@@ -140,6 +146,14 @@ func (p *ClientGoPlugin) Execute(rootDir string, request core.Storage, path ...s
 		docOpts.FilePath = filepath.Join(outDir, "README.md")
 	}
 
+	// Получаем путь модуля клиента
+	var moduleOpts generator.ModuleOptions
+	if moduleVal, ok := request.Get("module"); ok {
+		if modulePath, ok := moduleVal.(string); ok {
+			moduleOpts.Path = strings.TrimSpace(modulePath)
+		}
+	}
+
 	// Получаем список контрактов для фильтрации
 	var contracts []string
 	contractsVal, ok := request.Get("contracts")
@@ -173,7 +187,7 @@ func (p *ClientGoPlugin) Execute(rootDir string, request core.Storage, path ...s
 	}
 
 	// Генерируем клиент
-	if err := generator.GenerateClient(coreProject, outDir, rootDir, docOpts); err != nil {
+	if err := generator.GenerateClient(coreProject, outDir, rootDir, docOpts, moduleOpts); err != nil {
 		logger.Error(fmt.Sprintf("failed to generate Go client: error=%v", err))
 		return nil, fmt.Errorf("generate Go client: %w", err)
 	}
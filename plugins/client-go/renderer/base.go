@@ -22,20 +22,33 @@ type ClientRenderer struct {
 	project     *core.Project
 	outDir      string
 	projectRoot string
+	modulePath  string
 }
 
 // NewClientRenderer создает новый рендерер клиента.
-func NewClientRenderer(project *core.Project, outDir, projectRoot string) *ClientRenderer {
+// Непустой modulePath генерирует клиент как отдельный модуль с корнем в outDir.
+func NewClientRenderer(project *core.Project, outDir, projectRoot, modulePath string) *ClientRenderer {
 	return &ClientRenderer{
 		project:     project,
 		outDir:      outDir,
 		projectRoot: projectRoot,
+		modulePath:  modulePath,
 	}
 }
 
 // pkgPath возвращает путь пакета для указанной директории.
 func (r *ClientRenderer) pkgPath(dir string) string {
 
+	// Для отдельного модуля клиента пути пакетов строятся от outDir - корня модуля
+	if r.modulePath != "" {
+		if rel, err := filepath.Rel(r.outDir, dir); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			if rel == "." {
+				return r.modulePath
+			}
+			return r.modulePath + "/" + filepath.ToSlash(rel)
+		}
+	}
+
 	// В WASM файловая система монтируется в корень "/", поэтому используем относительные пути
 	// dir уже является относительным путем от rootDir
 	// projectRoot в WASM всегда "." (корень файловой системы)
@@ -62,6 +75,10 @@ func (r *ClientRenderer) pkgCopyTo(pkg, dst string) (err error) {
 		return
 	}
 	for _, entry := range entries {
+		// Тесты встроенного пакета не копируются в клиент
+		if strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
 		var fileContent []byte
 		if fileContent, err = pkgFiles.ReadFile(fmt.Sprintf("%s/%s", pkgPath, entry.Name())); err != nil {
 			return
@@ -110,6 +127,30 @@ func (r *ClientRenderer) HasMetrics() bool {
 	return false
 }
 
+// HasTrace проверяет, есть ли контракты с трассировкой.
+func (r *ClientRenderer) HasTrace() bool {
+
+	for _, contract := range r.project.Contracts {
+		if r.contains(contract.Annotations, TagTrace) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasIdempotent проверяет, есть ли идемпотентные методы.
+func (r *ClientRenderer) HasIdempotent() bool {
+
+	for _, contract := range r.project.Contracts {
+		for _, method := range contract.Methods {
+			if r.contains(method.Annotations, TagIdempotent) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // contains проверяет, содержится ли ключ в map.
 func (r *ClientRenderer) contains(m map[string]string, key string) bool {
 	if m == nil {
@@ -28,34 +28,113 @@ func (r *ClientRenderer) RenderClientBatch() error {
 
 	srcFile.Line().Type().Id("rpcCallback").Func().Params(Err().Error(), Id("response").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "ResponseRPC"))
 
-	srcFile.Line().Func().Params(Id("cli").Op("*").
+	srcFile.Line().Comment("DefaultMaxBatchSize - максимальный размер пакета по умолчанию (совпадает с MaxBatchSize сервера по умолчанию).")
+	srcFile.Const().Id("DefaultMaxBatchSize").Op("=").Lit(100)
+
+	srcFile.Line().Comment("WithMaxBatchSize задаёт максимальный размер пакета: Batch отправляет запросы пакетами не больше size (size <= 0 - одним пакетом).")
+	srcFile.Func().Id("WithMaxBatchSize").Params(Id("size").Int()).Params(Id("Option")).Block(
+		Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+			Id("cli").Dot("maxBatchSize").Op("=").Id("size"),
+		),
+	)
+
+	srcFile.Line().Comment("Batch отправляет запросы пакетами не больше MaxBatchSize и вызывает обработчики ответов в порядке запросов.")
+	srcFile.Comment("Каждый пакет учитывается общими ограничениями клиента и ограничениями метода \"batch\" (WithMethodLimits).")
+	srcFile.Func().Params(Id("cli").Op("*").
 		Id("Client")).Id("Batch").
 		Params(Id(_ctx_).Qual(PackageContext, "Context"), Id("requests").Op("...").Id("RequestRPC")).BlockFunc(func(bg *Group) {
 		bg.Line()
-		bg.Var().Id("rpcRequests").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "RequestsRPC")
-		bg.Id("callbacks").Op(":=").Make(Map(Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "ID")).Id("rpcCallback"))
-		bg.For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
-			Id("rpcRequests").Op("=").Append(Id("rpcRequests"), Id("request").Dot("rpcRequest")),
-			Id("callbacks").Op("[").Id("request").Dot("rpcRequest").Dot("ID").Op("]").Op("=").Id("request").Dot("retHandler"),
+		if r.HasTrace() {
+			bg.Var().Err().Error()
+			bg.List(Id(_ctx_), Id("span")).Op(":=").Id("cli").Dot("startSpan").Call(
+				Id(_ctx_),
+				Lit("batch"),
+				Qual(PackageAttributeOTEL, "String").Call(Lit("rpc.system"), Lit("jsonrpc")),
+				Qual(PackageAttributeOTEL, "String").Call(Lit("rpc.jsonrpc.version"), Lit("2.0")),
+				Qual(PackageAttributeOTEL, "Int").Call(Lit("rpc.jsonrpc.batch_size"), Len(Id("requests"))),
+			)
+			bg.Defer().Func().Params().Block(
+				Id("endSpan").Call(Id("span"), Err()),
+			).Call()
+		}
+		if r.HasMetrics() && r.HasIdempotent() {
+			bg.Id(_ctx_).Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "WithRetryObserver").Call(Id(_ctx_), Id("cli").Dot("retryObserver").Call(Lit("client"), Lit("batch")))
+		}
+		bg.Id("size").Op(":=").Id("cli").Dot("maxBatchSize")
+		bg.If(Id("size").Op("<=").Lit(0)).Block(
+			Id("size").Op("=").Len(Id("requests")),
 		)
-		bg.Var().Err().Error()
-		bg.Var().Id("rpcResponses").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "ResponsesRPC")
-		bg.List(Id("rpcResponses"), Err()).Op("=").Id("cli").Dot("rpc").Dot("CallBatch").Call(Id(_ctx_), Id("rpcRequests"))
-		bg.If(Err().Op("!=").Nil().Op("||").Id("rpcResponses").Op("==").Nil()).Block(
-			For(List(Id("_"), Id("callback")).Op(":=").Range().Id("callbacks")).Block(
-				Id("callback").Call(Err(), Nil()),
+		bg.For(Len(Id("requests")).Op(">").Lit(0)).BlockFunc(func(fg *Group) {
+			fg.Id("chunk").Op(":=").Id("requests").Index(Empty(), Min(Id("size"), Len(Id("requests"))))
+			fg.Id("requests").Op("=").Id("requests").Index(Len(Id("chunk")), Empty())
+			if !r.HasTrace() {
+				// Ошибки пакета передаются обработчикам ответов
+				fg.Id("_").Op("=").Id("cli").Dot("batch").Call(Id(_ctx_), Id("chunk"))
+				return
+			}
+			fg.If(Id("chunkErr").Op(":=").Id("cli").Dot("batch").Call(Id(_ctx_), Id("span"), Id("chunk")).Op(";").Id("chunkErr").Op("!=").Nil()).Block(
+				Err().Op("=").Id("chunkErr"),
+			)
+		})
+	})
+
+	srcFile.Line().Comment("batch отправляет один пакет запросов.")
+	srcFile.Func().Params(Id("cli").Op("*").Id("Client")).Id("batch").ParamsFunc(func(pg *Group) {
+		pg.Id(_ctx_).Qual(PackageContext, "Context")
+		if r.HasTrace() {
+			pg.Id("span").Qual(PackageTrace, "Span")
+		}
+		pg.Id("requests").Index().Id("RequestRPC")
+	}).Params(Err().Error()).BlockFunc(func(bg *Group) {
+		bg.Line()
+		bg.Var().Id("release").Func().Params()
+		bg.If(List(Id("release"), Err()).Op("=").Id("cli").Dot("acquire").Call(Id(_ctx_), Lit("batch")).Op(";").Err().Op("!=").Nil()).Block(
+			For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
+				If(Id("request").Dot("retHandler").Op("!=").Nil()).Block(
+					Id("request").Dot("retHandler").Call(Err(), Nil()),
+				),
 			),
 			Return(),
 		)
-		bg.For(List(Id("id"), Id("response")).Op(":=").Range().Id("rpcResponses").Dot("AsMap").Call()).Block(
-			If(Id("callback").Op(":=").Id("callbacks").Op("[").Id("id").Op("]").Op(";").Id("callback").Op("!=").Nil()).Block(
-				If(Id("response").Op("!=").Nil().Op("&&").Id("response").Dot("Error").Op("!=").Nil()).Block(
-					Id("callback").Call(Qual(PackageFmt, "Errorf").Call(Lit("%s"), Id("response").Dot("Error").Dot("Message")), Id("response")),
-				).Else().Block(
-					Id("callback").Call(Nil(), Id("response")),
+		bg.Defer().Id("release").Call()
+		bg.Id("rpcRequests").Op(":=").Make(Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "RequestsRPC"), Lit(0), Len(Id("requests")))
+		bg.For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).BlockFunc(func(fg *Group) {
+			fg.Id("rpcRequests").Op("=").Append(Id("rpcRequests"), Id("request").Dot("rpcRequest"))
+			if r.HasTrace() {
+				fg.Id("batchEvent").Call(Id("span"), Lit("request"), Id("request").Dot("rpcRequest").Dot("ID"), Id("request").Dot("rpcRequest").Dot("Method"), Nil())
+			}
+		})
+		bg.Var().Id("rpcResponses").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "ResponsesRPC")
+		bg.List(Id("rpcResponses"), Err()).Op("=").Id("cli").Dot("rpc").Dot("CallBatch").Call(Id(_ctx_), Id("rpcRequests"))
+		bg.If(Err().Op("!=").Nil().Op("||").Id("rpcResponses").Op("==").Nil()).Block(
+			For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
+				If(Id("request").Dot("retHandler").Op("!=").Nil()).Block(
+					Id("request").Dot("retHandler").Call(Err(), Nil()),
 				),
 			),
+			Return(),
 		)
+		bg.Id("responses").Op(":=").Id("rpcResponses").Dot("AsMap").Call()
+		bg.For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).BlockFunc(func(fg *Group) {
+			fg.List(Id("response"), Id("found")).Op(":=").Id("responses").Index(Id("request").Dot("rpcRequest").Dot("ID"))
+			fg.If(Op("!").Id("found")).Block(
+				Continue(),
+			)
+			if r.HasTrace() {
+				fg.If(Id("response").Op("!=").Nil()).Block(
+					Id("batchEvent").Call(Id("span"), Lit("response"), Id("request").Dot("rpcRequest").Dot("ID"), Lit(""), Id("response").Dot("Error")),
+				)
+			}
+			fg.If(Id("request").Dot("retHandler").Op("==").Nil()).Block(
+				Continue(),
+			)
+			fg.If(Id("response").Op("!=").Nil().Op("&&").Id("response").Dot("Error").Op("!=").Nil()).Block(
+				Id("request").Dot("retHandler").Call(Qual(PackageFmt, "Errorf").Call(Lit("%s"), Id("response").Dot("Error").Dot("Message")), Id("response")),
+			).Else().Block(
+				Id("request").Dot("retHandler").Call(Nil(), Id("response")),
+			)
+		})
+		bg.Return()
 	})
 	return srcFile.Save(path.Join(outDir, "batch.go"))
 }
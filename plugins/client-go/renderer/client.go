@@ -4,8 +4,10 @@ package renderer
 
 import (
 	"fmt"
+	"net/http"
 	"path"
 	"path/filepath"
+	"strings"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
 
@@ -70,12 +72,64 @@ func (r *ClientRenderer) RenderClient() error {
 				dict[Id("logOnError")] = False()
 				dict[Id("logRequests")] = False()
 				dict[Id("name")] = Id("name")
+				if r.HasJsonRPC() {
+					dict[Id("maxBatchSize")] = Id("DefaultMaxBatchSize")
+				}
 			}))
 			bg.Id("cli").Dot("applyOpts").Call(Id("opts"))
+			if r.HasJsonRPC() || r.HasHTTP() {
+				copyClient := Id("cli").Dot("hedge").Op("!=").Nil().Op("||").Id("cli").Dot("inProcess").Op("!=").Nil().Op("||").Id("cli").Dot("recordTo").Op("!=").Lit("").Op("||").Id("cli").Dot("replayFrom").Op("!=").Lit("")
+				if r.HasTrace() {
+					copyClient = copyClient.Op("||").Id("cli").Dot("tracer").Op("!=").Nil()
+				}
+				bg.If(copyClient).Block(
+					Id("httpClient").Op(":=").Op("*").Id("cli").Dot("httpClient"),
+					Id("cli").Dot("httpClient").Op("=").Op("&").Id("httpClient"),
+				)
+			}
 			if r.HasJsonRPC() {
 				bg.Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "ClientHTTP").Call(Id("cli").Dot("httpClient")))
 				bg.Id("cli").Dot("rpc").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewClient").Call(Id("endpoint"), Id("cli").Dot("rpcOpts").Op("..."))
 			}
+			if r.HasJsonRPC() || r.HasHTTP() {
+				bg.If(Id("cli").Dot("inProcess").Op("!=").Nil()).Block(
+					Id("cli").Dot("httpClient").Dot("Transport").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewInProcessTransport").Call(Id("cli").Dot("inProcess")),
+				)
+				bg.If(Id("cli").Dot("replayFrom").Op("!=").Lit("")).Block(
+					Id("cli").Dot("httpClient").Dot("Transport").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewReplayTransport").Call(Id("cli").Dot("replayFrom")),
+				).Else().If(Id("cli").Dot("recordTo").Op("!=").Lit("")).Block(
+					Id("cli").Dot("recorder").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewRecordTransport").Call(Id("cli").Dot("recordTo"), Id("cli").Dot("httpClient").Dot("Transport")),
+					Id("cli").Dot("httpClient").Dot("Transport").Op("=").Id("cli").Dot("recorder"),
+				)
+				bg.If(Id("cli").Dot("hedge").Op("!=").Nil()).Block(
+					Id("cli").Dot("httpClient").Dot("Transport").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewHedgedTransport").Call(Id("cli").Dot("httpClient").Dot("Transport"), Op("*").Id("cli").Dot("hedge")),
+				)
+				if r.HasTrace() {
+					bg.If(Id("cli").Dot("tracer").Op("!=").Nil()).Block(
+						If(Id("cli").Dot("propagator").Op("==").Nil()).Block(
+							Id("cli").Dot("propagator").Op("=").Qual(PackagePropagationOTEL, "NewCompositeTextMapPropagator").Call(
+								Qual(PackagePropagationOTEL, "TraceContext").Values(),
+								Qual(PackagePropagationOTEL, "Baggage").Values(),
+							),
+						),
+						Id("cli").Dot("httpClient").Dot("Transport").Op("=").Op("&").Id("tracingTransport").Values(Dict{
+							Id("base"):       Id("cli").Dot("httpClient").Dot("Transport"),
+							Id("propagator"): Id("cli").Dot("propagator"),
+						}),
+					)
+				}
+				if r.HasMetrics() {
+					bg.If(Id("cli").Dot("metrics").Op("!=").Nil()).Block(
+						Id("cli").Dot("breakerListeners").Op("=").Append(Id("cli").Dot("breakerListeners"), Id("cli").Dot("observeBreaker")),
+					)
+				}
+				bg.If(Id("cli").Dot("breakerPolicy").Op("!=").Nil().Op("||").Len(Id("cli").Dot("breakerPolicies")).Op(">").Lit(0)).Block(
+					Id("cli").Dot("breakers").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewBreakers").Call(Id("cli").Dot("breakerPolicy"), Id("cli").Dot("breakerPolicies"), Id("cli").Dot("breakerListeners").Op("...")),
+				)
+				bg.If(Id("cli").Dot("limitPolicy").Op("!=").Nil().Op("||").Len(Id("cli").Dot("limitPolicies")).Op(">").Lit(0)).Block(
+					Id("cli").Dot("limiters").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewLimiters").Call(Id("cli").Dot("limitPolicy"), Id("cli").Dot("limitPolicies")),
+				)
+			}
 
 			bg.Return()
 		})
@@ -95,6 +149,21 @@ func (r *ClientRenderer) RenderClient() error {
 			)
 		}
 	}
+	if r.HasJsonRPC() || r.HasHTTP() {
+		srcFile.Line().Comment("Close сохраняет кассету WithRecording. Клиент без записи закрывать не требуется.")
+		srcFile.Func().Params(Id("cli").Op("*").Id("Client")).Id("Close").Params().Params(Err().Error()).Block(
+			If(Id("cli").Dot("recorder").Op("!=").Nil()).Block(
+				Return(Id("cli").Dot("recorder").Dot("Close").Call()),
+			),
+			Return(),
+		)
+	}
+	if r.HasIdempotent() {
+		srcFile.Line().Comment("WithIdempotencyKey задаёт ключ идемпотентности для вызовов с этим контекстом (например, для повторов на уровне приложения).")
+		srcFile.Func().Id("WithIdempotencyKey").Params(Id(_ctx_).Qual(PackageContext, "Context"), Id("key").String()).Qual(PackageContext, "Context").Block(
+			Return(Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "WithIdempotencyKey").Call(Id(_ctx_), Id("key"))),
+		)
+	}
 	return srcFile.Save(path.Join(outDir, "client.go"))
 }
 
@@ -122,13 +191,90 @@ func (r *ClientRenderer) clientStructFunc(outDir string) Code {
 		}
 		if r.HasJsonRPC() || r.HasHTTP() {
 			sg.Id("allowUnknownFields").Bool()
+			sg.Id("codec").Id("Codec")
+		}
+		if r.HasJsonRPC() || r.HasHTTP() {
+			sg.Line().Id("breakerPolicy").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "BreakerPolicy")
+			sg.Id("breakerPolicies").Map(String()).Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "BreakerPolicy")
+			sg.Id("breakerListeners").Index().Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "BreakerListener")
+			sg.Id("breakers").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "Breakers")
+			sg.Id("hedge").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "HedgePolicy")
+			sg.Id("limitPolicy").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "LimitPolicy")
+			sg.Id("limitPolicies").Map(String()).Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "LimitPolicy")
+			sg.Id("limiters").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "Limiters")
+			sg.Id("inProcess").Qual(PackageHttp, "Handler")
+			sg.Id("recordTo").String()
+			sg.Id("recorder").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "RecordTransport")
+			sg.Id("replayFrom").String()
+		}
+		if r.HasJsonRPC() {
+			sg.Id("maxBatchSize").Int()
+		}
+		if r.HasIdempotent() {
+			sg.Id("retry").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "RetryPolicy")
 		}
 		if r.HasMetrics() {
 			sg.Line().Id("metrics").Op("*").Id("Metrics")
 		}
+		if r.HasTrace() {
+			sg.Line().Id("tracer").Qual(PackageTrace, "Tracer")
+			sg.Id("propagator").Qual(PackagePropagationOTEL, "TextMapPropagator")
+		}
+	})
+}
+
+// allowRetry генерирует разрешение повторов идемпотентного метода и счётчик повторов в метриках.
+func (r *ClientRenderer) allowRetry(contract *core.Contract, method *core.Method, outDir string) Code {
+
+	return CustomFunc(Options{Multi: true}, func(g *Group) {
+		g.Id(_ctx_).Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "AllowRetry").Call(Id(_ctx_))
+		if r.HasMetrics() && r.contains(contract.Annotations, TagMetrics) {
+			g.Id(_ctx_).Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "WithRetryObserver").Call(Id(_ctx_), Id("cli").Dot("retryObserver").Call(Lit("client_"+r.contractNameToLowerCamel(contract)), Lit(r.methodNameToLowerCamel(method))))
+		}
 	})
 }
 
+// breakerCall генерирует проверку автоматического выключателя метода и учёт результата вызова.
+func (r *ClientRenderer) breakerCall(cli Code, contract *core.Contract, method *core.Method) Code {
+
+	return CustomFunc(Options{Multi: true}, func(g *Group) {
+		g.Var().Id("_breakerDone").Func().Params(Error())
+		g.If(List(Id("_breakerDone"), Err()).Op("=").Add(cli).Dot("breaker").Call(Lit(contract.Name + "." + method.Name)).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		)
+		g.Defer().Func().Params().Block(
+			Id("_breakerDone").Call(Err()),
+		).Call()
+	})
+}
+
+// limitCall генерирует ожидание ограничителей частоты и числа одновременных вызовов метода.
+func (r *ClientRenderer) limitCall(cli Code, contract *core.Contract, method *core.Method) Code {
+
+	return CustomFunc(Options{Multi: true}, func(g *Group) {
+		g.Var().Id("_release").Func().Params()
+		g.If(List(Id("_release"), Err()).Op("=").Add(cli).Dot("acquire").Call(Id(_ctx_), Lit(contract.Name+"."+method.Name)).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		)
+		g.Defer().Id("_release").Call()
+	})
+}
+
+// methodIsHedged проверяет, можно ли дублировать запросы метода: чтение (аннотация hedge, REST GET или HEAD)
+// без ключа идемпотентности - копия запроса с тем же ключом отклоняется сервером как конфликт.
+func (r *ClientRenderer) methodIsHedged(method *core.Method, httpMethod string) bool {
+
+	if r.contains(method.Annotations, TagIdempotent) {
+		return false
+	}
+	return r.contains(method.Annotations, TagHedge) || strings.EqualFold(httpMethod, http.MethodGet) || strings.EqualFold(httpMethod, http.MethodHead)
+}
+
+// allowHedge генерирует разрешение дублирующих запросов для чтения.
+func (r *ClientRenderer) allowHedge(outDir string) Code {
+	return Id(_ctx_).Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "AllowHedge").Call(Id(_ctx_))
+}
+
 // findContract находит контракт по имени.
 func (r *ClientRenderer) findContract(name string) *core.Contract {
 	for _, contract := range r.project.Contracts {
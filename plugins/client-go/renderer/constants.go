@@ -11,6 +11,7 @@ const (
 	PackageTLS                = "crypto/tls"
 	PackageTime               = "time"
 	PackageHttp               = "net/http"
+	PackageIter               = "iter"
 	PackageContext            = "context"
 	PackageStrconv            = "strconv"
 	PackageBytes              = "bytes"
@@ -22,10 +23,16 @@ const (
 	PackageSlog               = "log/slog"
 	PackagePrometheus         = "github.com/prometheus/client_golang/prometheus"
 	PackagePrometheusAuto     = "github.com/prometheus/client_golang/prometheus/promauto"
+	PackageOTEL               = "go.opentelemetry.io/otel"
+	PackageTrace              = "go.opentelemetry.io/otel/trace"
+	PackageCodesOTEL          = "go.opentelemetry.io/otel/codes"
+	PackageAttributeOTEL      = "go.opentelemetry.io/otel/attribute"
+	PackagePropagationOTEL    = "go.opentelemetry.io/otel/propagation"
 	tagPackageJSON            = "packageJSON"
 	TagServerJsonRPC          = "jsonRPC-server"
 	TagServerHTTP             = "http-server"
 	TagMetrics                = "metrics"
+	TagTrace                  = "trace"
 	TagHttpEnableInlineSingle = "enableInlineSingle"
 	tagSummary                = "summary"
 	tagDesc                   = "desc"
@@ -39,4 +46,22 @@ const (
 	TagHttpPath               = "http-path"
 	TagHttpPrefix             = "http-prefix"
 	TagHttpSuccess            = "http-success"
+	TagHttpMultipart          = "http-multipart"
+	TagHttpBody               = "http-body"
+	TagHttpContentType        = "http-content-type"
+	TagHttpDownload           = "http-download"
+	TagIdempotent             = "idempotent"
+	TagHedge                  = "hedge"
+	TagVersion                = "version"
+	TagDeprecated             = "deprecated"
+	TagSunset                 = "sunset"
+	TagPaginate               = "paginate"
+	TagPaginateCursor         = "cursor"
+	TagPaginateNext           = "next"
+	TagPaginateItems          = "items"
+	TagPaginatePageSize       = "page-size"
+	PackageMime               = "mime"
+	PackageMultipart          = "mime/multipart"
+	PackageTextproto          = "net/textproto"
+	PackageBufio              = "bufio"
 )
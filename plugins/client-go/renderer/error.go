@@ -36,6 +36,8 @@ func (r *ClientRenderer) RenderClientError() error {
 		Return(Id("jsonrpcError")),
 	)
 
+	r.renderTypedErrors(&srcFile)
+
 	return srcFile.Save(path.Join(outDir, "error.go"))
 }
 
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"context"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/core"
+)
+
+// bodyArgs возвращает аргументы, передаваемые в теле HTTP запроса.
+func (r *ClientRenderer) bodyArgs(method *core.Method) []*core.Variable {
+
+	argsMappings := r.argParamMap(method)
+	cookieMappings := r.varCookieMap(method)
+	headerMappings := r.varHeaderMap(method)
+	pathParams := r.argPathMap(method)
+	vars := make([]*core.Variable, 0)
+	for _, arg := range r.argsWithoutContext(method) {
+		if _, exists := argsMappings[arg.Name]; exists {
+			continue
+		}
+		if _, exists := cookieMappings[arg.Name]; exists {
+			continue
+		}
+		if _, exists := headerMappings[arg.Name]; exists {
+			continue
+		}
+		if _, exists := pathParams[arg.Name]; exists {
+			continue
+		}
+		vars = append(vars, arg)
+	}
+	return vars
+}
+
+// multipartField генерирует запись аргумента в multipart форму.
+func (r *ClientRenderer) multipartField(ctx context.Context, arg *core.Variable) Code {
+
+	argID := Id(ToLowerCamel(arg.Name))
+	switch {
+	case isStreamVar(arg):
+		return If(Err().Op("=").Id("writeFormFile").Call(Id("mw"), Lit(arg.Name), argID).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		)
+	case isBinaryVar(arg):
+		return If(Err().Op("=").Id("writeFormFile").Call(Id("mw"), Lit(arg.Name), Qual(PackageBytes, "NewReader").Call(argID)).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		)
+	case arg.NumberOfPointers > 0:
+		return If(argID.Clone().Op("!=").Nil()).Block(
+			If(Err().Op("=").Id("mw").Dot("WriteField").Call(Lit(arg.Name), Qual(PackageFmt, "Sprint").Call(Op("*").Add(argID))).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+		)
+	default:
+		return If(Err().Op("=").Id("mw").Dot("WriteField").Call(Lit(arg.Name), r.varToString(ctx, arg)).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		)
+	}
+}
+
+// rawBody возвращает тело запроса для режима raw.
+func (r *ClientRenderer) rawBody(method *core.Method) Code {
+
+	for _, arg := range r.bodyArgs(method) {
+		if !isBinaryVar(arg) {
+			continue
+		}
+		if isStreamVar(arg) {
+			return Id(ToLowerCamel(arg.Name))
+		}
+		return Qual(PackageBytes, "NewReader").Call(Id(ToLowerCamel(arg.Name)))
+	}
+	return Nil()
+}
+
+// streamResponse генерирует присваивание результатов метода, отдающего файл.
+func (r *ClientRenderer) streamResponse(method *core.Method, streamRet *core.Variable) Code {
+
+	bg := Line()
+	bg.Id(ToLowerCamel(streamRet.Name)).Op("=").Id("httpResp").Dot("Body").Line()
+	if fileName, ok := method.Annotations[TagHttpDownload]; ok && fileName != "" {
+		for _, ret := range r.resultsWithoutError(method) {
+			if ret.Name == fileName && ret.TypeID == "string" && ret.NumberOfPointers == 0 {
+				bg.If(List(Id("_"), Id("params"), Id("parseErr")).Op(":=").Qual(PackageMime, "ParseMediaType").Call(Id("httpResp").Dot("Header").Dot("Get").Call(Lit("Content-Disposition"))).Op(";").Id("parseErr").Op("==").Nil()).Block(
+					Id(ToLowerCamel(ret.Name)).Op("=").Id("params").Index(Lit("filename")),
+				).Line()
+			}
+		}
+	}
+	for varName, headerName := range r.varHeaderMap(method) {
+		for _, ret := range r.resultsWithoutError(method) {
+			if ret.Name == varName && ret.TypeID == "string" && ret.NumberOfPointers == 0 {
+				bg.Id(ToLowerCamel(ret.Name)).Op("=").Id("httpResp").Dot("Header").Dot("Get").Call(Lit(headerName)).Line()
+			}
+		}
+	}
+	return bg
+}
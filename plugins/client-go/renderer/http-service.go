@@ -20,11 +20,15 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 
 	c := Comment(fmt.Sprintf("%s performs the %s operation.", method.Name, method.Name))
 	c.Line()
+	if _, _, deprecated := r.methodDeprecated(contract, method); deprecated {
+		c.Comment("").Line().Add(r.deprecatedDoc(contract, method))
+	}
 	c.Func().Params(Id("cli").Op("*").Id("Client" + contract.Name)).
 		Id(method.Name).
 		Params(r.funcDefinitionParams(ctx, method.Args)).Params(r.funcDefinitionParams(ctx, method.Results)).
 		BlockFunc(func(bg *Group) {
 			bg.Line()
+			bg.Add(r.traceSpan(Id("cli").Dot("Client"), contract, method, "http"))
 			if r.HasMetrics() && r.contains(contract.Annotations, TagMetrics) {
 				bg.Defer().Func().Params(Id("_begin").Qual(PackageTime, "Time")).Block(
 					If(Id("cli").Dot("Client").Dot("metrics").Op("==").Nil()).Block(
@@ -83,6 +87,8 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 					),
 				).Call(Qual(PackageTime, "Now").Call())
 			}
+			bg.Add(r.limitCall(Id("cli").Dot("Client"), contract, method))
+			bg.Add(r.breakerCall(Id("cli").Dot("Client"), contract, method))
 			var httpMethod string
 			if r.contains(method.Annotations, TagMethodHTTP) {
 				if val, ok := method.Annotations[TagMethodHTTP]; ok {
@@ -121,7 +127,7 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 			headerMappings := r.varHeaderMap(method)
 
 			// Формируем URL
-			fullURLPath := path.Join(svcPrefix, methodPath)
+			fullURLPath := path.Join(svcPrefix, r.methodVersion(contract, method), methodPath)
 			bg.Var().Id("urlStr").String()
 			if len(pathParams) > 0 {
 				bg.Id("urlPath").Op(":=").Lit(fullURLPath)
@@ -180,7 +186,20 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 				hasBody = true
 				break
 			}
-			if hasBody {
+			bodyMode := r.methodBodyMode(method)
+			streamRet := r.streamResult(method)
+			switch {
+			case hasBody && bodyMode == bodyModeMultipart:
+				bg.Var().Id("reqBody").Qual(PackageBytes, "Buffer")
+				bg.Id("mw").Op(":=").Qual(PackageMultipart, "NewWriter").Call(Op("&").Id("reqBody"))
+				for _, arg := range r.bodyArgs(method) {
+					bg.Add(r.multipartField(ctx, arg))
+				}
+				bg.If(Err().Op("=").Id("mw").Dot("Close").Call().Op(";").Err().Op("!=").Nil()).Block(
+					Return(),
+				)
+			case hasBody && bodyMode == bodyModeRaw:
+			case hasBody:
 				bg.Var().Id("reqBody").Qual(PackageBytes, "Buffer")
 				bg.Id("_request").Op(":=").Id(r.requestStructName(contract, method)).Values(DictFunc(func(dict Dict) {
 					for idx, arg := range argsWithoutCtx {
@@ -203,16 +222,32 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 				if val, ok := contract.Annotations[tagPackageJSON]; ok {
 					jsonPkg = val
 				}
-				bg.If(Err().Op("=").Qual(jsonPkg, "NewEncoder").Call(Op("&").Id("reqBody")).Dot("Encode").Call(Id("_request")).Op(";").Err().Op("!=").Nil()).Block(
+				bg.If(Id("cli").Dot("Client").Dot("codec").Op("!=").Nil()).Block(
+					Var().Id("data").Index().Byte(),
+					If(List(Id("data"), Err()).Op("=").Id("cli").Dot("Client").Dot("codec").Dot("Marshal").Call(Id("_request")).Op(";").Err().Op("!=").Nil()).Block(
+						Return(),
+					),
+					Id("reqBody").Dot("Write").Call(Id("data")),
+				).Else().If(Err().Op("=").Qual(jsonPkg, "NewEncoder").Call(Op("&").Id("reqBody")).Dot("Encode").Call(Id("_request")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
 			}
 
 			// Создаём HTTP запрос
+			if r.contains(method.Annotations, TagIdempotent) {
+				bg.Id(_ctx_).Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "EnsureIdempotencyKey").Call(Id(_ctx_))
+				bg.Add(r.allowRetry(contract, method, outDir))
+			}
+			if r.methodIsHedged(method, httpMethod) {
+				bg.Add(r.allowHedge(outDir))
+			}
 			bg.Var().Id("httpReq").Op("*").Qual(PackageHttp, "Request")
-			if hasBody {
+			switch {
+			case hasBody && bodyMode == bodyModeRaw:
+				bg.List(Id("httpReq"), Err()).Op("=").Qual(PackageHttp, "NewRequestWithContext").Call(Id(_ctx_), Lit(httpMethod), Id("urlStr"), r.rawBody(method))
+			case hasBody:
 				bg.List(Id("httpReq"), Err()).Op("=").Qual(PackageHttp, "NewRequestWithContext").Call(Id(_ctx_), Lit(httpMethod), Id("urlStr"), Qual(PackageBytes, "NewReader").Call(Id("reqBody").Dot("Bytes").Call()))
-			} else {
+			default:
 				bg.List(Id("httpReq"), Err()).Op("=").Qual(PackageHttp, "NewRequestWithContext").Call(Id(_ctx_), Lit(httpMethod), Id("urlStr"), Nil())
 			}
 			bg.If(Err().Op("!=").Nil()).Block(
@@ -220,9 +255,29 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 			)
 
 			// Устанавливаем заголовки
-			bg.Id("httpReq").Dot("Header").Dot("Set").Call(Lit("Accept"), Lit("application/json"))
-			if hasBody {
-				bg.Id("httpReq").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Lit("application/json"))
+			if streamRet != nil {
+				bg.Id("httpReq").Dot("Header").Dot("Set").Call(Lit("Accept"), Lit("*/*"))
+			} else {
+				bg.If(Id("cli").Dot("Client").Dot("codec").Op("!=").Nil()).Block(
+					Id("httpReq").Dot("Header").Dot("Set").Call(Lit("Accept"), Id("cli").Dot("Client").Dot("codec").Dot("ContentType").Call()),
+				).Else().Block(
+					Id("httpReq").Dot("Header").Dot("Set").Call(Lit("Accept"), Lit("application/json")),
+				)
+			}
+			switch {
+			case hasBody && bodyMode == bodyModeMultipart:
+				bg.Id("httpReq").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Id("mw").Dot("FormDataContentType").Call())
+			case hasBody && bodyMode == bodyModeRaw:
+				bg.Id("httpReq").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Lit(r.rawContentType(method)))
+			case hasBody:
+				bg.If(Id("cli").Dot("Client").Dot("codec").Op("!=").Nil()).Block(
+					Id("httpReq").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Id("cli").Dot("Client").Dot("codec").Dot("ContentType").Call()),
+				).Else().Block(
+					Id("httpReq").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Lit("application/json")),
+				)
+			}
+			if r.contains(method.Annotations, TagIdempotent) {
+				bg.Id("httpReq").Dot("Header").Dot("Set").Call(Lit("Idempotency-Key"), Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "IdempotencyKey").Call(Id(_ctx_)))
 			}
 			for paramName, headerName := range headerMappings {
 				if paramVar := r.argByName(method, paramName); paramVar != nil {
@@ -279,7 +334,11 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 
 			// Выполняем запрос
 			bg.Var().Id("httpResp").Op("*").Qual(PackageHttp, "Response")
-			bg.List(Id("httpResp"), Err()).Op("=").Id("cli").Dot("httpClient").Dot("Do").Call(Id("httpReq"))
+			if r.contains(method.Annotations, TagIdempotent) {
+				bg.List(Id("httpResp"), Err()).Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "DoRetry").Call(Id("cli").Dot("httpClient"), Id("httpReq"), Id("cli").Dot("Client").Dot("retry"))
+			} else {
+				bg.List(Id("httpResp"), Err()).Op("=").Id("cli").Dot("httpClient").Dot("Do").Call(Id("httpReq"))
+			}
 
 			// Логируем ошибку, если включено
 			bg.Defer().Func().Params().Block(
@@ -292,7 +351,16 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 			bg.If(Err().Op("!=").Nil()).Block(
 				Return(),
 			)
-			bg.Defer().Id("httpResp").Dot("Body").Dot("Close").Call()
+			if streamRet != nil {
+				// Тело ответа передается вызывающему и закрывается им, кроме случая ошибки
+				bg.Defer().Func().Params().Block(
+					If(Err().Op("!=").Nil()).Block(
+						Id("_").Op("=").Id("httpResp").Dot("Body").Dot("Close").Call(),
+					),
+				).Call()
+			} else {
+				bg.Defer().Id("httpResp").Dot("Body").Dot("Close").Call()
+			}
 
 			// Вызываем AfterRequest hook, если установлен
 			bg.If(Id("cli").Dot("Client").Dot("afterRequest").Op("!=").Nil()).Block(
@@ -306,24 +374,31 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 				Var().Id("respBodyBytes").Index().Byte(),
 				List(Id("respBodyBytes"), Err()).Op("=").Qual(PackageIO, "ReadAll").Call(Id("httpResp").Dot("Body")),
 				If(Err().Op("!=").Nil()).Block(
-					Err().Op("=").Qual(PackageFmt, "Errorf").Call(
+					Err().Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewHTTPError").Call(Id("httpResp").Dot("StatusCode"), Qual(PackageFmt, "Errorf").Call(
 						Lit("HTTP error: %d. URL: %s, Method: %s"),
 						Id("httpResp").Dot("StatusCode"),
 						Id("httpReq").Dot("URL").Dot("String").Call(),
 						Id("httpReq").Dot("Method"),
-					),
+					)),
 				).Else().Block(
-					Err().Op("=").Qual(PackageFmt, "Errorf").Call(
+					Err().Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewHTTPError").Call(Id("httpResp").Dot("StatusCode"), Qual(PackageFmt, "Errorf").Call(
 						Lit("HTTP error: %d. URL: %s, Method: %s, Body: %s"),
 						Id("httpResp").Dot("StatusCode"),
 						Id("httpReq").Dot("URL").Dot("String").Call(),
 						Id("httpReq").Dot("Method"),
 						String().Call(Id("respBodyBytes")),
-					),
+					)),
+					r.typedErrorDecode(contract, method, Id("httpResp").Dot("StatusCode"), Id("httpErrorMessage").Call(Id("respBodyBytes")), Id("respBodyBytes")),
 				),
 				Return(),
 			)
 
+			if streamRet != nil {
+				bg.Add(r.streamResponse(method, streamRet))
+				bg.Return()
+				return
+			}
+
 			// Потоковое чтение JSON ответа
 			resultsWithoutErr := r.resultsWithoutError(method)
 			fieldsResult := r.fieldsResult(method)
@@ -333,13 +408,7 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 				if val, ok := contract.Annotations[tagPackageJSON]; ok {
 					jsonPkg = val
 				}
-				bg.Var().Id("decoder").Op("=").Qual(jsonPkg, "NewDecoder").Call(Id("httpResp").Dot("Body"))
-				bg.If(Op("!").Id("cli").Dot("Client").Dot("allowUnknownFields")).Block(
-					Id("decoder").Dot("DisallowUnknownFields").Call(),
-				)
-				bg.If(Err().Op("=").Id("decoder").Dot("Decode").Call(Op("&").Id("_response").Dot(ToCamel(resultsWithoutErr[0].Name))).Op(";").Err().Op("!=").Nil()).Block(
-					Return(),
-				)
+				bg.Add(r.httpDecodeResponse(Op("&").Id("_response").Dot(ToCamel(resultsWithoutErr[0].Name)), jsonPkg))
 				// fieldsResult и resultsWithoutErr имеют одинаковый порядок и количество элементов
 				for i, ret := range resultsWithoutErr {
 					if i >= len(fieldsResult) {
@@ -366,13 +435,7 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 				if val, ok := contract.Annotations[tagPackageJSON]; ok {
 					jsonPkg = val
 				}
-				bg.Var().Id("decoder").Op("=").Qual(jsonPkg, "NewDecoder").Call(Id("httpResp").Dot("Body"))
-				bg.If(Op("!").Id("cli").Dot("Client").Dot("allowUnknownFields")).Block(
-					Id("decoder").Dot("DisallowUnknownFields").Call(),
-				)
-				bg.If(Err().Op("=").Id("decoder").Dot("Decode").Call(Op("&").Id("_response")).Op(";").Err().Op("!=").Nil()).Block(
-					Return(),
-				)
+				bg.Add(r.httpDecodeResponse(Op("&").Id("_response"), jsonPkg))
 				// fieldsResult и resultsWithoutErr имеют одинаковый порядок и количество элементов
 				for i, ret := range resultsWithoutErr {
 					if i >= len(fieldsResult) {
@@ -398,3 +461,25 @@ func (r *ClientRenderer) httpClientMethodFunc(ctx context.Context, contract *cor
 		})
 	return c
 }
+
+// httpDecodeResponse генерирует декодирование тела ответа с учетом выбранного кодека.
+func (r *ClientRenderer) httpDecodeResponse(target Code, jsonPkg string) Code {
+
+	return If(Id("codec").Op(":=").Id("cli").Dot("Client").Dot("responseCodec").Call(Id("httpResp")).Op(";").Id("codec").Op("!=").Nil()).Block(
+		Var().Id("respBody").Index().Byte(),
+		If(List(Id("respBody"), Err()).Op("=").Qual(PackageIO, "ReadAll").Call(Id("httpResp").Dot("Body")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		If(Err().Op("=").Id("codec").Dot("Unmarshal").Call(Id("respBody"), target).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+	).Else().Block(
+		Var().Id("decoder").Op("=").Qual(jsonPkg, "NewDecoder").Call(Id("httpResp").Dot("Body")),
+		If(Op("!").Id("cli").Dot("Client").Dot("allowUnknownFields")).Block(
+			Id("decoder").Dot("DisallowUnknownFields").Call(),
+		),
+		If(Err().Op("=").Id("decoder").Dot("Decode").Call(target).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+	)
+}
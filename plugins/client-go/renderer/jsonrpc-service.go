@@ -19,6 +19,7 @@ func (r *ClientRenderer) jsonrpcClientMethodFunc(ctx context.Context, contract *
 		Id(method.Name).
 		Params(r.funcDefinitionParams(ctx, method.Args)).Params(r.funcDefinitionParams(ctx, method.Results)).BlockFunc(func(bg *Group) {
 
+		bg.Add(r.traceSpan(Id("cli"), contract, method, "jsonrpc"))
 		if r.HasMetrics() && r.contains(contract.Annotations, TagMetrics) {
 			bg.Line().Defer().Func().Params(Id("_begin").Qual(PackageTime, "Time")).Block(
 				If(Id("cli").Dot("metrics").Op("==").Nil()).Block(
@@ -79,6 +80,15 @@ func (r *ClientRenderer) jsonrpcClientMethodFunc(ctx context.Context, contract *
 		}
 
 		bg.Line()
+		bg.Add(r.limitCall(Id("cli"), contract, method))
+		bg.Add(r.breakerCall(Id("cli"), contract, method))
+		if r.contains(method.Annotations, TagIdempotent) {
+			bg.Id(_ctx_).Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "EnsureIdempotencyKey").Call(Id(_ctx_))
+			bg.Add(r.allowRetry(contract, method, outDir))
+		}
+		if r.methodIsHedged(method, "") {
+			bg.Add(r.allowHedge(outDir))
+		}
 		bg.Id("_request").Op(":=").Id(r.requestStructName(contract, method)).Values(DictFunc(func(dict Dict) {
 			argsWithoutCtx := r.argsWithoutContext(method)
 			fieldsArg := r.fieldsArgument(method)
@@ -90,15 +100,17 @@ func (r *ClientRenderer) jsonrpcClientMethodFunc(ctx context.Context, contract *
 		}))
 		bg.Var().Id("_response").Id(r.responseStructName(contract, method))
 		bg.Var().Id("rpcResponse").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "ResponseRPC")
-		bg.If(List(Id("rpcResponse"), Err()).Op("=").Id("cli").Dot("rpc").Dot("Call").Call(Id(_ctx_), Lit(r.contractNameToLower(contract)+"."+r.methodNameToLower(method)), Id("_request")).Op(";").Err().Op("!=").Nil().Op("||").Id("rpcResponse").Op("==").Nil()).Block(
+		bg.If(List(Id("rpcResponse"), Err()).Op("=").Id("cli").Dot("rpc").Dot("Call").Call(Id(_ctx_), Lit(r.jsonRPCMethodName(contract, method)), Id("_request")).Op(";").Err().Op("!=").Nil().Op("||").Id("rpcResponse").Op("==").Nil()).Block(
 			Return(),
 		)
 		bg.If(Id("rpcResponse").Dot("Error").Op("!=").Nil()).Block(
+			r.traceRPCError(contract),
 			If(Id("cli").Dot("errorDecoder").Op("!=").Nil()).Block(
 				Err().Op("=").Id("cli").Dot("errorDecoder").Call(Id("rpcResponse").Dot("Error").Dot("Raw").Call()),
 			).Else().Block(
 				Err().Op("=").Qual(PackageFmt, "Errorf").Call(Lit("%s"), Id("rpcResponse").Dot("Error").Dot("Message")),
 			),
+			r.typedErrorDecode(contract, method, Id("rpcResponse").Dot("Error").Dot("Code"), Id("rpcResponse").Dot("Error").Dot("Message"), Id("rpcResponse").Dot("Error").Dot("Data")),
 			Return(),
 		)
 		resp := Id("_response")
@@ -146,11 +158,11 @@ func (r *ClientRenderer) jsonrpcClientRequestFunc(ctx context.Context, contract
 
 		bg.Line()
 		bg.Id("_request").Op("=").Id("RequestRPC").Values(Dict{
-			Id("rpcRequest"): Op("&").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "RequestRPC").Values(Dict{
-				Id("ID"):      Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewID").Call(),
-				Id("JSONRPC"): Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "Version"),
-				Id("Method"):  Lit(r.contractNameToLower(contract) + "." + r.methodNameToLower(method)),
-				Id("Params"): Id(r.requestStructName(contract, method)).Values(DictFunc(func(dg Dict) {
+			Id("rpcRequest"): Op("&").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "RequestRPC").Values(DictFunc(func(rd Dict) {
+				rd[Id("ID")] = Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "NewID").Call()
+				rd[Id("JSONRPC")] = Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "Version")
+				rd[Id("Method")] = Lit(r.jsonRPCMethodName(contract, method))
+				rd[Id("Params")] = Id(r.requestStructName(contract, method)).Values(DictFunc(func(dg Dict) {
 					argsWithoutCtx := r.argsWithoutContext(method)
 					fieldsArg := r.fieldsArgument(method)
 					for idx, arg := range fieldsArg {
@@ -158,8 +170,11 @@ func (r *ClientRenderer) jsonrpcClientRequestFunc(ctx context.Context, contract
 							dg[Id(ToCamel(arg.name))] = Id(ToLowerCamel(argsWithoutCtx[idx].Name))
 						}
 					}
-				})),
-			}),
+				}))
+				if r.contains(method.Annotations, TagIdempotent) {
+					rd[Id("Idempotent")] = True()
+				}
+			})),
 		})
 		resp := Id("_response")
 		resultsWithoutErr := r.resultsWithoutError(method)
@@ -179,6 +194,7 @@ func (r *ClientRenderer) jsonrpcClientRequestFunc(ctx context.Context, contract
 						).Else().Block(
 							Err().Op("=").Qual(PackageFmt, "Errorf").Call(Lit("%s"), Id("rpcResponse").Dot("Error").Dot("Message")),
 						),
+						r.typedErrorDecode(contract, method, Id("rpcResponse").Dot("Error").Dot("Code"), Id("rpcResponse").Dot("Error").Dot("Message"), Id("rpcResponse").Dot("Error").Dot("Data")),
 					).Else().Block(
 						Err().Op("=").Id("rpcResponse").Dot("GetObject").Call(Op("&").Add(resp)),
 					),
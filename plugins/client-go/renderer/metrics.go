@@ -26,6 +26,9 @@ func (r *ClientRenderer) RenderClientMetrics() error {
 		Id("RequestCount").Op("*").Qual(PackagePrometheus, "CounterVec"),
 		Id("RequestCountAll").Op("*").Qual(PackagePrometheus, "CounterVec"),
 		Id("RequestLatency").Op("*").Qual(PackagePrometheus, "HistogramVec"),
+		Id("RetryCount").Op("*").Qual(PackagePrometheus, "CounterVec"),
+		Id("BreakerState").Op("*").Qual(PackagePrometheus, "GaugeVec"),
+		Id("BreakerTransitions").Op("*").Qual(PackagePrometheus, "CounterVec"),
 	).Line()
 
 	srcFile.Line().Func().Id("NewMetrics").Params().Params(Op("*").Id("Metrics")).BlockFunc(func(bg *Group) {
@@ -63,11 +66,52 @@ func (r *ClientRenderer) RenderClientMetrics() error {
 					d[Id("Help")] = Lit("Total duration of requests in microseconds")
 				}),
 			), Index().String().Values(Lit("service"), Lit("method"), Lit("success"), Lit("errCode"))),
+			Id("RetryCount"): Qual(PackagePrometheusAuto, "NewCounterVec").Call(Qual(PackagePrometheus, "CounterOpts").Values(
+				DictFunc(func(d Dict) {
+					d[Id("Name")] = Lit("retry_count")
+					d[Id("Namespace")] = Lit("client")
+					d[Id("Subsystem")] = Lit("requests")
+					d[Id("Help")] = Lit("Number of retried requests")
+				}),
+			), Index().String().Values(Lit("service"), Lit("method"))),
+			Id("BreakerState"): Qual(PackagePrometheusAuto, "NewGaugeVec").Call(Qual(PackagePrometheus, "GaugeOpts").Values(
+				DictFunc(func(d Dict) {
+					d[Id("Name")] = Lit("state")
+					d[Id("Namespace")] = Lit("client")
+					d[Id("Subsystem")] = Lit("breaker")
+					d[Id("Help")] = Lit("Circuit breaker state of method (0 - closed, 1 - open, 2 - half-open)")
+				}),
+			), Index().String().Values(Lit("method"))),
+			Id("BreakerTransitions"): Qual(PackagePrometheusAuto, "NewCounterVec").Call(Qual(PackagePrometheus, "CounterOpts").Values(
+				DictFunc(func(d Dict) {
+					d[Id("Name")] = Lit("transitions_count")
+					d[Id("Namespace")] = Lit("client")
+					d[Id("Subsystem")] = Lit("breaker")
+					d[Id("Help")] = Lit("Number of circuit breaker state changes")
+				}),
+			), Index().String().Values(Lit("method"), Lit("state"))),
 		})
 		bg.Id("m").Dot("VersionGauge").Dot("WithLabelValues").Call(Lit("tg"), Id("VersionTg"), Id("hostname")).Dot("Set").Call(Lit(1))
 		bg.Return(Id("m"))
 	})
 
+	if r.HasJsonRPC() || r.HasHTTP() {
+		srcFile.Line().Comment("observeBreaker отражает смену состояния выключателя метода в метриках.")
+		srcFile.Func().Params(Id("cli").Op("*").Id("Client")).Id("observeBreaker").Params(Id("method").String(), List(Id("_"), Id("to")).Id("BreakerState")).Block(
+			Id("cli").Dot("metrics").Dot("BreakerState").Dot("WithLabelValues").Call(Id("method")).Dot("Set").Call(Float64().Call(Id("to"))),
+			Id("cli").Dot("metrics").Dot("BreakerTransitions").Dot("WithLabelValues").Call(Id("method"), Id("to").Dot("String").Call()).Dot("Inc").Call(),
+		)
+	}
+	if r.HasIdempotent() {
+		srcFile.Line().Comment("retryObserver возвращает счётчик повторов метода для WithRetry.")
+		srcFile.Func().Params(Id("cli").Op("*").Id("Client")).Id("retryObserver").Params(Id("service").String(), Id("method").String()).Func().Params(Id("attempt").Int()).Block(
+			Return(Func().Params(Id("_").Int())).Block(
+				If(Id("cli").Dot("metrics").Op("!=").Nil()).Block(
+					Id("cli").Dot("metrics").Dot("RetryCount").Dot("WithLabelValues").Call(Id("service"), Id("method")).Dot("Inc").Call(),
+				),
+			),
+		)
+	}
 	return srcFile.Save(path.Join(outDir, "metrics.go"))
 }
 
@@ -0,0 +1,159 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"tgp/core"
+)
+
+// defaultGoVersion - версия Go модуля клиента, если в go.mod проекта она не указана (iter.Seq2 требует 1.23).
+const defaultGoVersion = "1.23"
+
+// licenseFiles - имена файлов лицензии проекта, копируемых в модуль клиента.
+var licenseFiles = []string{"LICENSE", "LICENSE.md", "LICENSE.txt"}
+
+// IsModule сообщает, генерируется ли клиент как отдельный модуль.
+func (r *ClientRenderer) IsModule() bool {
+	return r.modulePath != ""
+}
+
+// typesPackage возвращает имя пакета локальных типов клиента: types для отдельного модуля, dto для пакета проекта.
+func (r *ClientRenderer) typesPackage() string {
+
+	if r.IsModule() {
+		return "types"
+	}
+	return "dto"
+}
+
+// moduleVersion возвращает версию модуля клиента: git тег проекта или "(devel)", если тега нет.
+func (r *ClientRenderer) moduleVersion() string {
+
+	if r.project.Git != nil && r.project.Git.Tag != "" {
+		return r.project.Git.Tag
+	}
+	return "(devel)"
+}
+
+// RenderGoMod генерирует go.mod модуля клиента. Вызывается до генерации файлов без зависимостей (для группировки
+// импортов по модулю клиента) и после неё с зависимостями: модулями внешних пакетов, импортируемых сгенерированными
+// файлами, с версиями из go.mod проекта; пакет вне требований проекта - ошибка. go.sum не генерируется.
+func (r *ClientRenderer) RenderGoMod(withDependencies bool) (err error) {
+
+	goVersion := defaultGoVersion
+	var projectMod *modfile.File
+	projectModPath := filepath.Join(r.projectRoot, "go.mod")
+	if data, readErr := os.ReadFile(projectModPath); readErr == nil {
+		if projectMod, err = modfile.Parse(projectModPath, data, nil); err != nil {
+			return fmt.Errorf("parse %s: %w", projectModPath, err)
+		}
+		if projectMod.Go != nil && projectMod.Go.Version != "" {
+			goVersion = projectMod.Go.Version
+		}
+	}
+
+	if err = os.MkdirAll(r.outDir, 0755); err != nil {
+		return
+	}
+	var imports []string
+	if withDependencies {
+		if imports, err = r.externalImports(); err != nil {
+			return
+		}
+	}
+	requires := make(map[string]string)
+	for _, importPath := range imports {
+		var found *modfile.Require
+		if projectMod != nil {
+			for _, require := range projectMod.Require {
+				if importPath != require.Mod.Path && !strings.HasPrefix(importPath, require.Mod.Path+"/") {
+					continue
+				}
+				if found == nil || len(require.Mod.Path) > len(found.Mod.Path) {
+					found = require
+				}
+			}
+		}
+		if found == nil {
+			return fmt.Errorf("client module dependency not found in %s: import=%s", projectModPath, importPath)
+		}
+		requires[found.Mod.Path] = found.Mod.Version
+	}
+
+	var content strings.Builder
+	content.WriteString("// " + DoNotEdit + "\n\n")
+	content.WriteString("module " + r.modulePath + "\n\n")
+	content.WriteString("go " + goVersion + "\n")
+	if len(requires) > 0 {
+		modules := make([]string, 0, len(requires))
+		for module := range requires {
+			modules = append(modules, module)
+		}
+		sort.Strings(modules)
+		content.WriteString("\nrequire (\n")
+		for _, module := range modules {
+			content.WriteString("\t" + module + " " + requires[module] + "\n")
+		}
+		content.WriteString(")\n")
+	}
+	return os.WriteFile(path.Join(r.outDir, "go.mod"), []byte(content.String()), 0600)
+}
+
+// externalImports возвращает пути пакетов вне стандартной библиотеки и модуля клиента, импортируемых сгенерированными файлами.
+func (r *ClientRenderer) externalImports() (imports []string, err error) {
+
+	seen := make(map[string]bool)
+	fileSet := token.NewFileSet()
+	err = filepath.WalkDir(r.outDir, func(filePath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			return nil
+		}
+		file, parseErr := parser.ParseFile(fileSet, filePath, nil, parser.ImportsOnly)
+		if parseErr != nil {
+			return parseErr
+		}
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			isStd := !strings.Contains(strings.Split(importPath, "/")[0], ".")
+			isOwn := importPath == r.modulePath || strings.HasPrefix(importPath, r.modulePath+"/")
+			if isStd || isOwn || seen[importPath] {
+				continue
+			}
+			seen[importPath] = true
+			imports = append(imports, importPath)
+		}
+		return nil
+	})
+	sort.Strings(imports)
+	return
+}
+
+// RenderLicense копирует файл лицензии проекта в модуль клиента.
+func (r *ClientRenderer) RenderLicense() error {
+
+	for _, name := range licenseFiles {
+		data, err := os.ReadFile(filepath.Join(r.projectRoot, name))
+		if err != nil {
+			continue
+		}
+		return os.WriteFile(path.Join(r.outDir, name), data, 0600)
+	}
+	core.GetLogger().Warn(fmt.Sprintf("project license not found, client module is generated without it: projectRoot=%s", r.projectRoot))
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// HasMultipart проверяет, есть ли HTTP методы с multipart телом запроса.
+func (r *ClientRenderer) HasMultipart() bool {
+
+	for _, contract := range r.project.Contracts {
+		if !r.contains(contract.Annotations, TagServerHTTP) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			if r.methodIsHTTP(method) && r.methodBodyMode(method) == bodyModeMultipart {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RenderClientMultipart генерирует файл multipart.go.
+func (r *ClientRenderer) RenderClientMultipart() error {
+
+	outDir := r.outDir
+	srcFile := NewSrcFile(filepath.Base(outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageIO, "io")
+	srcFile.ImportName(PackageMime, "mime")
+	srcFile.ImportName(PackageBufio, "bufio")
+	srcFile.ImportName(PackageHttp, "http")
+	srcFile.ImportName(PackageMultipart, "multipart")
+	srcFile.ImportName(PackageTextproto, "textproto")
+
+	srcFile.Line().Func().Id("writeFormFile").
+		Params(Id("mw").Op("*").Qual(PackageMultipart, "Writer"), Id("field").String(), Id("data").Qual(PackageIO, "Reader")).
+		Params(Err().Error()).
+		Block(
+			Line().If(Id("data").Op("==").Nil()).Block(
+				Return(),
+			),
+			Id("reader").Op(":=").Qual(PackageBufio, "NewReader").Call(Id("data")),
+			List(Id("head"), Id("_")).Op(":=").Id("reader").Dot("Peek").Call(Lit(512)),
+			Id("header").Op(":=").Make(Qual(PackageTextproto, "MIMEHeader")),
+			Id("header").Dot("Set").Call(Lit("Content-Disposition"), Qual(PackageMime, "FormatMediaType").Call(Lit("form-data"), Map(String()).String().Values(Dict{
+				Lit("name"):     Id("field"),
+				Lit("filename"): Id("field"),
+			}))),
+			Id("header").Dot("Set").Call(Lit("Content-Type"), Qual(PackageHttp, "DetectContentType").Call(Id("head"))),
+			Var().Id("part").Qual(PackageIO, "Writer"),
+			If(List(Id("part"), Err()).Op("=").Id("mw").Dot("CreatePart").Call(Id("header")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			List(Id("_"), Err()).Op("=").Qual(PackageIO, "Copy").Call(Id("part"), Id("reader")),
+			Return(),
+		)
+
+	return srcFile.Save(path.Join(outDir, "multipart.go"))
+}
@@ -158,6 +158,173 @@ func (r *ClientRenderer) RenderClientOptions() error {
 		})
 	}
 
+	// WithCodec - работает для JSON-RPC и HTTP
+	if r.HasJsonRPC() || r.HasHTTP() {
+		srcFile.Line().Comment("Codec кодирует тела запросов и ответов в формат, отличный от JSON (например, MessagePack или CBOR).")
+		srcFile.Comment("Реализация должна учитывать json-теги структур.")
+		srcFile.Type().Id("Codec").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "Codec")
+
+		srcFile.Line().Func().Id("WithCodec").Params(Id("codec").Id("Codec")).Params(Id("Option")).BlockFunc(func(bg *Group) {
+			bg.Return(Func().Params(Id("cli").Op("*").Id("Client"))).BlockFunc(func(returnBg *Group) {
+				returnBg.Id("cli").Dot("codec").Op("=").Id("codec")
+				if r.HasJsonRPC() {
+					returnBg.Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "WithCodec").Call(Id("codec")))
+				}
+			})
+		})
+	}
+	if r.HasHTTP() {
+		srcFile.ImportName(PackageMime, "mime")
+		srcFile.ImportName(PackageStrings, "strings")
+		srcFile.Line().Func().Params(Id("cli").Op("*").Id("Client")).Id("responseCodec").Params(Id("httpResp").Op("*").Qual(PackageHttp, "Response")).Params(Id("Codec")).Block(
+			If(Id("cli").Dot("codec").Op("==").Nil()).Block(
+				Return(Nil()),
+			),
+			List(Id("mediaType"), Id("_"), Id("_")).Op(":=").Qual(PackageMime, "ParseMediaType").Call(Id("httpResp").Dot("Header").Dot("Get").Call(Lit("Content-Type"))),
+			If(Op("!").Qual(PackageStrings, "EqualFold").Call(Id("mediaType"), Id("cli").Dot("codec").Dot("ContentType").Call())).Block(
+				Return(Nil()),
+			),
+			Return(Id("cli").Dot("codec")),
+		)
+	}
+
+	if r.HasJsonRPC() || r.HasHTTP() {
+		srcFile.Line().Comment("BreakerPolicy - пороги автоматического выключателя метода.")
+		srcFile.Type().Id("BreakerPolicy").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "BreakerPolicy")
+
+		srcFile.Line().Comment("BreakerState - состояние автоматического выключателя метода.")
+		srcFile.Type().Id("BreakerState").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "BreakerState")
+
+		srcFile.Line().Const().Defs(
+			Id("BreakerClosed").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "BreakerClosed"),
+			Id("BreakerOpen").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "BreakerOpen"),
+			Id("BreakerHalfOpen").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "BreakerHalfOpen"),
+		)
+
+		srcFile.Line().Comment("ErrBreakerOpen возвращается без обращения к серверу, пока выключатель метода разомкнут.")
+		srcFile.Var().Id("ErrBreakerOpen").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "ErrBreakerOpen")
+
+		srcFile.Line().Comment("DefaultBreakerPolicy возвращает пороги автоматического выключателя по умолчанию.")
+		srcFile.Func().Id("DefaultBreakerPolicy").Params().Params(Id("BreakerPolicy")).Block(
+			Return(Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "DefaultBreakerPolicy").Call()),
+		)
+
+		srcFile.Line().Comment("WithCircuitBreaker включает автоматический выключатель для каждого метода клиента.")
+		srcFile.Func().Id("WithCircuitBreaker").Params(Id("policy").Id("BreakerPolicy")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+				Id("cli").Dot("breakerPolicy").Op("=").Op("&").Id("policy"),
+			),
+		)
+
+		srcFile.Line().Comment("WithMethodCircuitBreaker задаёт пороги автоматического выключателя метода (Contract.Method).")
+		srcFile.Func().Id("WithMethodCircuitBreaker").Params(Id("method").String(), Id("policy").Id("BreakerPolicy")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+				If(Id("cli").Dot("breakerPolicies").Op("==").Nil()).Block(
+					Id("cli").Dot("breakerPolicies").Op("=").Make(Map(String()).Id("BreakerPolicy")),
+				),
+				Id("cli").Dot("breakerPolicies").Index(Id("method")).Op("=").Id("policy"),
+			),
+		)
+
+		srcFile.Line().Comment("OnBreakerStateChange задаёт функцию, вызываемую при смене состояния выключателя метода (Contract.Method).")
+		srcFile.Func().Id("OnBreakerStateChange").Params(Id("listener").Func().Params(Id("method").String(), List(Id("from"), Id("to")).Id("BreakerState"))).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+				Id("cli").Dot("breakerListeners").Op("=").Append(Id("cli").Dot("breakerListeners"), Id("listener")),
+			),
+		)
+
+		srcFile.Line().Func().Params(Id("cli").Op("*").Id("Client")).Id("breaker").Params(Id("method").String()).Params(Id("done").Func().Params(Error()), Err().Error()).Block(
+			If(Id("cli").Dot("breakers").Op("==").Nil()).Block(
+				Return(Func().Params(Error()).Block(), Nil()),
+			),
+			Return(Id("cli").Dot("breakers").Dot("Allow").Call(Id("method"))),
+		)
+
+		srcFile.Line().Comment("HedgePolicy - политика дублирующих запросов для чтений (аннотация hedge, HTTP GET и HEAD).")
+		srcFile.Type().Id("HedgePolicy").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "HedgePolicy")
+
+		srcFile.Line().Comment("WithHedging включает дублирующие запросы для чтений (аннотация hedge, HTTP GET и HEAD): если ответа нет за Delay, отправляется копия.")
+		srcFile.Func().Id("WithHedging").Params(Id("policy").Id("HedgePolicy")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+				Id("cli").Dot("hedge").Op("=").Op("&").Id("policy"),
+			),
+		)
+
+		srcFile.Line().Comment("WithInProcess направляет вызовы обработчику сервера в том же процессе (например, transport.Server.Handler()) без сетевых соединений.")
+		srcFile.Comment("Цепочка middleware, валидация и логирование сервера сохраняются; адрес клиента используется только для построения URL.")
+		srcFile.Comment("Заменяется только транспорт: настройки ClientHTTP (таймаут, cookie), WithRecording, WithHedging и трассировка применяются поверх.")
+		srcFile.Comment("Вызов без сериализации не поддерживается: клиент генерирует собственные копии типов, не связанные с типами сервера,")
+		srcFile.Comment("а валидация и логирование сервера работают с телом запроса, поэтому запросы и ответы кодируются как при сетевом вызове (JSON или WithCodec).")
+		srcFile.Func().Id("WithInProcess").Params(Id("handler").Qual(PackageHttp, "Handler")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+				Id("cli").Dot("inProcess").Op("=").Id("handler"),
+			),
+		)
+
+		srcFile.Line().Comment("WithRecording записывает пары запрос/ответ всех вызовов клиента в файл кассеты path (формат JSON, заголовки авторизации, Cookie и Set-Cookie не сохраняются).")
+		srcFile.Comment("Кассета хранится в памяти и записывается в файл вызовом Client.Close; используется WithReplay для воспроизведения ответов без сервера.")
+		srcFile.Func().Id("WithRecording").Params(Id("path").String()).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+				Id("cli").Dot("recordTo").Op("=").Id("path"),
+			),
+		)
+
+		srcFile.Line().Comment("WithReplay отвечает на вызовы клиента ответами из кассеты path, записанной WithRecording, без сетевых запросов.")
+		srcFile.Comment("Вызов, для которого нет записи, завершается ошибкой. Имеет приоритет над WithRecording.")
+		srcFile.Func().Id("WithReplay").Params(Id("path").String()).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+				Id("cli").Dot("replayFrom").Op("=").Id("path"),
+			),
+		)
+
+		srcFile.Line().Comment("LimitPolicy - ограничения частоты (token bucket) и числа одновременных вызовов на стороне клиента.")
+		srcFile.Type().Id("LimitPolicy").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "LimitPolicy")
+
+		srcFile.Line().Comment("WithLimits задаёт общие для всех методов клиента ограничения: при их превышении вызов ждёт, пока не истечёт контекст.")
+		srcFile.Func().Id("WithLimits").Params(Id("policy").Id("LimitPolicy")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+				Id("cli").Dot("limitPolicy").Op("=").Op("&").Id("policy"),
+			),
+		)
+
+		srcFile.Line().Comment("WithMethodLimits задаёт ограничения метода (Contract.Method), действующие вместе с общими.")
+		srcFile.Func().Id("WithMethodLimits").Params(Id("method").String(), Id("policy").Id("LimitPolicy")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+				If(Id("cli").Dot("limitPolicies").Op("==").Nil()).Block(
+					Id("cli").Dot("limitPolicies").Op("=").Make(Map(String()).Id("LimitPolicy")),
+				),
+				Id("cli").Dot("limitPolicies").Index(Id("method")).Op("=").Id("policy"),
+			),
+		)
+
+		srcFile.Line().Func().Params(Id("cli").Op("*").Id("Client")).Id("acquire").Params(Id(_ctx_).Qual(PackageContext, "Context"), Id("method").String()).Params(Id("release").Func().Params(), Err().Error()).Block(
+			If(Id("cli").Dot("limiters").Op("==").Nil()).Block(
+				Return(Func().Params().Block(), Nil()),
+			),
+			Return(Id("cli").Dot("limiters").Dot("Acquire").Call(Id(_ctx_), Id("method"))),
+		)
+	}
+
+	if r.HasIdempotent() {
+		srcFile.Line().Comment("RetryPolicy - политика повторов идемпотентных методов (аннотация idempotent).")
+		srcFile.Type().Id("RetryPolicy").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "RetryPolicy")
+
+		srcFile.Line().Comment("DefaultRetryPolicy возвращает политику повторов по умолчанию.")
+		srcFile.Func().Id("DefaultRetryPolicy").Params().Params(Id("RetryPolicy")).Block(
+			Return(Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "DefaultRetryPolicy").Call()),
+		)
+
+		srcFile.Line().Comment("WithRetry включает повторы идемпотентных методов с экспоненциальной задержкой и учётом Retry-After.")
+		srcFile.Func().Id("WithRetry").Params(Id("policy").Id("RetryPolicy")).Params(Id("Option")).BlockFunc(func(bg *Group) {
+			bg.Return(Func().Params(Id("cli").Op("*").Id("Client"))).BlockFunc(func(returnBg *Group) {
+				returnBg.Id("cli").Dot("retry").Op("=").Op("&").Id("policy")
+				if r.HasJsonRPC() {
+					returnBg.Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "WithRetry").Call(Id("policy")))
+				}
+			})
+		})
+	}
+
 	if r.HasMetrics() {
 		srcFile.Line().Func().Id("WithMetrics").Params().Params(Id("Option")).Block(
 			Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
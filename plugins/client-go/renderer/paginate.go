@@ -0,0 +1,140 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"context"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/core"
+)
+
+// pagination описывает курсорную пагинацию метода (аннотация paginate).
+type pagination struct {
+	cursor   *core.Variable
+	next     *core.Variable
+	items    *core.Variable
+	pageSize *core.Variable
+}
+
+// methodPagination возвращает пагинацию метода или nil, если метод не помечен paginate (сигнатура проверяется валидацией контракта):
+// курсор - строковый аргумент, следующий курсор - строковый результат, элементы - слайс в результатах.
+func (r *ClientRenderer) methodPagination(method *core.Method) *pagination {
+
+	if !r.contains(method.Annotations, TagPaginate) {
+		return nil
+	}
+	annotation := func(key, def string) string {
+		if value := method.Annotations[key]; value != "" {
+			return value
+		}
+		return def
+	}
+	find := func(vars []*core.Variable, name string) *core.Variable {
+		for _, v := range vars {
+			if v.Name == name {
+				return v
+			}
+		}
+		return nil
+	}
+	isString := func(v *core.Variable) bool {
+		return v != nil && v.TypeID == "string" && !v.IsSlice && v.NumberOfPointers == 0 && v.MapKeyID == ""
+	}
+	p := &pagination{
+		cursor: find(r.argsWithoutContext(method), annotation(TagPaginateCursor, "cursor")),
+		next:   find(r.resultsWithoutError(method), annotation(TagPaginateNext, "nextCursor")),
+		items:  find(r.resultsWithoutError(method), annotation(TagPaginateItems, "items")),
+	}
+	if !isString(p.cursor) || !isString(p.next) || p.items == nil || !p.items.IsSlice || !r.isErrorLast(method.Results) {
+		return nil
+	}
+	if pageSize := method.Annotations[TagPaginatePageSize]; pageSize != "" {
+		p.pageSize = find(r.argsWithoutContext(method), pageSize)
+	}
+	return p
+}
+
+// paginateFuncName возвращает имя итератора по всем страницам метода.
+func (r *ClientRenderer) paginateFuncName(method *core.Method) string {
+	return method.Name + "All"
+}
+
+// paginateClientMethodFunc генерирует итератор iter.Seq2 по элементам всех страниц метода.
+func (r *ClientRenderer) paginateClientMethodFunc(ctx context.Context, contract *core.Contract, method *core.Method, p *pagination) Code {
+
+	var params []*core.Variable
+	for _, arg := range method.Args {
+		if arg != p.cursor {
+			params = append(params, arg)
+		}
+	}
+	ctxName := _ctx_
+	if r.isContextFirst(method.Args) {
+		ctxName = ToLowerCamel(method.Args[0].Name)
+	}
+	itemType := r.fieldType(ctx, p.items.TypeID, p.items.ElementPointers, false)
+
+	c := Comment(r.paginateFuncName(method) + " обходит элементы всех страниц " + method.Name + ", запрашивая следующую страницу по курсору " + p.next.Name + ".").Line()
+	if p.pageSize != nil {
+		c.Comment("Размер страницы задаётся аргументом " + ToLowerCamel(p.pageSize.Name) + " и одинаков для всех запросов.").Line()
+	}
+	c.Comment("Обход завершается на пустом или повторном курсоре, на первой ошибке или при отмене контекста.").Line()
+	c.Func().Params(Id("cli").Op("*").Id("Client" + contract.Name)).
+		Id(r.paginateFuncName(method)).
+		Params(r.funcDefinitionParams(ctx, params)).
+		Params(Qual(PackageIter, "Seq2").Types(itemType, Error())).
+		Block(
+			Return(Func().Params(Id("yield").Func().Params(itemType, Error()).Bool())).Block(
+				Line().Var().Id("_cursor").String(),
+				For().Block(
+					If(Err().Op(":=").Id(ctxName).Dot("Err").Call(), Err().Op("!=").Nil()).Block(
+						Var().Id("_empty").Add(itemType),
+						Id("yield").Call(Id("_empty"), Err()),
+						Return(),
+					),
+					ListFunc(func(lg *Group) {
+						for _, result := range r.resultsWithoutError(method) {
+							switch result {
+							case p.items:
+								lg.Id("_items")
+							case p.next:
+								lg.Id("_next")
+							default:
+								lg.Id("_")
+							}
+						}
+						lg.Err()
+					}).Op(":=").Id("cli").Dot(method.Name).CallFunc(func(cg *Group) {
+						for _, arg := range method.Args {
+							if arg == p.cursor {
+								cg.Id("_cursor")
+								continue
+							}
+							if arg.IsEllipsis {
+								cg.Id(ToLowerCamel(arg.Name)).Op("...")
+								continue
+							}
+							cg.Id(ToLowerCamel(arg.Name))
+						}
+					}),
+					If(Err().Op("!=").Nil()).Block(
+						Var().Id("_empty").Add(itemType),
+						Id("yield").Call(Id("_empty"), Err()),
+						Return(),
+					),
+					For(List(Id("_"), Id("_item")).Op(":=").Range().Id("_items")).Block(
+						If(Op("!").Id("yield").Call(Id("_item"), Nil())).Block(
+							Return(),
+						),
+					),
+					If(Id("_next").Op("==").Lit("").Op("||").Id("_next").Op("==").Id("_cursor")).Block(
+						Return(),
+					),
+					Id("_cursor").Op("=").Id("_next"),
+				),
+			),
+		)
+	return c
+}
@@ -0,0 +1,199 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BreakerState - состояние автоматического выключателя метода.
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// ErrBreakerOpen возвращается без обращения к серверу, пока выключатель метода разомкнут.
+var ErrBreakerOpen = errors.New("circuit breaker is open")
+
+// BreakerPolicy - пороги автоматического выключателя метода.
+type BreakerPolicy struct {
+	// FailureThreshold - число отказов подряд, после которого выключатель размыкается.
+	FailureThreshold int
+	// OpenTimeout - время в разомкнутом состоянии до пробных запросов.
+	OpenTimeout time.Duration
+	// HalfOpenRequests - число успешных пробных запросов, после которого выключатель замыкается.
+	HalfOpenRequests int
+}
+
+// DefaultBreakerPolicy возвращает пороги по умолчанию: 5 отказов подряд, 10s в разомкнутом состоянии, 1 пробный запрос.
+func DefaultBreakerPolicy() BreakerPolicy {
+	return BreakerPolicy{
+		FailureThreshold: 5,
+		OpenTimeout:      10 * time.Second,
+		HalfOpenRequests: 1,
+	}
+}
+
+// BreakerListener вызывается при смене состояния выключателя метода (под блокировкой, без обращения к Breakers).
+type BreakerListener func(method string, from, to BreakerState)
+
+// Breakers - автоматические выключатели методов клиента.
+type Breakers struct {
+	policy    *BreakerPolicy
+	policies  map[string]BreakerPolicy
+	listeners []BreakerListener
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+type breaker struct {
+	policy    BreakerPolicy
+	state     BreakerState
+	failures  int
+	successes int
+	probes    int
+	openedAt  time.Time
+	// generation меняется при каждой смене состояния: результаты вызовов прошлых состояний не учитываются.
+	generation uint64
+}
+
+// NewBreakers создаёт выключатели с общей политикой policy (nil - только для методов из policies).
+func NewBreakers(policy *BreakerPolicy, policies map[string]BreakerPolicy, listeners ...BreakerListener) *Breakers {
+	return &Breakers{
+		policy:    policy,
+		policies:  policies,
+		listeners: listeners,
+		breakers:  make(map[string]*breaker),
+	}
+}
+
+// Allow проверяет выключатель метода. done сообщает результат вызова и должен быть вызван ровно один раз.
+func (b *Breakers) Allow(method string) (done func(err error), err error) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cb := b.breaker(method)
+	if cb == nil {
+		return func(error) {}, nil
+	}
+	if cb.state == BreakerOpen {
+		if time.Since(cb.openedAt) < cb.policy.OpenTimeout {
+			return nil, ErrBreakerOpen
+		}
+		b.transition(method, cb, BreakerHalfOpen)
+	}
+	if cb.state == BreakerHalfOpen {
+		if cb.probes >= max(cb.policy.HalfOpenRequests, 1) {
+			return nil, ErrBreakerOpen
+		}
+		cb.probes++
+	}
+	generation := cb.generation
+	return func(err error) { b.done(method, cb, generation, err) }, nil
+}
+
+// State возвращает текущее состояние выключателя метода.
+func (b *Breakers) State(method string) BreakerState {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if cb, found := b.breakers[method]; found {
+		return cb.state
+	}
+	return BreakerClosed
+}
+
+func (b *Breakers) breaker(method string) *breaker {
+
+	if cb, found := b.breakers[method]; found {
+		return cb
+	}
+	policy, found := b.policies[method]
+	if !found {
+		if b.policy == nil {
+			return nil
+		}
+		policy = *b.policy
+	}
+	cb := &breaker{policy: policy}
+	b.breakers[method] = cb
+	return cb
+}
+
+func (b *Breakers) done(method string, cb *breaker, generation uint64, err error) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if generation != cb.generation {
+		return
+	}
+	failure := BreakerFailure(err)
+	switch cb.state {
+	case BreakerHalfOpen:
+		cb.probes--
+		if failure {
+			b.transition(method, cb, BreakerOpen)
+			return
+		}
+		if cb.successes++; cb.successes >= max(cb.policy.HalfOpenRequests, 1) {
+			b.transition(method, cb, BreakerClosed)
+		}
+	case BreakerClosed:
+		if !failure {
+			cb.failures = 0
+			return
+		}
+		if cb.failures++; cb.failures >= max(cb.policy.FailureThreshold, 1) {
+			b.transition(method, cb, BreakerOpen)
+		}
+	}
+}
+
+func (b *Breakers) transition(method string, cb *breaker, to BreakerState) {
+
+	from := cb.state
+	cb.state = to
+	cb.failures, cb.successes, cb.probes = 0, 0, 0
+	cb.generation++
+	if to == BreakerOpen {
+		cb.openedAt = time.Now()
+	}
+	for _, listener := range b.listeners {
+		listener(method, from, to)
+	}
+}
+
+// BreakerFailure сообщает, считается ли ошибка отказом сервера: сетевые ошибки, таймауты, HTTP 5xx и 429.
+// Ошибки бизнес-логики (ответы JSON-RPC с ошибкой, HTTP 4xx) выключатель не размыкают.
+func BreakerFailure(err error) bool {
+
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code >= http.StatusInternalServerError || httpErr.Code == http.StatusTooManyRequests
+	}
+	return retryableError(context.Background(), err)
+}
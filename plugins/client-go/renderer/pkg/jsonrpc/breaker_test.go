@@ -0,0 +1,145 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// errServer - отказ сервера, размыкающий выключатель.
+var errServer = NewHTTPError(http.StatusServiceUnavailable, errors.New("unavailable"))
+
+// breakerCall выполняет вызов метода через выключатель с результатом err.
+func breakerCall(t *testing.T, breakers *Breakers, method string, err error) error {
+
+	t.Helper()
+	done, allowErr := breakers.Allow(method)
+	if allowErr != nil {
+		return allowErr
+	}
+	done(err)
+	return nil
+}
+
+func TestBreakerFailure(t *testing.T) {
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "success", err: nil, want: false},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "deadline", err: fmt.Errorf("call: %w", context.DeadlineExceeded), want: true},
+		{name: "server error", err: errServer, want: true},
+		{name: "too many requests", err: NewHTTPError(http.StatusTooManyRequests, errors.New("too many requests")), want: true},
+		{name: "client error", err: NewHTTPError(http.StatusNotFound, errors.New("not found")), want: false},
+		{name: "network error", err: &url.Error{Op: "Post", URL: "http://svc", Err: errors.New("connection refused")}, want: true},
+		{name: "business error", err: &RPCError{Code: -32044, Message: "not found"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BreakerFailure(tt.err); got != tt.want {
+				t.Errorf("BreakerFailure(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakersOpenAndClose(t *testing.T) {
+
+	var transitions []string
+	policy := BreakerPolicy{FailureThreshold: 2, OpenTimeout: 20 * time.Millisecond, HalfOpenRequests: 1}
+	breakers := NewBreakers(&policy, nil, func(method string, from, to BreakerState) {
+		transitions = append(transitions, fmt.Sprintf("%s:%s->%s", method, from, to))
+	})
+
+	_ = breakerCall(t, breakers, "svc.get", errServer)
+	_ = breakerCall(t, breakers, "svc.get", nil)
+	_ = breakerCall(t, breakers, "svc.get", errServer)
+	if state := breakers.State("svc.get"); state != BreakerClosed {
+		t.Fatalf("success must reset failures, state = %s", state)
+	}
+	_ = breakerCall(t, breakers, "svc.get", errServer)
+	if state := breakers.State("svc.get"); state != BreakerOpen {
+		t.Fatalf("state after %d failures in a row = %s, want open", policy.FailureThreshold, state)
+	}
+	if err := breakerCall(t, breakers, "svc.get", nil); !errors.Is(err, ErrBreakerOpen) {
+		t.Fatalf("open breaker must reject calls, got %v", err)
+	}
+	if err := breakerCall(t, breakers, "svc.other", nil); err != nil {
+		t.Fatalf("breaker of other method must stay closed, got %v", err)
+	}
+
+	time.Sleep(policy.OpenTimeout)
+	probe, err := breakers.Allow("svc.get")
+	if err != nil {
+		t.Fatalf("breaker must allow probe after open timeout, got %v", err)
+	}
+	if _, err = breakers.Allow("svc.get"); !errors.Is(err, ErrBreakerOpen) {
+		t.Fatalf("half-open breaker must allow only %d probe, got %v", policy.HalfOpenRequests, err)
+	}
+	probe(nil)
+	if state := breakers.State("svc.get"); state != BreakerClosed {
+		t.Fatalf("state after successful probe = %s, want closed", state)
+	}
+
+	want := []string{"svc.get:closed->open", "svc.get:open->half-open", "svc.get:half-open->closed"}
+	if fmt.Sprint(transitions) != fmt.Sprint(want) {
+		t.Errorf("transitions = %v, want %v", transitions, want)
+	}
+}
+
+func TestBreakersProbeFailure(t *testing.T) {
+
+	policy := BreakerPolicy{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond, HalfOpenRequests: 2}
+	breakers := NewBreakers(&policy, nil)
+
+	_ = breakerCall(t, breakers, "svc.get", errServer)
+	time.Sleep(policy.OpenTimeout)
+	_ = breakerCall(t, breakers, "svc.get", nil)
+	if state := breakers.State("svc.get"); state != BreakerHalfOpen {
+		t.Fatalf("state after 1 of %d successful probes = %s, want half-open", policy.HalfOpenRequests, state)
+	}
+	_ = breakerCall(t, breakers, "svc.get", errServer)
+	if state := breakers.State("svc.get"); state != BreakerOpen {
+		t.Fatalf("state after failed probe = %s, want open", state)
+	}
+}
+
+func TestBreakersStaleResult(t *testing.T) {
+
+	policy := BreakerPolicy{FailureThreshold: 1, OpenTimeout: time.Minute}
+	breakers := NewBreakers(&policy, nil)
+
+	slow, err := breakers.Allow("svc.get")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = breakerCall(t, breakers, "svc.get", errServer)
+	// результат вызова, начатого до размыкания, не должен влиять на новое состояние
+	slow(nil)
+	if state := breakers.State("svc.get"); state != BreakerOpen {
+		t.Fatalf("stale success changed state to %s", state)
+	}
+}
+
+func TestBreakersPolicies(t *testing.T) {
+
+	breakers := NewBreakers(nil, map[string]BreakerPolicy{"svc.get": {FailureThreshold: 1, OpenTimeout: time.Minute}})
+
+	for range 3 {
+		_ = breakerCall(t, breakers, "svc.list", errServer)
+	}
+	if state := breakers.State("svc.list"); state != BreakerClosed {
+		t.Errorf("method without policy must not have breaker, state = %s", state)
+	}
+	_ = breakerCall(t, breakers, "svc.get", errServer)
+	if state := breakers.State("svc.get"); state != BreakerOpen {
+		t.Errorf("method policy must open breaker, state = %s", state)
+	}
+}
@@ -0,0 +1,359 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Cassette - записанные пары запрос/ответ клиента в порядке выполнения.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction - записанный запрос и ответ сервера на него.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest - записанный запрос. Заголовки авторизации и cookie не записываются.
+type RecordedRequest struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"bodyBase64,omitempty"`
+}
+
+// RecordedResponse - записанный ответ сервера. Заголовки Set-Cookie не записываются.
+type RecordedResponse struct {
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"bodyBase64,omitempty"`
+}
+
+// sensitiveHeaders - заголовки запроса, которые не попадают в кассету.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+// sensitiveResponseHeaders - заголовки ответа, которые не попадают в кассету.
+var sensitiveResponseHeaders = []string{"Set-Cookie"}
+
+// NewRecordTransport оборачивает транспорт: каждая пара запрос/ответ дописывается в кассету в памяти.
+// Кассета сохраняется в файл path вызовом Close, существующий файл перезаписывается. Тела ответов читаются целиком.
+func NewRecordTransport(path string, base http.RoundTripper) *RecordTransport {
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RecordTransport{path: path, base: base}
+}
+
+// RecordTransport - транспорт, записывающий пары запрос/ответ в кассету.
+type RecordTransport struct {
+	path string
+	base http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// RoundTrip выполняет запрос через базовый транспорт и добавляет пару запрос/ответ в кассету.
+func (t *RecordTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	outgoing := request.Clone(request.Context())
+	if body != nil {
+		outgoing.Body = io.NopCloser(bytes.NewReader(body))
+		outgoing.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	response, err := t.base.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: request.Method,
+			URL:    request.URL.RequestURI(),
+			Header: request.Header.Clone(),
+		},
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Header:     response.Header.Clone(),
+		},
+	}
+	for _, header := range sensitiveHeaders {
+		interaction.Request.Header.Del(header)
+	}
+	for _, header := range sensitiveResponseHeaders {
+		interaction.Response.Header.Del(header)
+	}
+	interaction.Request.Body, interaction.Request.BodyBase64 = encodeBody(body)
+	interaction.Response.Body, interaction.Response.BodyBase64 = encodeBody(responseBody)
+
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	t.mu.Unlock()
+	return response, nil
+}
+
+// Close сохраняет записанную кассету в файл. Повторный вызов перезаписывает файл текущим содержимым кассеты.
+func (t *RecordTransport) Close() (err error) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err = t.save(); err != nil {
+		return fmt.Errorf("save cassette %s: %w", t.path, err)
+	}
+	return
+}
+
+func (t *RecordTransport) save() (err error) {
+
+	var data []byte
+	if data, err = json.MarshalIndent(t.cassette, "", "  "); err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return
+	}
+	tmpPath := t.path + ".tmp"
+	if err = os.WriteFile(tmpPath, data, 0644); err != nil {
+		return
+	}
+	return os.Rename(tmpPath, t.path)
+}
+
+// NewReplayTransport возвращает транспорт, отвечающий на запросы из кассеты path без сетевых вызовов.
+// Запрос сопоставляется с записью по методу, пути с query и телу; идентификаторы JSON-RPC не учитываются и подставляются в ответ.
+// Одинаковые запросы получают записанные ответы по порядку, после их исчерпания повторяется последний.
+// Ошибка чтения кассеты возвращается при каждом запросе.
+func NewReplayTransport(path string) http.RoundTripper {
+
+	t := &replayTransport{
+		interactions: make(map[string][]Interaction),
+		served:       make(map[string]int),
+	}
+	var cassette Cassette
+	data, err := os.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(data, &cassette)
+	}
+	if err != nil {
+		t.err = fmt.Errorf("load cassette %s: %w", path, err)
+		return t
+	}
+	for _, interaction := range cassette.Interactions {
+		body, err := decodeBody(interaction.Request.Body, interaction.Request.BodyBase64)
+		if err != nil {
+			t.err = fmt.Errorf("load cassette %s: %w", path, err)
+			return t
+		}
+		key := interactionKey(interaction.Request.Method, interaction.Request.URL, interaction.Request.Header.Get("Content-Type"), body)
+		t.interactions[key] = append(t.interactions[key], interaction)
+	}
+	return t
+}
+
+type replayTransport struct {
+	err error
+
+	mu           sync.Mutex
+	interactions map[string][]Interaction
+	served       map[string]int
+}
+
+func (t *replayTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+
+	if t.err != nil {
+		return nil, t.err
+	}
+	if err := request.Context().Err(); err != nil {
+		return nil, err
+	}
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	key := interactionKey(request.Method, request.URL.RequestURI(), request.Header.Get("Content-Type"), body)
+
+	t.mu.Lock()
+	recorded := t.interactions[key]
+	if len(recorded) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("no recorded interaction for %s %s", request.Method, request.URL.RequestURI())
+	}
+	index := t.served[key]
+	if index < len(recorded)-1 {
+		t.served[key]++
+	} else {
+		index = len(recorded) - 1
+	}
+	interaction := recorded[index]
+	t.mu.Unlock()
+
+	responseBody, err := decodeBody(interaction.Response.Body, interaction.Response.BodyBase64)
+	if err != nil {
+		return nil, err
+	}
+	recordedBody, err := decodeBody(interaction.Request.Body, interaction.Request.BodyBase64)
+	if err != nil {
+		return nil, err
+	}
+	responseBody = replaceRPCIDs(responseBody, rpcIDs(recordedBody), rpcIDs(body))
+
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(responseBody)))
+	return &http.Response{
+		Status:        strconv.Itoa(interaction.Response.StatusCode) + " " + http.StatusText(interaction.Response.StatusCode),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(responseBody)),
+		ContentLength: int64(len(responseBody)),
+		Request:       request,
+	}, nil
+}
+
+// readRequestBody читает и закрывает тело запроса (nil, если тела нет).
+func readRequestBody(request *http.Request) (body []byte, err error) {
+
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err = io.ReadAll(request.Body)
+	_ = request.Body.Close()
+	return
+}
+
+// interactionKey - ключ сопоставления запроса с записью: метод, путь с query и нормализованное тело.
+func interactionKey(method, uri, contentType string, body []byte) string {
+
+	if _, params, err := mime.ParseMediaType(contentType); err == nil && params["boundary"] != "" {
+		// Граница multipart генерируется случайно и не должна влиять на сопоставление
+		body = bytes.ReplaceAll(body, []byte(params["boundary"]), []byte("boundary"))
+	} else if value, ok := decodeJSON(body); ok {
+		forEachRPC(value, func(message map[string]any) {
+			delete(message, "id")
+		})
+		body, _ = json.Marshal(value)
+	}
+	return method + " " + uri + "\n" + string(body)
+}
+
+// rpcIDs возвращает идентификаторы JSON-RPC запросов тела в порядке следования.
+func rpcIDs(body []byte) (ids []string) {
+
+	value, ok := decodeJSON(body)
+	if !ok {
+		return nil
+	}
+	forEachRPC(value, func(message map[string]any) {
+		if id, found := message["id"]; found {
+			ids = append(ids, fmt.Sprint(id))
+		}
+	})
+	return
+}
+
+// replaceRPCIDs заменяет в ответе идентификаторы записанных запросов на идентификаторы текущих.
+func replaceRPCIDs(body []byte, recorded, current []string) []byte {
+
+	if len(recorded) == 0 || len(recorded) != len(current) {
+		return body
+	}
+	value, ok := decodeJSON(body)
+	if !ok {
+		return body
+	}
+	ids := make(map[string]string, len(recorded))
+	for i, id := range recorded {
+		ids[id] = current[i]
+	}
+	forEachRPC(value, func(message map[string]any) {
+		if id, found := ids[fmt.Sprint(message["id"])]; found {
+			message["id"] = json.Number(id)
+		}
+	})
+	if replaced, err := json.Marshal(value); err == nil {
+		return replaced
+	}
+	return body
+}
+
+// forEachRPC вызывает fn для каждого JSON-RPC сообщения значения (одиночного или batch).
+func forEachRPC(value any, fn func(message map[string]any)) {
+
+	switch typed := value.(type) {
+	case map[string]any:
+		if _, found := typed["jsonrpc"]; found {
+			fn(typed)
+		}
+	case []any:
+		for _, item := range typed {
+			if message, ok := item.(map[string]any); ok {
+				if _, found := message["jsonrpc"]; found {
+					fn(message)
+				}
+			}
+		}
+	}
+}
+
+func decodeJSON(data []byte) (value any, ok bool) {
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return nil, false
+	}
+	return value, true
+}
+
+// encodeBody сохраняет тело строкой, если это UTF-8 текст, иначе в base64.
+func encodeBody(body []byte) (text, encoded string) {
+
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return "", base64.StdEncoding.EncodeToString(body)
+}
+
+func decodeBody(text, encoded string) ([]byte, error) {
+
+	if encoded != "" {
+		return base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	}
+	return []byte(text), nil
+}
@@ -0,0 +1,160 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// cassetteDo выполняет запрос через транспорт и возвращает тело ответа.
+func cassetteDo(t *testing.T, transport http.RoundTripper, url, contentType, body string) (status int, respBody string) {
+
+	t.Helper()
+	request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("Authorization", "Bearer secret")
+	request.Header.Set("Cookie", "session=secret")
+	response, err := (&http.Client{Transport: transport}).Do(request)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	defer response.Body.Close()
+	data, _ := io.ReadAll(response.Body)
+	return response.StatusCode, string(data)
+}
+
+// recordCassette записывает ответы сервера на запросы bodies и возвращает путь к кассете.
+func recordCassette(t *testing.T, bodies ...string) (path string) {
+
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request RequestRPC
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+		_ = json.NewEncoder(w).Encode(ResponseRPC{JSONRPC: Version, ID: request.ID, Result: json.RawMessage(fmt.Sprintf(`"call %d"`, calls.Add(1)))})
+	}))
+	defer server.Close()
+
+	path = filepath.Join(t.TempDir(), "svc.cassette.json")
+	recorder := NewRecordTransport(path, server.Client().Transport)
+	for _, body := range bodies {
+		cassetteDo(t, recorder, server.URL+"/rpc", "application/json", body)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("cassette must not be written before Close, stat: %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return
+}
+
+func TestRecordTransport(t *testing.T) {
+
+	path := recordCassette(t, `{"jsonrpc":"2.0","id":1,"method":"svc.get"}`)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cassette must be written on Close: %v", err)
+	}
+	var cassette Cassette
+	if err = json.Unmarshal(data, &cassette); err != nil {
+		t.Fatal(err)
+	}
+	if len(cassette.Interactions) != 1 {
+		t.Fatalf("interactions = %d, want 1", len(cassette.Interactions))
+	}
+	interaction := cassette.Interactions[0]
+	if interaction.Request.URL != "/rpc" || !strings.Contains(interaction.Response.Body, `"call 1"`) {
+		t.Errorf("recorded %s -> %s", interaction.Request.URL, interaction.Response.Body)
+	}
+	for _, header := range sensitiveHeaders {
+		if interaction.Request.Header.Get(header) != "" {
+			t.Errorf("request header %s must not be recorded", header)
+		}
+	}
+	if interaction.Response.Header.Get("Set-Cookie") != "" {
+		t.Error("response header Set-Cookie must not be recorded")
+	}
+}
+
+func TestReplayTransport(t *testing.T) {
+
+	request := `{"jsonrpc":"2.0","id":1,"method":"svc.get"}`
+	path := recordCassette(t, request, request, `{"jsonrpc":"2.0","id":3,"method":"svc.list"}`)
+	replay := NewReplayTransport(path)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantID     ID
+		wantResult string
+	}{
+		{name: "id substituted", body: `{"jsonrpc":"2.0","id":7,"method":"svc.get"}`, wantID: 7, wantResult: `"call 1"`},
+		{name: "repeated in order", body: `{"jsonrpc":"2.0","id":8,"method":"svc.get"}`, wantID: 8, wantResult: `"call 2"`},
+		{name: "last repeated", body: `{"jsonrpc":"2.0","id":9,"method":"svc.get"}`, wantID: 9, wantResult: `"call 2"`},
+		{name: "other body", body: `{"jsonrpc":"2.0","id":1,"method":"svc.list"}`, wantID: 1, wantResult: `"call 3"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := cassetteDo(t, replay, "http://replay/rpc", "application/json", tt.body)
+			var response ResponseRPC
+			if err := json.Unmarshal([]byte(body), &response); err != nil || status != http.StatusOK {
+				t.Fatalf("response = %d %s: %v", status, body, err)
+			}
+			if response.ID != tt.wantID || string(response.Result) != tt.wantResult {
+				t.Errorf("response id %d result %s, want id %d result %s", response.ID, response.Result, tt.wantID, tt.wantResult)
+			}
+		})
+	}
+
+	if _, err := replay.RoundTrip(httptest.NewRequest(http.MethodPost, "http://replay/rpc", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"svc.delete"}`))); err == nil {
+		t.Error("request without recorded interaction must fail")
+	}
+	if _, err := NewReplayTransport(filepath.Join(t.TempDir(), "missing.json")).RoundTrip(httptest.NewRequest(http.MethodGet, "http://replay/", nil)); err == nil {
+		t.Error("missing cassette must fail")
+	}
+}
+
+func TestReplayTransportMultipart(t *testing.T) {
+
+	// multipartBody кодирует одинаковые поля со случайной границей
+	multipartBody := func() (contentType, body string) {
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		_ = writer.WriteField("name", "avatar")
+		_ = writer.Close()
+		return writer.FormDataContentType(), buf.String()
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "uploaded")
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "upload.cassette.json")
+	recorder := NewRecordTransport(path, server.Client().Transport)
+	contentType, body := multipartBody()
+	cassetteDo(t, recorder, server.URL+"/upload", contentType, body)
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	contentType, body = multipartBody()
+	if status, respBody := cassetteDo(t, NewReplayTransport(path), "http://replay/upload", contentType, body); status != http.StatusOK || respBody != "uploaded" {
+		t.Errorf("response = %d %q, want multipart request matched regardless of boundary", status, respBody)
+	}
+}
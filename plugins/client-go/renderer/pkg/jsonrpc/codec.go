@@ -0,0 +1,96 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+type Codec interface {
+	ContentType() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+type codecErrorRPC struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+type codecResponseRPC struct {
+	ID      ID             `json:"id"`
+	JSONRPC string         `json:"jsonrpc"`
+	Error   *codecErrorRPC `json:"error,omitempty"`
+	Result  any            `json:"result,omitempty"`
+}
+
+func (envelope codecResponseRPC) response(codec Codec) (response *ResponseRPC, err error) {
+
+	response = &ResponseRPC{
+		ID:      envelope.ID,
+		JSONRPC: envelope.JSONRPC,
+		codec:   codec,
+	}
+	if envelope.Error != nil {
+		response.Error = &RPCError{
+			Code:    envelope.Error.Code,
+			Message: envelope.Error.Message,
+		}
+		if envelope.Error.Data != nil {
+			response.Error.Data, _ = json.Marshal(envelope.Error.Data)
+		}
+	}
+	if envelope.Result != nil {
+		if response.encoded, err = codec.Marshal(envelope.Result); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func decodeCodecResponse(codec Codec, data []byte) (response *ResponseRPC, err error) {
+
+	var envelope codecResponseRPC
+	if err = codec.Unmarshal(data, &envelope); err != nil {
+		return
+	}
+	return envelope.response(codec)
+}
+
+func decodeCodecResponses(codec Codec, data []byte) (responses ResponsesRPC, err error) {
+
+	var envelopes []codecResponseRPC
+	if err = codec.Unmarshal(data, &envelopes); err != nil {
+		var envelope codecResponseRPC
+		if codec.Unmarshal(data, &envelope) != nil {
+			return
+		}
+		envelopes = []codecResponseRPC{envelope}
+	}
+	for _, envelope := range envelopes {
+		var response *ResponseRPC
+		if response, err = envelope.response(codec); err != nil {
+			return
+		}
+		responses = append(responses, response)
+	}
+	if len(responses) == 0 {
+		err = errors.New("empty batch response")
+	}
+	return
+}
+
+func (client *ClientRPC) responseCodec(response *http.Response) Codec {
+
+	if client.options.codec == nil {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	if !strings.EqualFold(mediaType, client.options.codec.ContentType()) {
+		return nil
+	}
+	return client.options.codec
+}
@@ -3,8 +3,12 @@ package jsonrpc
 import (
 	"encoding/json"
 	"strconv"
+	"time"
 )
 
+// CodeRateLimited - код ошибки JSON-RPC, которым сервер отклоняет вызов сверх лимита запросов.
+const CodeRateLimited = -32029
+
 type RPCError struct {
 	Code    int             `json:"code"`
 	Message string          `json:"message"`
@@ -20,11 +24,32 @@ func (e *RPCError) Error() string {
 	return strconv.Itoa(e.Code) + ": " + e.Message
 }
 
+// RetryAfter возвращает задержку до повтора из data.retryAfter (секунды), если сервер её передал.
+func (e *RPCError) RetryAfter() time.Duration {
+
+	var data struct {
+		RetryAfter int `json:"retryAfter"`
+	}
+	if len(e.Data) == 0 || json.Unmarshal(e.Data, &data) != nil {
+		return 0
+	}
+	return time.Duration(max(data.RetryAfter, 0)) * time.Second
+}
+
 type HTTPError struct {
-	Code int
-	err  error
+	Code       int
+	RetryAfter time.Duration
+	err        error
 }
 
 func (e *HTTPError) Error() string {
 	return e.err.Error()
 }
+
+func NewHTTPError(code int, err error) *HTTPError {
+	return &HTTPError{Code: code, err: err}
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,196 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// codeIdempotencyConflict - код ошибки JSON-RPC, которым сервер отклоняет запрос с ключом идемпотентности, который ещё выполняется.
+const codeIdempotencyConflict = -32009
+
+// HedgePolicy - политика дублирующих (hedged) запросов: если ответа нет за Delay, отправляется копия запроса.
+type HedgePolicy struct {
+	// Delay - ожидание ответа перед отправкой очередной копии запроса.
+	Delay time.Duration
+	// MaxHedged - максимальное число дополнительных копий запроса.
+	MaxHedged int
+}
+
+type hedgeCtx struct{}
+
+// AllowHedge помечает вызов как безопасный для дублирования (чтение без побочных эффектов).
+func AllowHedge(ctx context.Context) context.Context {
+	return context.WithValue(ctx, hedgeCtx{}, true)
+}
+
+// HedgeAllowed сообщает, разрешено ли дублирование запросов с этим контекстом.
+func HedgeAllowed(ctx context.Context) bool {
+
+	allowed, _ := ctx.Value(hedgeCtx{}).(bool)
+	return allowed
+}
+
+// NewHedgedTransport оборачивает транспорт: запросы с контекстом AllowHedge дублируются по политике,
+// используется первый ответ без ошибки, без статуса 5xx и без конфликта ключа идемпотентности, остальные запросы отменяются.
+// Запросы с заголовком Idempotency-Key не дублируются: копия с тем же ключом отклоняется сервером.
+func NewHedgedTransport(base http.RoundTripper, policy HedgePolicy) http.RoundTripper {
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &hedgedTransport{base: base, policy: policy}
+}
+
+type hedgedTransport struct {
+	base   http.RoundTripper
+	policy HedgePolicy
+}
+
+type hedgeResult struct {
+	index    int
+	response *http.Response
+	err      error
+}
+
+func (t *hedgedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+
+	if t.policy.MaxHedged <= 0 || !HedgeAllowed(request.Context()) || request.Header.Get(headerIdempotencyKey) != "" || (request.Body != nil && request.GetBody == nil) {
+		return t.base.RoundTrip(request)
+	}
+	results := make(chan hedgeResult, t.policy.MaxHedged+1)
+	cancels := make([]context.CancelFunc, 0, t.policy.MaxHedged+1)
+	send := func(body io.ReadCloser) {
+		ctx, cancel := context.WithCancel(request.Context())
+		attempt := request.Clone(ctx)
+		attempt.Body = body
+		index := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			response, err := t.base.RoundTrip(attempt)
+			results <- hedgeResult{index: index, response: response, err: err}
+		}()
+	}
+	// hedge отправляет очередную копию запроса, если не исчерпан лимит копий.
+	hedge := func() bool {
+		if len(cancels) > t.policy.MaxHedged {
+			return false
+		}
+		var body io.ReadCloser
+		if request.GetBody != nil {
+			var err error
+			if body, err = request.GetBody(); err != nil {
+				return false
+			}
+		}
+		send(body)
+		return true
+	}
+	send(request.Body)
+	inflight := 1
+	timer := time.NewTimer(t.policy.Delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			if hedge() {
+				inflight++
+				timer.Reset(t.policy.Delay)
+			}
+		case result := <-results:
+			inflight--
+			if result.err == nil && hedgeAccepted(result.response) {
+				return finishHedge(result, cancels, inflight, results)
+			}
+			if inflight == 0 {
+				if !hedge() {
+					return finishHedge(result, cancels, inflight, results)
+				}
+				inflight++
+			}
+			if result.response != nil {
+				_ = result.response.Body.Close()
+			}
+			cancels[result.index]()
+		}
+	}
+}
+
+// hedgeAccepted проверяет, окончателен ли ответ копии: ошибки сервера (5xx) и конфликт ключа идемпотентности
+// (статус 409 или ошибка JSON-RPC в ответе 200) не принимаются. Тело JSON ответа читается и подменяется копией.
+func hedgeAccepted(response *http.Response) bool {
+
+	if response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusConflict {
+		return false
+	}
+	if mediaType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type")); mediaType != "application/json" {
+		return true
+	}
+	data, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(data))
+	return err == nil && !idempotencyConflict(data)
+}
+
+// idempotencyConflict проверяет, содержит ли ответ JSON-RPC (одиночный или batch) ошибку конфликта ключа идемпотентности.
+func idempotencyConflict(data []byte) bool {
+
+	var responses ResponsesRPC
+	if data = bytes.TrimSpace(data); len(data) != 0 && data[0] == '[' {
+		if json.Unmarshal(data, &responses) != nil {
+			return false
+		}
+	} else {
+		var response ResponseRPC
+		if json.Unmarshal(data, &response) != nil {
+			return false
+		}
+		responses = ResponsesRPC{&response}
+	}
+	for _, response := range responses {
+		if response != nil && response.Error != nil && response.Error.Code == codeIdempotencyConflict {
+			return true
+		}
+	}
+	return false
+}
+
+// finishHedge отдаёт ответ вызывающему (контекст отменяется при закрытии тела), отменяет и закрывает оставшиеся копии.
+func finishHedge(result hedgeResult, cancels []context.CancelFunc, inflight int, results chan hedgeResult) (*http.Response, error) {
+
+	for index, cancel := range cancels {
+		if index != result.index {
+			cancel()
+		}
+	}
+	cancel := cancels[result.index]
+	go func() {
+		for ; inflight > 0; inflight-- {
+			if rest := <-results; rest.response != nil {
+				_ = rest.response.Body.Close()
+			}
+		}
+	}()
+	if result.err != nil {
+		cancel()
+		return nil, result.err
+	}
+	result.response.Body = &cancelBody{ReadCloser: result.response.Body, cancel: cancel}
+	return result.response, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,189 @@
+package jsonrpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// hedgeServer запускает тестовый сервер, ответ на попытку с номером attempt задаёт handle.
+func hedgeServer(t *testing.T, handle func(attempt int32, w http.ResponseWriter, r *http.Request)) (server *httptest.Server, calls *atomic.Int32) {
+
+	calls = new(atomic.Int32)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if len(body) != 0 && string(body) != "payload" {
+			t.Errorf("hedged request body = %q, want payload", body)
+		}
+		handle(calls.Add(1), w, r)
+	}))
+	t.Cleanup(server.Close)
+	return
+}
+
+// hedgeDo выполняет запрос через транспорт с дублированием и возвращает статус и тело ответа.
+func hedgeDo(ctx context.Context, t *testing.T, server *httptest.Server, policy HedgePolicy) (status int, body string) {
+
+	t.Helper()
+	client := &http.Client{Transport: NewHedgedTransport(server.Client().Transport, policy)}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("hedged request: %v", err)
+	}
+	defer response.Body.Close()
+	data, _ := io.ReadAll(response.Body)
+	return response.StatusCode, string(data)
+}
+
+func TestHedgedTransportSlowFirst(t *testing.T) {
+
+	canceled := make(chan struct{})
+	server, calls := hedgeServer(t, func(attempt int32, w http.ResponseWriter, r *http.Request) {
+		if attempt == 1 {
+			select {
+			case <-r.Context().Done():
+				close(canceled)
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		_, _ = io.WriteString(w, "hedged")
+	})
+
+	status, body := hedgeDo(AllowHedge(context.Background()), t, server, HedgePolicy{Delay: 20 * time.Millisecond, MaxHedged: 1})
+	if status != http.StatusOK || body != "hedged" {
+		t.Fatalf("response = %d %q, want response of hedged copy", status, body)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("requests = %d, want 2", calls.Load())
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("slow request must be canceled after hedged copy answered")
+	}
+}
+
+func TestHedgedTransportServerError(t *testing.T) {
+
+	server, calls := hedgeServer(t, func(attempt int32, w http.ResponseWriter, r *http.Request) {
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	})
+
+	// 5xx отправляет копию сразу, не дожидаясь задержки
+	started := time.Now()
+	status, body := hedgeDo(AllowHedge(context.Background()), t, server, HedgePolicy{Delay: time.Minute, MaxHedged: 1})
+	if status != http.StatusOK || body != "ok" {
+		t.Fatalf("response = %d %q, want ok", status, body)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("copy after server error waited %v", elapsed)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("requests = %d, want 2", calls.Load())
+	}
+}
+
+func TestHedgedTransportAllFailed(t *testing.T) {
+
+	server, calls := hedgeServer(t, func(attempt int32, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	status, _ := hedgeDo(AllowHedge(context.Background()), t, server, HedgePolicy{Delay: time.Minute, MaxHedged: 2})
+	if status != http.StatusBadGateway {
+		t.Errorf("status = %d, want last server error", status)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("requests = %d, want 1 + MaxHedged", calls.Load())
+	}
+}
+
+func TestHedgedTransportNotAllowed(t *testing.T) {
+
+	server, calls := hedgeServer(t, func(attempt int32, w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = io.WriteString(w, "ok")
+	})
+
+	if status, _ := hedgeDo(context.Background(), t, server, HedgePolicy{Delay: time.Millisecond, MaxHedged: 2}); status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("requests without AllowHedge = %d, want 1", calls.Load())
+	}
+}
+
+func TestHedgedTransportIdempotencyKey(t *testing.T) {
+
+	server, calls := hedgeServer(t, func(attempt int32, w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = io.WriteString(w, "ok")
+	})
+
+	client := &http.Client{Transport: NewHedgedTransport(server.Client().Transport, HedgePolicy{Delay: time.Millisecond, MaxHedged: 2})}
+	request, err := http.NewRequestWithContext(AllowHedge(context.Background()), http.MethodPost, server.URL, strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set(headerIdempotencyKey, NewIdempotencyKey())
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	_ = response.Body.Close()
+	if calls.Load() != 1 {
+		t.Errorf("requests with Idempotency-Key = %d, want 1", calls.Load())
+	}
+}
+
+func TestHedgedTransportIdempotencyConflict(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		conflict func(w http.ResponseWriter)
+	}{
+		{name: "http conflict", conflict: func(w http.ResponseWriter) { w.WriteHeader(http.StatusConflict) }},
+		{name: "json-rpc conflict", conflict: func(w http.ResponseWriter) {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32009,"message":"request with the same idempotency key is in progress"}}`)
+		}},
+		{name: "json-rpc batch conflict", conflict: func(w http.ResponseWriter) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `[{"jsonrpc":"2.0","id":1,"result":true},{"jsonrpc":"2.0","id":2,"error":{"code":-32009,"message":"in progress"}}]`)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := hedgeServer(t, func(attempt int32, w http.ResponseWriter, r *http.Request) {
+				if attempt == 1 {
+					tt.conflict(w)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":1,"result":"ok"}`)
+			})
+
+			status, body := hedgeDo(AllowHedge(context.Background()), t, server, HedgePolicy{Delay: time.Minute, MaxHedged: 1})
+			if status != http.StatusOK || !strings.Contains(body, `"ok"`) {
+				t.Fatalf("response = %d %q, want response of copy after conflict", status, body)
+			}
+			if calls.Load() != 2 {
+				t.Errorf("requests = %d, want 2", calls.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package jsonrpc
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+const headerIdempotencyKey = "Idempotency-Key"
+
+type idempotencyKeyCtx struct{}
+
+// idempotentCallCtx отмечает контекст вызова идемпотентного метода: только такие запросы получают заголовок ключа.
+type idempotentCallCtx struct{}
+
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+func IdempotencyKey(ctx context.Context) string {
+
+	key, _ := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key
+}
+
+// EnsureIdempotencyKey отмечает контекст как вызов идемпотентного метода и добавляет в него ключ, если ключа нет.
+func EnsureIdempotencyKey(ctx context.Context) context.Context {
+
+	if IdempotencyKey(ctx) == "" {
+		ctx = WithIdempotencyKey(ctx, NewIdempotencyKey())
+	}
+	return context.WithValue(ctx, idempotentCallCtx{}, true)
+}
+
+// idempotentCallKey возвращает ключ идемпотентности, если контекст отмечен как вызов идемпотентного метода.
+func idempotentCallKey(ctx context.Context) string {
+
+	if idempotent, _ := ctx.Value(idempotentCallCtx{}).(bool); !idempotent {
+		return ""
+	}
+	return IdempotencyKey(ctx)
+}
+
+func NewIdempotencyKey() string {
+
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,79 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// NewInProcessTransport возвращает транспорт, передающий запросы обработчику сервера в том же процессе без сетевых соединений.
+// Сериализация сохраняется: обработчик получает закодированный запрос и проходит обычную цепочку middleware сервера.
+func NewInProcessTransport(handler http.Handler) http.RoundTripper {
+	return &inProcessTransport{handler: handler}
+}
+
+type inProcessTransport struct {
+	handler http.Handler
+}
+
+func (t *inProcessTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+
+	ctx := request.Context()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	serverRequest := request.Clone(ctx)
+	if serverRequest.Body == nil {
+		serverRequest.Body = http.NoBody
+	}
+	serverRequest.RequestURI = request.URL.RequestURI()
+	serverRequest.RemoteAddr = "127.0.0.1:0"
+	if serverRequest.Host == "" {
+		serverRequest.Host = request.URL.Host
+	}
+	writer := &inProcessWriter{header: make(http.Header)}
+	t.handler.ServeHTTP(writer, serverRequest)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if writer.code == 0 {
+		writer.code = http.StatusOK
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(writer.code) + " " + http.StatusText(writer.code),
+		StatusCode:    writer.code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        writer.header,
+		Body:          io.NopCloser(&writer.body),
+		ContentLength: int64(writer.body.Len()),
+		Request:       request,
+	}, nil
+}
+
+type inProcessWriter struct {
+	header http.Header
+	body   bytes.Buffer
+	code   int
+}
+
+func (w *inProcessWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *inProcessWriter) WriteHeader(code int) {
+
+	if w.code == 0 {
+		w.code = code
+	}
+}
+
+func (w *inProcessWriter) Write(data []byte) (int, error) {
+
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	return w.body.Write(data)
+}
@@ -4,23 +4,33 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"time"
 )
 
 func (client *ClientRPC) newRequest(ctx context.Context, reqBody any) (request *http.Request, err error) {
 
 	bodyReader := bytes.NewBuffer(nil)
-	if err = json.NewEncoder(bodyReader).Encode(reqBody); err != nil {
+	contentType := "application/json"
+	if client.options.codec != nil {
+		var data []byte
+		if data, err = client.options.codec.Marshal(reqBody); err != nil {
+			return
+		}
+		bodyReader.Write(data)
+		contentType = client.options.codec.ContentType()
+	} else if err = json.NewEncoder(bodyReader).Encode(reqBody); err != nil {
 		return
 	}
 	if request, err = http.NewRequestWithContext(ctx, http.MethodPost, client.endpoint, bodyReader); err != nil {
 		return
 	}
-	request.Header.Set("Accept", "application/json")
-	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", contentType)
+	request.Header.Set("Content-Type", contentType)
 	for k, v := range client.options.customHeaders {
 		if k == "Host" {
 			request.Host = v
@@ -42,11 +52,34 @@ func (client *ClientRPC) newRequest(ctx context.Context, reqBody any) (request *
 
 func (client *ClientRPC) doCall(ctx context.Context, request *RequestRPC) (rpcResponse *ResponseRPC, err error) {
 
+	policy := client.options.retry
+	if policy == nil || !RetryAllowed(ctx) {
+		return client.doCallOnce(ctx, request)
+	}
+	retryErr := policy.retry(ctx, func(int) (bool, time.Duration) {
+		rpcResponse, err = client.doCallOnce(ctx, request)
+		if err == nil && rpcResponse.Error != nil && policy.Retryable(rpcResponse.Error.Code) {
+			return true, rpcResponse.Error.RetryAfter()
+		}
+		return retryableCallError(ctx, policy, err)
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return
+}
+
+func (client *ClientRPC) doCallOnce(ctx context.Context, request *RequestRPC) (rpcResponse *ResponseRPC, err error) {
+
 	var httpRequest *http.Request
 	if httpRequest, err = client.newRequest(ctx, request); err != nil {
 		err = fmt.Errorf("rpc call %v() on %v: %v", request.Method, client.endpoint, err.Error())
 		return
 	}
+	// Ключ относится к одному вызову идемпотентного метода, поэтому пакеты и остальные методы его не получают
+	if key := idempotentCallKey(ctx); key != "" {
+		httpRequest.Header.Set(headerIdempotencyKey, key)
+	}
 	if client.options.before != nil {
 		ctx = client.options.before(ctx, httpRequest)
 	}
@@ -64,7 +97,7 @@ func (client *ClientRPC) doCall(ctx context.Context, request *RequestRPC) (rpcRe
 	}()
 	var httpResponse *http.Response
 	if httpResponse, err = client.httpClient.Do(httpRequest); err != nil {
-		err = fmt.Errorf("rpc call %v() on %v: %v", request.Method, httpRequest.URL.String(), err.Error())
+		err = fmt.Errorf("rpc call %v() on %v: %w", request.Method, httpRequest.URL.String(), err)
 		return
 	}
 	defer httpResponse.Body.Close()
@@ -80,16 +113,24 @@ func (client *ClientRPC) doCall(ctx context.Context, request *RequestRPC) (rpcRe
 			errorMsg = httpResponse.Status
 		}
 		return nil, &HTTPError{
-			Code: httpResponse.StatusCode,
-			err:  fmt.Errorf("rpc call %v() on %v status code: %v. %v", request.Method, httpRequest.URL.String(), httpResponse.StatusCode, errorMsg),
+			Code:       httpResponse.StatusCode,
+			RetryAfter: RetryAfter(httpResponse.Header),
+			err:        fmt.Errorf("rpc call %v() on %v status code: %v. %v", request.Method, httpRequest.URL.String(), httpResponse.StatusCode, errorMsg),
 		}
 	}
-	decoder := json.NewDecoder(httpResponse.Body)
-	if !client.options.allowUnknownFields {
-		decoder.DisallowUnknownFields()
+	if codec := client.responseCodec(httpResponse); codec != nil {
+		var body []byte
+		if body, err = io.ReadAll(httpResponse.Body); err == nil {
+			rpcResponse, err = decodeCodecResponse(codec, body)
+		}
+	} else {
+		decoder := json.NewDecoder(httpResponse.Body)
+		if !client.options.allowUnknownFields {
+			decoder.DisallowUnknownFields()
+		}
+		decoder.UseNumber()
+		err = decoder.Decode(&rpcResponse)
 	}
-	decoder.UseNumber()
-	err = decoder.Decode(&rpcResponse)
 	if err != nil {
 		return nil, fmt.Errorf("rpc call %v() on %v status code: %v. could not decode body to rpc response: %v", request.Method, httpRequest.URL.String(), httpResponse.StatusCode, err.Error())
 	}
@@ -105,6 +146,22 @@ func (client *ClientRPC) doCall(ctx context.Context, request *RequestRPC) (rpcRe
 
 func (client *ClientRPC) doBatchCall(ctx context.Context, rpcRequests []*RequestRPC) (rpcResponses ResponsesRPC, err error) {
 
+	policy := client.options.retry
+	if policy == nil || !idempotentRequests(rpcRequests) {
+		return client.doBatchCallOnce(ctx, rpcRequests)
+	}
+	retryErr := policy.retry(ctx, func(int) (bool, time.Duration) {
+		rpcResponses, err = client.doBatchCallOnce(ctx, rpcRequests)
+		return retryableCallError(ctx, policy, err)
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return
+}
+
+func (client *ClientRPC) doBatchCallOnce(ctx context.Context, rpcRequests []*RequestRPC) (rpcResponses ResponsesRPC, err error) {
+
 	defer func() {
 		if err != nil {
 			for _, request := range rpcRequests {
@@ -143,7 +200,7 @@ func (client *ClientRPC) doBatchCall(ctx context.Context, rpcRequests []*Request
 	}()
 	var httpResponse *http.Response
 	if httpResponse, err = client.httpClient.Do(httpRequest); err != nil {
-		err = fmt.Errorf("rpc batch call on %v: %v", httpRequest.URL.String(), err.Error())
+		err = fmt.Errorf("rpc batch call on %v: %w", httpRequest.URL.String(), err)
 		return
 	}
 	defer httpResponse.Body.Close()
@@ -159,16 +216,24 @@ func (client *ClientRPC) doBatchCall(ctx context.Context, rpcRequests []*Request
 			errorMsg = httpResponse.Status
 		}
 		return nil, &HTTPError{
-			Code: httpResponse.StatusCode,
-			err:  fmt.Errorf("rpc batch call on %v status code: %v. %v", httpRequest.URL.String(), httpResponse.StatusCode, errorMsg),
+			Code:       httpResponse.StatusCode,
+			RetryAfter: RetryAfter(httpResponse.Header),
+			err:        fmt.Errorf("rpc batch call on %v status code: %v. %v", httpRequest.URL.String(), httpResponse.StatusCode, errorMsg),
 		}
 	}
-	decoder := json.NewDecoder(httpResponse.Body)
-	if !client.options.allowUnknownFields {
-		decoder.DisallowUnknownFields()
+	if codec := client.responseCodec(httpResponse); codec != nil {
+		var body []byte
+		if body, err = io.ReadAll(httpResponse.Body); err == nil {
+			rpcResponses, err = decodeCodecResponses(codec, body)
+		}
+	} else {
+		decoder := json.NewDecoder(httpResponse.Body)
+		if !client.options.allowUnknownFields {
+			decoder.DisallowUnknownFields()
+		}
+		decoder.UseNumber()
+		err = decoder.Decode(&rpcResponses)
 	}
-	decoder.UseNumber()
-	err = decoder.Decode(&rpcResponses)
 	if err != nil {
 		err = fmt.Errorf("rpc batch call on %v status code: %v. could not decode body to rpc response: %v", httpRequest.URL.String(), httpResponse.StatusCode, err.Error())
 		return
@@ -191,3 +256,25 @@ func (client *ClientRPC) doBatchCall(ctx context.Context, rpcRequests []*Request
 	}
 	return
 }
+
+func retryableCallError(ctx context.Context, policy *RetryPolicy, err error) (retry bool, retryAfter time.Duration) {
+
+	if err == nil {
+		return false, 0
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return policy.Retryable(httpErr.Code), httpErr.RetryAfter
+	}
+	return retryableError(ctx, err), 0
+}
+
+func idempotentRequests(requests []*RequestRPC) bool {
+
+	for _, request := range requests {
+		if !request.Idempotent {
+			return false
+		}
+	}
+	return len(requests) != 0
+}
@@ -0,0 +1,155 @@
+package jsonrpc
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// LimitPolicy - ограничения вызовов на стороне клиента.
+type LimitPolicy struct {
+	// Rate - число запросов в секунду (0 - без ограничения частоты).
+	Rate float64
+	// Burst - число запросов, которые можно отправить сразу без ожидания (по умолчанию - округлённый вверх Rate, не меньше 1).
+	Burst int
+	// MaxInFlight - максимальное число одновременных запросов (0 - без ограничения).
+	MaxInFlight int
+}
+
+// Limiters - ограничители частоты и числа одновременных вызовов клиента: общий для всех методов и по методам.
+type Limiters struct {
+	global   *limiter
+	policies map[string]LimitPolicy
+
+	mu       sync.Mutex
+	limiters map[string]*limiter
+}
+
+type limiter struct {
+	rate     float64
+	burst    float64
+	inFlight chan struct{}
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiters создаёт ограничители с общей политикой policy (nil - только для методов из policies).
+func NewLimiters(policy *LimitPolicy, policies map[string]LimitPolicy) *Limiters {
+
+	l := &Limiters{
+		policies: policies,
+		limiters: make(map[string]*limiter),
+	}
+	if policy != nil {
+		l.global = newLimiter(*policy)
+	}
+	return l
+}
+
+// Acquire ждёт разрешения на вызов метода с учётом общего ограничения и ограничения метода.
+// release освобождает слот одновременного вызова и должен быть вызван ровно один раз.
+func (l *Limiters) Acquire(ctx context.Context, method string) (release func(), err error) {
+
+	var releaseGlobal func()
+	if releaseGlobal, err = l.global.acquire(ctx); err != nil {
+		return nil, err
+	}
+	var releaseMethod func()
+	if releaseMethod, err = l.limiter(method).acquire(ctx); err != nil {
+		releaseGlobal()
+		return nil, err
+	}
+	return func() {
+		releaseMethod()
+		releaseGlobal()
+	}, nil
+}
+
+func (l *Limiters) limiter(method string) *limiter {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lim, found := l.limiters[method]; found {
+		return lim
+	}
+	policy, found := l.policies[method]
+	if !found {
+		return nil
+	}
+	lim := newLimiter(policy)
+	l.limiters[method] = lim
+	return lim
+}
+
+func newLimiter(policy LimitPolicy) *limiter {
+
+	lim := &limiter{rate: policy.Rate}
+	if policy.Rate > 0 {
+		lim.burst = float64(policy.Burst)
+		if lim.burst <= 0 {
+			lim.burst = math.Max(math.Ceil(policy.Rate), 1)
+		}
+		lim.tokens = lim.burst
+		lim.last = time.Now()
+	}
+	if policy.MaxInFlight > 0 {
+		lim.inFlight = make(chan struct{}, policy.MaxInFlight)
+	}
+	return lim
+}
+
+func (lim *limiter) acquire(ctx context.Context) (release func(), err error) {
+
+	if lim == nil {
+		return func() {}, nil
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	release = func() {}
+	if lim.inFlight != nil {
+		select {
+		case lim.inFlight <- struct{}{}:
+			release = func() { <-lim.inFlight }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if err = lim.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// wait резервирует токен и ждёт его появления; при отмене контекста токен возвращается.
+func (lim *limiter) wait(ctx context.Context) error {
+
+	if lim.rate <= 0 {
+		return nil
+	}
+	lim.mu.Lock()
+	now := time.Now()
+	lim.tokens = math.Min(lim.burst, lim.tokens+now.Sub(lim.last).Seconds()*lim.rate)
+	lim.last = now
+	lim.tokens--
+	delay := time.Duration(-lim.tokens / lim.rate * float64(time.Second))
+	lim.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		lim.mu.Lock()
+		lim.tokens++
+		lim.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,114 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// acquireWithin проверяет, получено ли разрешение на вызов за время timeout.
+func acquireWithin(limiters *Limiters, method string, timeout time.Duration) (release func(), err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return limiters.Acquire(ctx, method)
+}
+
+func TestLimitersRate(t *testing.T) {
+
+	limiters := NewLimiters(&LimitPolicy{Rate: 20, Burst: 2}, nil)
+
+	for i := range 2 {
+		release, err := acquireWithin(limiters, "svc.get", time.Millisecond)
+		if err != nil {
+			t.Fatalf("call %d within burst must not wait: %v", i+1, err)
+		}
+		release()
+	}
+	if _, err := acquireWithin(limiters, "svc.get", time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("call over burst must wait for token, got %v", err)
+	}
+	// отменённое ожидание возвращает токен: следующий появляется через 1/Rate
+	started := time.Now()
+	release, err := acquireWithin(limiters, "svc.get", time.Second)
+	if err != nil {
+		t.Fatalf("call must get token after 1/Rate: %v", err)
+	}
+	release()
+	if elapsed := time.Since(started); elapsed < 30*time.Millisecond || elapsed > 500*time.Millisecond {
+		t.Errorf("waited %v for token at 20 rps, want about 50ms", elapsed)
+	}
+}
+
+func TestLimitersDefaultBurst(t *testing.T) {
+
+	limiters := NewLimiters(&LimitPolicy{Rate: 2.5}, nil)
+
+	for i := range 3 {
+		release, err := acquireWithin(limiters, "svc.get", time.Millisecond)
+		if err != nil {
+			t.Fatalf("call %d within default burst ceil(Rate) must not wait: %v", i+1, err)
+		}
+		release()
+	}
+	if _, err := acquireWithin(limiters, "svc.get", time.Millisecond); err == nil {
+		t.Error("call over default burst must wait")
+	}
+}
+
+func TestLimitersMaxInFlight(t *testing.T) {
+
+	limiters := NewLimiters(&LimitPolicy{MaxInFlight: 1}, nil)
+
+	release, err := acquireWithin(limiters, "svc.get", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = acquireWithin(limiters, "svc.list", 10*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second concurrent call must wait for slot, got %v", err)
+	}
+	release()
+	if release, err = acquireWithin(limiters, "svc.list", time.Millisecond); err != nil {
+		t.Fatalf("call after release must get slot: %v", err)
+	}
+	release()
+}
+
+func TestLimitersMethodPolicies(t *testing.T) {
+
+	limiters := NewLimiters(nil, map[string]LimitPolicy{"svc.get": {MaxInFlight: 1}})
+
+	for range 3 {
+		if _, err := acquireWithin(limiters, "svc.list", time.Millisecond); err != nil {
+			t.Fatalf("method without policy must not be limited: %v", err)
+		}
+	}
+	release, err := acquireWithin(limiters, "svc.get", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	if _, err = acquireWithin(limiters, "svc.get", 10*time.Millisecond); err == nil {
+		t.Error("method policy must limit concurrent calls")
+	}
+	if _, err = acquireWithin(limiters, "svc.list", time.Millisecond); err != nil {
+		t.Errorf("method policy must not limit other methods: %v", err)
+	}
+}
+
+func TestLimitersCanceledContext(t *testing.T) {
+
+	limiters := NewLimiters(&LimitPolicy{Rate: 1, MaxInFlight: 1}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := limiters.Acquire(ctx, "svc.get"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled context must fail, got %v", err)
+	}
+	// отказ не расходует ни слот, ни токен
+	release, err := acquireWithin(limiters, "svc.get", time.Millisecond)
+	if err != nil {
+		t.Fatalf("canceled call must not consume limits: %v", err)
+	}
+	release()
+}
@@ -14,6 +14,8 @@ type options struct {
 	clientHTTP         *http.Client
 	headersFromCtx     []any
 	customHeaders      map[string]string
+	codec              Codec
+	retry              *RetryPolicy
 	before             func(ctx context.Context, req *http.Request) context.Context
 	after              func(ctx context.Context, res *http.Response) error
 }
@@ -76,3 +78,15 @@ func LogOnError() Option {
 		ops.logOnError = true
 	}
 }
+
+func WithCodec(codec Codec) Option {
+	return func(ops *options) {
+		ops.codec = codec
+	}
+}
+
+func WithRetry(policy RetryPolicy) Option {
+	return func(ops *options) {
+		ops.retry = &policy
+	}
+}
@@ -5,6 +5,8 @@ type RequestRPC struct {
 	Method  string `json:"method"`
 	Params  any    `json:"params,omitempty"`
 	JSONRPC string `json:"jsonrpc"`
+	// Idempotent разрешает повтор batch-вызова: batch повторяется, только если все его запросы идемпотентны.
+	Idempotent bool `json:"-"`
 }
 
 type RequestsRPC []*RequestRPC
@@ -9,6 +9,9 @@ type ResponseRPC struct {
 	JSONRPC string          `json:"jsonrpc"`
 	Error   *RPCError       `json:"error,omitempty"`
 	Result  json.RawMessage `json:"result,omitempty"`
+
+	codec   Codec
+	encoded []byte
 }
 
 type ResponsesRPC []*ResponseRPC
@@ -43,5 +46,12 @@ func (res ResponsesRPC) HasError() bool {
 }
 
 func (responseRPC *ResponseRPC) GetObject(object any) error {
+
+	if responseRPC.codec != nil {
+		if len(responseRPC.encoded) == 0 {
+			return nil
+		}
+		return responseRPC.codec.Unmarshal(responseRPC.encoded, object)
+	}
 	return json.Unmarshal(responseRPC.Result, object)
 }
@@ -0,0 +1,176 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy - политика повторов идемпотентных вызовов: число попыток, экспоненциальная задержка с разбросом и повторяемые коды.
+type RetryPolicy struct {
+	// MaxAttempts - максимальное число попыток, включая первую.
+	MaxAttempts int
+	// BaseDelay - задержка перед первым повтором, далее удваивается.
+	BaseDelay time.Duration
+	// MaxDelay - верхняя граница задержки (в том числе для Retry-After).
+	MaxDelay time.Duration
+	// Jitter - доля случайного разброса задержки (0..1).
+	Jitter float64
+	// RetryCodes - HTTP-статусы и коды ошибок JSON-RPC, при которых выполняется повтор.
+	RetryCodes []int
+}
+
+// DefaultRetryPolicy возвращает политику по умолчанию: 3 попытки, 100ms..5s, разброс 20%, статусы 429/502/503/504 и ошибка JSON-RPC -32029.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+		RetryCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+			CodeRateLimited,
+		},
+	}
+}
+
+type retryCtx struct{}
+
+type retryObserverCtx struct{}
+
+// AllowRetry помечает вызов как идемпотентный: только такие вызовы повторяются по политике WithRetry.
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryCtx{}, true)
+}
+
+// RetryAllowed сообщает, разрешены ли повторы для вызова с этим контекстом.
+func RetryAllowed(ctx context.Context) bool {
+
+	allowed, _ := ctx.Value(retryCtx{}).(bool)
+	return allowed
+}
+
+// WithRetryObserver задаёт функцию, вызываемую перед каждым повтором (например, для метрик).
+func WithRetryObserver(ctx context.Context, observer func(attempt int)) context.Context {
+	return context.WithValue(ctx, retryObserverCtx{}, observer)
+}
+
+func retryObserver(ctx context.Context) func(attempt int) {
+
+	observer, _ := ctx.Value(retryObserverCtx{}).(func(attempt int))
+	return observer
+}
+
+// Retryable сообщает, является ли код повторяемым по политике.
+func (p *RetryPolicy) Retryable(code int) bool {
+	return slices.Contains(p.RetryCodes, code)
+}
+
+// Delay возвращает задержку перед повтором с номером attempt (с 1); retryAfter от сервера имеет приоритет.
+func (p *RetryPolicy) Delay(attempt int, retryAfter time.Duration) (delay time.Duration) {
+
+	if retryAfter > 0 {
+		if p.MaxDelay > 0 && retryAfter > p.MaxDelay {
+			return p.MaxDelay
+		}
+		return retryAfter
+	}
+	delay = time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(attempt-1)))
+	if p.MaxDelay > 0 && (delay > p.MaxDelay || delay <= 0) {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		spread := float64(delay) * min(p.Jitter, 1)
+		delay = time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+	}
+	return max(delay, 0)
+}
+
+// RetryAfter разбирает заголовок Retry-After (секунды или HTTP-дата).
+func RetryAfter(header http.Header) time.Duration {
+
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+// retry выполняет call, пока он сообщает о повторяемой ошибке и не исчерпаны попытки.
+func (p *RetryPolicy) retry(ctx context.Context, call func(attempt int) (retry bool, retryAfter time.Duration)) (err error) {
+
+	observer := retryObserver(ctx)
+	for attempt := 1; ; attempt++ {
+		retry, retryAfter := call(attempt)
+		if !retry || attempt >= p.MaxAttempts {
+			return nil
+		}
+		timer := time.NewTimer(p.Delay(attempt, retryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		if observer != nil {
+			observer(attempt)
+		}
+	}
+}
+
+// retryableError сообщает, является ли ошибка транспорта повторяемой (сетевая ошибка при живом контексте).
+func retryableError(ctx context.Context, err error) bool {
+
+	var urlErr *url.Error
+	return ctx.Err() == nil && errors.As(err, &urlErr)
+}
+
+// DoRetry выполняет HTTP-запрос с повторами по политике, если они разрешены контекстом запроса (AllowRetry).
+// Тело запроса перечитывается через GetBody; запросы без GetBody не повторяются.
+func DoRetry(client *http.Client, request *http.Request, policy *RetryPolicy) (response *http.Response, err error) {
+
+	ctx := request.Context()
+	if policy == nil || !RetryAllowed(ctx) {
+		return client.Do(request)
+	}
+	if request.Body != nil && request.GetBody == nil {
+		return client.Do(request)
+	}
+	retryErr := policy.retry(ctx, func(attempt int) (retry bool, retryAfter time.Duration) {
+		if attempt > 1 && request.GetBody != nil {
+			if request.Body, err = request.GetBody(); err != nil {
+				return false, 0
+			}
+		}
+		if response, err = client.Do(request); err != nil {
+			return retryableError(ctx, err), 0
+		}
+		if !policy.Retryable(response.StatusCode) || attempt >= policy.MaxAttempts {
+			return false, 0
+		}
+		retryAfter = RetryAfter(response.Header)
+		_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+		_ = response.Body.Close()
+		return true, retryAfter
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return
+}
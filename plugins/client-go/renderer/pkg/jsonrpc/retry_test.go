@@ -0,0 +1,271 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy возвращает политику без задержек и разброса.
+func testRetryPolicy(attempts int) RetryPolicy {
+
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = attempts
+	policy.BaseDelay = time.Millisecond
+	policy.Jitter = 0
+	return policy
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter time.Duration
+		want       time.Duration
+	}{
+		{name: "first retry", attempt: 1, want: 100 * time.Millisecond},
+		{name: "exponential", attempt: 3, want: 400 * time.Millisecond},
+		{name: "capped", attempt: 10, want: time.Second},
+		{name: "overflow capped", attempt: 100, want: time.Second},
+		{name: "retry after", attempt: 1, retryAfter: 300 * time.Millisecond, want: 300 * time.Millisecond},
+		{name: "retry after capped", attempt: 1, retryAfter: time.Minute, want: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Delay(tt.attempt, tt.retryAfter); got != tt.want {
+				t.Errorf("Delay(%d, %v) = %v, want %v", tt.attempt, tt.retryAfter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelayJitter(t *testing.T) {
+
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, Jitter: 0.2}
+	for range 100 {
+		if delay := policy.Delay(1, 0); delay < 80*time.Millisecond || delay > 120*time.Millisecond {
+			t.Fatalf("Delay with 20%% jitter = %v, want 80ms..120ms", delay)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		approx bool
+	}{
+		{name: "missing", value: "", want: 0},
+		{name: "seconds", value: "3", want: 3 * time.Second},
+		{name: "negative seconds", value: "-3", want: 0},
+		{name: "http date", value: time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), want: 10 * time.Second, approx: true},
+		{name: "past http date", value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), want: 0},
+		{name: "invalid", value: "soon", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+			got := RetryAfter(header)
+			if tt.approx {
+				if got <= tt.want-2*time.Second || got > tt.want {
+					t.Errorf("RetryAfter(%q) = %v, want about %v", tt.value, got, tt.want)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoRetry(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		allow     bool
+		failures  int32
+		status    int
+		wantCalls int32
+		wantCode  int
+	}{
+		{name: "retried until success", allow: true, failures: 2, status: http.StatusServiceUnavailable, wantCalls: 3, wantCode: http.StatusOK},
+		{name: "attempts exhausted", allow: true, failures: 5, status: http.StatusServiceUnavailable, wantCalls: 3, wantCode: http.StatusServiceUnavailable},
+		{name: "not retryable status", allow: true, failures: 1, status: http.StatusInternalServerError, wantCalls: 1, wantCode: http.StatusInternalServerError},
+		{name: "not idempotent", allow: false, failures: 1, status: http.StatusServiceUnavailable, wantCalls: 1, wantCode: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != "payload" {
+					t.Errorf("attempt %d: body = %q, want payload", calls.Load()+1, body)
+				}
+				if calls.Add(1) <= tt.failures {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			if tt.allow {
+				ctx = AllowRetry(ctx)
+			}
+			request, err := http.NewRequestWithContext(ctx, http.MethodPut, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			policy := testRetryPolicy(3)
+			response, err := DoRetry(server.Client(), request, &policy)
+			if err != nil {
+				t.Fatalf("DoRetry: %v", err)
+			}
+			_ = response.Body.Close()
+			if response.StatusCode != tt.wantCode {
+				t.Errorf("status = %d, want %d", response.StatusCode, tt.wantCode)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDoRetryContextCanceled(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(AllowRetry(context.Background()), 50*time.Millisecond)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := testRetryPolicy(3)
+	policy.MaxDelay = time.Minute
+	started := time.Now()
+	if _, err = DoRetry(server.Client(), request, &policy); err == nil {
+		t.Fatal("DoRetry must fail when context is done while waiting for Retry-After")
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("DoRetry waited %v after context deadline", elapsed)
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+
+	var calls atomic.Int32
+	var observed []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request RequestRPC
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		response := ResponseRPC{JSONRPC: Version, ID: request.ID}
+		if calls.Add(1) == 1 {
+			response.Error = &RPCError{Code: http.StatusServiceUnavailable, Message: "unavailable"}
+		} else {
+			response.Result = json.RawMessage(`"ok"`)
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRetry(testRetryPolicy(3)))
+	ctx := WithRetryObserver(AllowRetry(context.Background()), func(attempt int) { observed = append(observed, attempt) })
+	var result string
+	if err := client.CallFor(ctx, &result, "svc.ping"); err != nil {
+		t.Fatalf("CallFor: %v", err)
+	}
+	if result != "ok" || calls.Load() != 2 {
+		t.Errorf("result = %q after %d calls, want ok after 2", result, calls.Load())
+	}
+	if len(observed) != 1 || observed[0] != 1 {
+		t.Errorf("observed retries = %v, want [1]", observed)
+	}
+
+	calls.Store(0)
+	if err := client.CallFor(context.Background(), &result, "svc.ping"); err == nil {
+		t.Error("call without AllowRetry must not be retried")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls without AllowRetry = %d, want 1", calls.Load())
+	}
+}
+
+func TestRPCErrorRetryAfter(t *testing.T) {
+
+	tests := []struct {
+		name string
+		data string
+		want time.Duration
+	}{
+		{name: "no data", data: "", want: 0},
+		{name: "seconds", data: `{"retryAfter":3}`, want: 3 * time.Second},
+		{name: "negative", data: `{"retryAfter":-1}`, want: 0},
+		{name: "other data", data: `{"field":"name"}`, want: 0},
+		{name: "not object", data: `"limit"`, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpcErr := RPCError{Code: CodeRateLimited, Data: json.RawMessage(tt.data)}
+			if got := rpcErr.RetryAfter(); got != tt.want {
+				t.Errorf("RetryAfter(%s) = %v, want %v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientRetryRateLimited(t *testing.T) {
+
+	var calls atomic.Int32
+	var retried time.Duration
+	var started time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request RequestRPC
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		response := ResponseRPC{JSONRPC: Version, ID: request.ID}
+		if calls.Add(1) == 1 {
+			started = time.Now()
+			response.Error = &RPCError{Code: CodeRateLimited, Message: "rate limit exceeded", Data: json.RawMessage(`{"retryAfter":1}`)}
+		} else {
+			retried = time.Since(started)
+			response.Result = json.RawMessage(`"ok"`)
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.Jitter = 0
+	client := NewClient(server.URL, WithRetry(policy))
+	var result string
+	if err := client.CallFor(AllowRetry(context.Background()), &result, "svc.ping"); err != nil {
+		t.Fatalf("CallFor: %v", err)
+	}
+	if result != "ok" || calls.Load() != 2 {
+		t.Errorf("result = %q after %d calls, want ok after 2", result, calls.Load())
+	}
+	if retried < time.Second {
+		t.Errorf("retried after %v, want data.retryAfter 1s", retried)
+	}
+}
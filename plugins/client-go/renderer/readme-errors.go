@@ -105,6 +105,52 @@ func main() {
 }`, pkgPath, pkgName))
 
 	md.PlainText("HTTP клиент автоматически проверяет статус код ответа и возвращает ошибку, если код не соответствует ожидаемому успешному коду для метода.")
+
+	r.renderTypedErrorsSection(md, pkgPath, pkgName)
+}
+
+// renderTypedErrorsSection генерирует описание типизированных ошибок, объявленных в контрактах
+func (r *ClientRenderer) renderTypedErrorsSection(md *markdown.Markdown, pkgPath, pkgName string) {
+
+	for _, contractName := range r.contractKeys() {
+		contract := r.findContract(contractName)
+		if contract == nil {
+			continue
+		}
+		for _, method := range contract.Methods {
+			errs := r.typedErrors(method)
+			if len(errs) == 0 {
+				continue
+			}
+			typedErrorsAnchor := generateAnchor("Типизированные ошибки")
+			md.PlainText(fmt.Sprintf("<a id=\"%s\"></a>", typedErrorsAnchor))
+			md.LF()
+			md.H3("Типизированные ошибки")
+			md.PlainText("Ошибки, объявленные в контракте с HTTP кодом (" + markdown.Code("@tg 404=pkg:ErrNotFound") + "), декодируются в типы клиента по коду ошибки JSON-RPC или статусу HTTP ответа. " +
+				"Тип содержит текст ошибки, данные ответа и код (" + markdown.Code("Code()") + "); исходная ошибка доступна через " + markdown.Code("errors.Unwrap") + ". Список ошибок приведён в описании каждого метода. " +
+				"Если один код объявлен у нескольких ошибок метода, по ответу их не различить: такие ошибки не декодируются в типы и возвращаются как ошибки транспорта.")
+			name := r.typedErrorName(errs[0])
+			results := strings.Repeat("_, ", len(r.resultsWithoutError(method)))
+			md.CodeBlocks(markdown.SyntaxHighlightGo, fmt.Sprintf(`package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "%s"
+)
+
+func main() {
+    client := %s.New("http://localhost:9000")
+
+    %serr := client.%s().%s(context.Background() /* ... */)
+    if target := new(%s.%s); errors.As(err, target) {
+        fmt.Printf("%%d: %%s\n", target.Code(), target.Message)
+    }
+}`, pkgPath, pkgName, results, contract.Name, method.Name, pkgName, name))
+			return
+		}
+	}
 }
 
 // renderBatchExample генерирует пример использования batch запросов
@@ -308,6 +354,7 @@ func (r *ClientRenderer) renderBatchExample(md *markdown.Markdown, contracts []*
 		"Callbacks": callbacks,
 		"Requests":  requests,
 		"NeedsDto":  needsDto,
+		"TypesPkg":  r.typesPackage(),
 	}
 
 	batchExample, err := r.renderTemplate("templates/batch_example.tmpl", templateData)
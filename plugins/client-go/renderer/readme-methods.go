@@ -41,9 +41,11 @@ func (r *ClientRenderer) renderMethodDoc(md *markdown.Markdown, method *core.Met
 		md.PlainText(desc)
 		md.LF()
 	}
+	r.renderMethodDeprecation(md, contract, method)
 
 	// Сигнатура метода
 	r.renderMethodSignature(md, method, contract, outDir, false)
+	r.renderMethodPagination(md, method, contract)
 
 	// Параметры и возвращаемые значения
 	r.renderMethodParamsAndResults(md, method, contract, typeUsages)
@@ -90,9 +92,11 @@ func (r *ClientRenderer) renderHTTPMethodDoc(md *markdown.Markdown, method *core
 		md.PlainText(desc)
 		md.LF()
 	}
+	r.renderMethodDeprecation(md, contract, method)
 
 	// Сигнатура метода
 	r.renderMethodSignature(md, method, contract, outDir, true)
+	r.renderMethodPagination(md, method, contract)
 
 	// Параметры и возвращаемые значения
 	r.renderMethodParamsAndResults(md, method, contract, typeUsages)
@@ -153,6 +157,36 @@ func (r *ClientRenderer) renderMethodSignature(md *markdown.Markdown, method *co
 	md.LF()
 }
 
+// renderMethodPagination генерирует описание итератора по страницам метода с аннотацией paginate
+func (r *ClientRenderer) renderMethodPagination(md *markdown.Markdown, method *core.Method, contract *core.Contract) {
+	p := r.methodPagination(method)
+	if p == nil {
+		return
+	}
+	description := fmt.Sprintf("%s возвращает iter.Seq2 по элементам %s всех страниц: следующая страница запрашивается по курсору %s, обход завершается на пустом курсоре, ошибке или отмене контекста.",
+		markdown.Code(r.paginateFuncName(method)), markdown.Code(p.items.Name), markdown.Code(p.next.Name))
+	if p.pageSize != nil {
+		description += fmt.Sprintf(" Размер страницы задаётся параметром %s.", markdown.Code(p.pageSize.Name))
+	}
+	md.PlainText(markdown.Bold("Пагинация:") + " " + description)
+	md.LF()
+
+	var args []string
+	for _, arg := range r.argsWithoutContext(method) {
+		if arg != p.cursor {
+			args = append(args, arg.Name)
+		}
+	}
+	call := strings.Join(append([]string{"ctx"}, args...), ", ")
+	md.CodeBlocks(markdown.SyntaxHighlightGo, fmt.Sprintf(`for item, err := range client.%s().%s(%s) {
+    if err != nil {
+        return err
+    }
+    _ = item
+}`, contract.Name, r.paginateFuncName(method), call))
+	md.LF()
+}
+
 // renderMethodParamsAndResults генерирует таблицы параметров и возвращаемых значений
 func (r *ClientRenderer) renderMethodParamsAndResults(md *markdown.Markdown, method *core.Method, contract *core.Contract, typeUsages map[string]*typeUsage) {
 	args := r.argsWithoutContext(method)
@@ -260,11 +294,24 @@ func (r *ClientRenderer) renderMethodErrors(md *markdown.Markdown, method *core.
 		return errors[i].HTTPCode < errors[j].HTTPCode
 	})
 
+	// Ошибки, которые клиент декодирует в типы
+	typed := make(map[*core.ErrorInfo]bool)
+	for _, errInfo := range r.typedErrors(method) {
+		typed[errInfo] = true
+	}
+	ambiguous := r.ambiguousErrors(method)
+
 	// Описываем все ошибки
 	for _, errInfo := range errors {
 		// Заголовок ошибки
 		if errInfo.HTTPCode != 0 {
 			errorDesc := fmt.Sprintf("%s (%d)", errInfo.HTTPCodeText, errInfo.HTTPCode)
+			if typed[errInfo] {
+				errorDesc += ", " + markdown.Code(r.typedErrorsDoc(errInfo))
+			}
+			if _, shared := ambiguous[errInfo.HTTPCode]; shared {
+				errorDesc = fmt.Sprintf("%s, %s - не декодируется в тип: код объявлен у нескольких ошибок", errorDesc, markdown.Code(errInfo.TypeName))
+			}
 			md.PlainText(fmt.Sprintf("- %s - %s", markdown.Code(fmt.Sprintf("%d", errInfo.HTTPCode)), errorDesc))
 		} else {
 			md.PlainText(fmt.Sprintf("- %s", markdown.Code(errInfo.TypeName)))
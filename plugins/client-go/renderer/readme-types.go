@@ -167,6 +167,18 @@ func (r *ClientRenderer) goTypeStringFromStructField(field *core.StructField, pk
 	return r.goTypeString(field.TypeID, pkgPath)
 }
 
+// typeAlias возвращает имя пакета импортированного типа в клиенте: типы проекта генерируются в пакете типов клиента.
+func (r *ClientRenderer) typeAlias(typ *core.Type) string {
+
+	if r.isTypeFromCurrentProject(typ.ImportPkgPath) {
+		return r.typesPackage()
+	}
+	if typ.ImportAlias != "" {
+		return typ.ImportAlias
+	}
+	return filepath.Base(typ.ImportPkgPath)
+}
+
 // goTypeString возвращает строковое представление Go типа
 func (r *ClientRenderer) goTypeString(typeID, pkgPath string) string {
 	typ, ok := r.project.Types[typeID]
@@ -197,10 +209,7 @@ func (r *ClientRenderer) goTypeString(typeID, pkgPath string) string {
 
 	// Сначала проверяем импортированные типы (имеют ImportPkgPath)
 	if typ.ImportPkgPath != "" {
-		alias := typ.ImportAlias
-		if alias == "" {
-			alias = filepath.Base(typ.ImportPkgPath)
-		}
+		alias := r.typeAlias(typ)
 
 		typeName := typ.TypeName
 		if typeName == "" {
@@ -223,10 +232,7 @@ func (r *ClientRenderer) goTypeString(typeID, pkgPath string) string {
 		core.TypeKindUint, core.TypeKindUint8, core.TypeKindUint16, core.TypeKindUint32, core.TypeKindUint64,
 		core.TypeKindFloat32, core.TypeKindFloat64, core.TypeKindBool, core.TypeKindByte, core.TypeKindRune, core.TypeKindError, core.TypeKindAny:
 		if typ.ImportPkgPath != "" && typ.TypeName != "" {
-			alias := typ.ImportAlias
-			if alias == "" {
-				alias = filepath.Base(typ.ImportPkgPath)
-			}
+			alias := r.typeAlias(typ)
 			return fmt.Sprintf("%s.%s", alias, typ.TypeName)
 		}
 		return string(typ.Kind)
@@ -242,10 +248,7 @@ func (r *ClientRenderer) goTypeString(typeID, pkgPath string) string {
 		}
 
 		if typ.ImportPkgPath != "" {
-			alias := typ.ImportAlias
-			if alias == "" {
-				alias = filepath.Base(typ.ImportPkgPath)
-			}
+			alias := r.typeAlias(typ)
 			if structName != "" {
 				return fmt.Sprintf("%s.%s", alias, structName)
 			}
@@ -270,10 +273,7 @@ func (r *ClientRenderer) goTypeString(typeID, pkgPath string) string {
 	// Если TypeName задан, используем его
 	if typ.TypeName != "" {
 		if typ.ImportPkgPath != "" {
-			alias := typ.ImportAlias
-			if alias == "" {
-				alias = filepath.Base(typ.ImportPkgPath)
-			}
+			alias := r.typeAlias(typ)
 			return fmt.Sprintf("%s.%s", alias, typ.TypeName)
 		}
 		return typ.TypeName
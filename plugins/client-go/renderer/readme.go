@@ -207,6 +207,10 @@ func (r *ClientRenderer) RenderReadmeGo(docOpts interface{}) error {
 		md.PlainText(fmt.Sprintf("- [Метрики](#%s)", generateAnchor("Метрики")))
 		md.LF()
 	}
+	if r.HasTrace() {
+		md.PlainText(fmt.Sprintf("- [Трассировка](#%s)", generateAnchor("Трассировка")))
+		md.LF()
+	}
 	md.HorizontalRule()
 
 	// Описание клиента
@@ -238,6 +242,11 @@ func (r *ClientRenderer) RenderReadmeGo(docOpts interface{}) error {
 		r.renderMetricsSection(md, outDir)
 	}
 
+	// Трассировка
+	if r.HasTrace() {
+		r.renderTracingSection(md, outDir)
+	}
+
 	if err = md.Build(); err != nil {
 		return err
 	}
@@ -273,9 +282,25 @@ func (r *ClientRenderer) renderClientDescription(md *markdown.Markdown) {
 	if r.HasMetrics() {
 		capabilities = append(capabilities, "Поддержка Prometheus метрик")
 	}
+	if r.HasTrace() {
+		capabilities = append(capabilities, "Трассировка OpenTelemetry с передачей W3C Trace Context и Baggage")
+	}
 	md.BulletList(capabilities...)
 	md.LF()
 
+	if r.IsModule() {
+		version := r.moduleVersion()
+		if version == "(devel)" {
+			version = "latest"
+		}
+		md.PlainText(markdown.Bold("Установка:"))
+		md.LF()
+		md.CodeBlocks(markdown.SyntaxHighlightShell, fmt.Sprintf("go get %s@%s", r.modulePath, version))
+		md.LF()
+		md.PlainText("go.sum модуля не генерируется: перед сборкой, тестами или публикацией модуля выполните " + markdown.Code("go mod tidy") + " в его директории.")
+		md.LF()
+	}
+
 	// Находим первый доступный контракт и метод для примеров
 	var exampleContract *core.Contract
 	var exampleMethod *core.Method
@@ -672,6 +697,143 @@ client := %s.New("http://localhost:9000",
 		})
 	}
 
+	if r.HasJsonRPC() || r.HasHTTP() {
+		options = append(options, struct {
+			name        string
+			description string
+			signature   string
+			example     string
+		}{
+			name:        "WithCodec",
+			description: "Устанавливает кодек тел запросов и ответов (например, application/msgpack или application/cbor). Кодек передаётся в заголовках Content-Type и Accept; если сервер ответил JSON, ответ декодируется как JSON. Реализация кодека должна учитывать json-теги структур.",
+			signature:   "func WithCodec(codec Codec) Option",
+			example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithCodec(msgpackCodec{}),
+)`, pkgName, pkgName),
+		})
+	}
+
+	if r.HasJsonRPC() || r.HasHTTP() {
+		options = append(options, []struct {
+			name        string
+			description string
+			signature   string
+			example     string
+		}{
+			{
+				name:        "WithCircuitBreaker",
+				description: "Включает автоматический выключатель для каждого метода: после FailureThreshold отказов подряд (сетевые ошибки, таймауты, HTTP 5xx и 429) вызовы метода завершаются ошибкой ErrBreakerOpen без обращения к серверу, через OpenTimeout выполняются пробные запросы (half-open). Состояние доступно в метриках client_breaker_state и client_breaker_transitions_count.",
+				signature:   "func WithCircuitBreaker(policy BreakerPolicy) Option",
+				example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithCircuitBreaker(%s.DefaultBreakerPolicy()),
+)`, pkgName, pkgName, pkgName),
+			},
+			{
+				name:        "WithMethodCircuitBreaker",
+				description: "Задаёт пороги автоматического выключателя отдельного метода (Contract.Method)",
+				signature:   "func WithMethodCircuitBreaker(method string, policy BreakerPolicy) Option",
+				example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithMethodCircuitBreaker("Contract.Method", %s.BreakerPolicy{FailureThreshold: 3, OpenTimeout: 5 * time.Second, HalfOpenRequests: 1}),
+)`, pkgName, pkgName, pkgName),
+			},
+			{
+				name:        "OnBreakerStateChange",
+				description: "Устанавливает функцию, вызываемую при смене состояния выключателя метода",
+				signature:   "func OnBreakerStateChange(listener func(method string, from, to BreakerState)) Option",
+				example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithCircuitBreaker(%s.DefaultBreakerPolicy()),
+    %s.OnBreakerStateChange(func(method string, from, to %s.BreakerState) {
+        slog.Warn("circuit breaker", "method", method, "from", from, "to", to)
+    }),
+)`, pkgName, pkgName, pkgName, pkgName, pkgName),
+			},
+			{
+				name:        "WithHedging",
+				description: "Включает дублирующие запросы для чтений: методов с аннотацией hedge и HTTP GET/HEAD. Если ответа нет за Delay, отправляется копия запроса (не более MaxHedged), используется первый успешный ответ. Методы с аннотацией idempotent и запросы с заголовком Idempotency-Key не дублируются, ответ с конфликтом ключа идемпотентности (409 или ошибка JSON-RPC -32009) не считается успешным",
+				signature:   "func WithHedging(policy HedgePolicy) Option",
+				example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithHedging(%s.HedgePolicy{Delay: 50 * time.Millisecond, MaxHedged: 1}),
+)`, pkgName, pkgName, pkgName),
+			},
+			{
+				name:        "WithInProcess",
+				description: "Направляет вызовы обработчику сервера в том же процессе без сетевых соединений (модульный монолит, тесты). Цепочка middleware, валидация и логирование сервера сохраняются, адрес клиента используется только для построения URL. Заменяет только транспорт HTTP клиента, поэтому сочетается с ClientHTTP, WithRecording и WithHedging в любом порядке. Вызов без сериализации не поддерживается: клиент использует собственные копии типов, не связанные с типами сервера, а валидация и логирование сервера работают с телом запроса. Поэтому запросы и ответы кодируются как при сетевом вызове; для снижения затрат на кодирование используйте WithCodec",
+				signature:   "func WithInProcess(handler http.Handler) Option",
+				example: fmt.Sprintf(`srv := transport.New(log, transport.Users(svc))
+client := %s.New("http://in-process",
+    %s.WithInProcess(srv.Handler()),
+)`, pkgName, pkgName),
+			},
+			{
+				name:        "WithRecording",
+				description: "Записывает пары запрос/ответ всех вызовов клиента в файл кассеты (JSON). Кассета накапливается в памяти и записывается в файл вызовом Close клиента. Заголовки авторизации, Cookie и Set-Cookie не сохраняются. Кассета воспроизводится опцией WithReplay",
+				signature:   "func WithRecording(path string) Option",
+				example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithRecording("testdata/users.cassette.json"),
+)
+defer client.Close()`, pkgName, pkgName),
+			},
+			{
+				name:        "WithReplay",
+				description: "Отвечает на вызовы ответами из кассеты, записанной WithRecording, без сетевых запросов: интеграционные тесты выполняются без зависимых сервисов и детерминированно. Запрос сопоставляется по методу, пути и телу (идентификаторы JSON-RPC не учитываются); вызов без записи завершается ошибкой",
+				signature:   "func WithReplay(path string) Option",
+				example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithReplay("testdata/users.cassette.json"),
+)`, pkgName, pkgName),
+			},
+			{
+				name:        "WithLimits",
+				description: "Задаёт общие для всех методов ограничения на стороне клиента: частоту запросов (token bucket, Rate запросов в секунду с запасом Burst) и число одновременных запросов MaxInFlight. При превышении вызов ждёт, пока не истечёт контекст",
+				signature:   "func WithLimits(policy LimitPolicy) Option",
+				example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithLimits(%s.LimitPolicy{Rate: 100, Burst: 20, MaxInFlight: 10}),
+)`, pkgName, pkgName, pkgName),
+			},
+			{
+				name:        "WithMethodLimits",
+				description: "Задаёт ограничения отдельного метода (Contract.Method), действующие вместе с общими. Для пакетов Batch используется имя метода batch",
+				signature:   "func WithMethodLimits(method string, policy LimitPolicy) Option",
+				example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithMethodLimits("Contract.Method", %s.LimitPolicy{Rate: 2, MaxInFlight: 1}),
+)`, pkgName, pkgName, pkgName),
+			},
+		}...)
+	}
+
+	if r.HasJsonRPC() {
+		options = append(options, struct {
+			name        string
+			description string
+			signature   string
+			example     string
+		}{
+			name:        "WithMaxBatchSize",
+			description: "Задаёт максимальный размер пакета: Batch делит запросы на пакеты не больше size (по умолчанию DefaultMaxBatchSize, как MaxBatchSize сервера) и вызывает обработчики ответов в порядке запросов. Значение size <= 0 отправляет все запросы одним пакетом",
+			signature:   "func WithMaxBatchSize(size int) Option",
+			example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithMaxBatchSize(50),
+)`, pkgName, pkgName),
+		})
+	}
+
+	if r.HasIdempotent() {
+		options = append(options, struct {
+			name        string
+			description string
+			signature   string
+			example     string
+		}{
+			name:        "WithRetry",
+			description: "Включает повторы методов, помеченных аннотацией idempotent: экспоненциальная задержка с разбросом, ограничение числа попыток и повторяемые коды (HTTP-статусы и коды ошибок JSON-RPC). По умолчанию повторяются статусы 429/502/503/504 и ошибка превышения лимита JSON-RPC -32029. Сетевые ошибки повторяются всегда, заголовок Retry-After и data.retryAfter ошибки JSON-RPC учитываются. Batch повторяется, только если все его запросы идемпотентны. Число повторов доступно в метрике client_requests_retry_count.",
+			signature:   "func WithRetry(policy RetryPolicy) Option",
+			example: fmt.Sprintf(`policy := %s.DefaultRetryPolicy()
+policy.MaxAttempts = 5
+client := %s.New("http://localhost:9000",
+    %s.WithRetry(policy),
+)`, pkgName, pkgName, pkgName),
+		})
+	}
+
 	if r.HasMetrics() {
 		options = append(options, struct {
 			name        string
@@ -688,6 +850,33 @@ client := %s.New("http://localhost:9000",
 		})
 	}
 
+	if r.HasTrace() {
+		options = append(options, []struct {
+			name        string
+			description string
+			signature   string
+			example     string
+		}{
+			{
+				name:        "WithTracing",
+				description: "Включает трассировку OpenTelemetry для контрактов с аннотацией trace: client span на каждый вызов и на batch, заголовки traceparent/tracestate и baggage в запросах. Если provider равен nil, используется глобальный провайдер otel",
+				signature:   "func WithTracing(provider trace.TracerProvider) Option",
+				example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithTracing(otel.GetTracerProvider()),
+)`, pkgName, pkgName),
+			},
+			{
+				name:        "WithTracePropagator",
+				description: "Задаёт формат заголовков трассировки (по умолчанию W3C TraceContext и Baggage)",
+				signature:   "func WithTracePropagator(propagator propagation.TextMapPropagator) Option",
+				example: fmt.Sprintf(`client := %s.New("http://localhost:9000",
+    %s.WithTracing(nil),
+    %s.WithTracePropagator(propagation.TraceContext{}),
+)`, pkgName, pkgName, pkgName),
+			},
+		}...)
+	}
+
 	for _, opt := range options {
 		md.PlainText(fmt.Sprintf("- **%s** - %s", markdown.Code(opt.name), opt.description))
 		md.LF()
@@ -759,6 +948,21 @@ func main() {
 			description: "Задержка выполнения запросов в микросекундах",
 			labels:      "service, method, success, errCode",
 		},
+		{
+			name:        "client_requests_retry_count",
+			description: "Количество повторов идемпотентных запросов (WithRetry)",
+			labels:      "service, method",
+		},
+		{
+			name:        "client_breaker_state",
+			description: "Состояние автоматического выключателя метода: 0 - closed, 1 - open, 2 - half-open",
+			labels:      "method",
+		},
+		{
+			name:        "client_breaker_transitions_count",
+			description: "Количество смен состояния автоматического выключателя метода",
+			labels:      "method, state",
+		},
 	}
 
 	for _, metric := range metrics {
@@ -937,3 +1141,46 @@ func (r *ClientRenderer) renderLoggingSection(md *markdown.Markdown, outDir stri
 	md.LF()
 	md.HorizontalRule()
 }
+
+// renderTracingSection генерирует секцию описания трассировки
+func (r *ClientRenderer) renderTracingSection(md *markdown.Markdown, outDir string) {
+	tracingAnchor := generateAnchor("Трассировка")
+	md.PlainText(fmt.Sprintf("<a id=\"%s\"></a>", tracingAnchor))
+	md.LF()
+	md.H2("Трассировка")
+	md.PlainText("Трассировка включается опцией " + markdown.Code("WithTracing") + " для контрактов с аннотацией " + markdown.Code("trace") + ". " +
+		"Каждый вызов создаёт client span с именем метода JSON-RPC, batch - один span с событиями " + markdown.Code("request") + " и " + markdown.Code("response") + " для каждого запроса. " +
+		"Контекст трассировки и baggage передаются серверу в заголовках " + markdown.Code("traceparent") + ", " + markdown.Code("tracestate") + " и " + markdown.Code("baggage") + ".")
+	md.LF()
+
+	pkgPath := r.pkgPath(outDir)
+	pkgName := filepath.Base(outDir)
+	if pkgName == "" || pkgName == "." {
+		pkgName = "client"
+	}
+
+	md.CodeBlocks(markdown.SyntaxHighlightGo, fmt.Sprintf(`package main
+
+import (
+    "go.opentelemetry.io/otel"
+    "%s"
+)
+
+func main() {
+    client := %s.New("http://localhost:9000",
+        %s.WithTracing(otel.GetTracerProvider()),
+    )
+    _ = client
+}`, pkgPath, pkgName, pkgName))
+	md.LF()
+
+	md.PlainText(markdown.Bold("Атрибуты span:"))
+	md.LF()
+	md.BulletList(
+		markdown.Code("rpc.system")+" - "+markdown.Code("jsonrpc")+" или "+markdown.Code("http"),
+		markdown.Code("rpc.service")+", "+markdown.Code("rpc.method")+" - сервис и метод",
+		markdown.Code("rpc.jsonrpc.version")+", "+markdown.Code("rpc.jsonrpc.batch_size")+" - версия протокола и размер batch",
+		markdown.Code("rpc.jsonrpc.error_code")+", "+markdown.Code("rpc.jsonrpc.error_message")+" - код и текст ошибки JSON-RPC",
+		markdown.Code("http.request.method")+", "+markdown.Code("http.response.status_code")+" - метод и статус HTTP запроса",
+	)
+}
@@ -69,12 +69,15 @@ func (r *ClientRenderer) RenderServiceClient(contract *core.Contract) error {
 	for _, method := range contract.Methods {
 		if r.methodIsJsonRPC(contract, method) {
 			// JSON-RPC методы
-			srcFile.Line().Add(r.jsonrpcClientMethodFunc(ctx, contract, method, outDir))
+			srcFile.Line().Add(r.deprecatedDoc(contract, method)).Add(r.jsonrpcClientMethodFunc(ctx, contract, method, outDir))
 			srcFile.Line().Add(r.jsonrpcClientRequestFunc(ctx, contract, method, outDir))
 		} else if r.methodIsHTTP(method) {
 			// HTTP методы
 			srcFile.Line().Add(r.httpClientMethodFunc(ctx, contract, method, outDir))
 		}
+		if p := r.methodPagination(method); p != nil && (r.methodIsJsonRPC(contract, method) || r.methodIsHTTP(method)) {
+			srcFile.Line().Add(r.paginateClientMethodFunc(ctx, contract, method, p))
+		}
 	}
 
 	return srcFile.Save(path.Join(outDir, strings.ToLower(contract.Name)+"-client.go"))
@@ -0,0 +1,161 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/core"
+)
+
+// RenderClientTracing генерирует файл tracing.go.
+func (r *ClientRenderer) RenderClientTracing() error {
+
+	outDir := r.outDir
+	srcFile := NewSrcFile(filepath.Base(outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageHttp, "http")
+	srcFile.ImportName(PackageOTEL, "otel")
+	srcFile.ImportName(PackageTrace, "trace")
+	srcFile.ImportName(PackageCodesOTEL, "codes")
+	srcFile.ImportName(PackageAttributeOTEL, "attribute")
+	srcFile.ImportName(PackagePropagationOTEL, "propagation")
+
+	srcFile.Line().Comment("WithTracing включает трассировку OpenTelemetry вызовов контрактов с аннотацией trace: client span на каждый вызов и на batch,")
+	srcFile.Comment("заголовки W3C traceparent/tracestate и baggage в запросах. Если provider == nil, используется глобальный провайдер otel.")
+	srcFile.Func().Id("WithTracing").Params(Id("provider").Qual(PackageTrace, "TracerProvider")).Params(Id("Option")).Block(
+		Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+			If(Id("provider").Op("==").Nil()).Block(
+				Id("provider").Op("=").Qual(PackageOTEL, "GetTracerProvider").Call(),
+			),
+			Id("cli").Dot("tracer").Op("=").Id("provider").Dot("Tracer").Call(Qual(PackageFmt, "Sprintf").Call(Lit("tg:%s"), Id("VersionTg"))),
+		),
+	)
+
+	srcFile.Line().Comment("WithTracePropagator задаёт формат заголовков трассировки (по умолчанию W3C TraceContext и Baggage).")
+	srcFile.Func().Id("WithTracePropagator").Params(Id("propagator").Qual(PackagePropagationOTEL, "TextMapPropagator")).Params(Id("Option")).Block(
+		Return(Func().Params(Id("cli").Op("*").Id("Client"))).Block(
+			Id("cli").Dot("propagator").Op("=").Id("propagator"),
+		),
+	)
+
+	srcFile.Line().Comment("startSpan начинает client span вызова; без WithTracing возвращает пустой span.")
+	srcFile.Func().Params(Id("cli").Op("*").Id("Client")).Id("startSpan").
+		Params(Id(_ctx_).Qual(PackageContext, "Context"), Id("name").String(), Id("attributes").Op("...").Qual(PackageAttributeOTEL, "KeyValue")).
+		Params(Qual(PackageContext, "Context"), Qual(PackageTrace, "Span")).Block(
+		If(Id("cli").Dot("tracer").Op("==").Nil()).Block(
+			Return(Id(_ctx_), Qual(PackageTrace, "SpanFromContext").Call(Qual(PackageContext, "Background").Call())),
+		),
+		Return(Id("cli").Dot("tracer").Dot("Start").Call(
+			Id(_ctx_),
+			Id("name"),
+			Qual(PackageTrace, "WithSpanKind").Call(Qual(PackageTrace, "SpanKindClient")),
+			Qual(PackageTrace, "WithAttributes").Call(Id("attributes").Op("...")),
+		)),
+	)
+
+	srcFile.Line().Comment("endSpan завершает span вызова, записывая ошибку.")
+	srcFile.Func().Id("endSpan").Params(Id("span").Qual(PackageTrace, "Span"), Err().Error()).Block(
+		If(Err().Op("!=").Nil()).Block(
+			Id("span").Dot("RecordError").Call(Err()),
+			Id("span").Dot("SetStatus").Call(Qual(PackageCodesOTEL, "Error"), Err().Dot("Error").Call()),
+		),
+		Id("span").Dot("End").Call(),
+	)
+
+	if r.HasJsonRPC() {
+		srcFile.Line().Comment("spanRPCError записывает в span код и текст ошибки JSON-RPC.")
+		srcFile.Func().Id("spanRPCError").Params(Id("span").Qual(PackageTrace, "Span"), Id("rpcError").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "RPCError")).Block(
+			Id("span").Dot("SetAttributes").Call(
+				Qual(PackageAttributeOTEL, "Int").Call(Lit("rpc.jsonrpc.error_code"), Id("rpcError").Dot("Code")),
+				Qual(PackageAttributeOTEL, "String").Call(Lit("rpc.jsonrpc.error_message"), Id("rpcError").Dot("Message")),
+			),
+		)
+
+		srcFile.Line().Comment("batchEvent добавляет в span batch событие запроса или ответа с идентификатором запроса и кодом ошибки.")
+		srcFile.Func().Id("batchEvent").Params(
+			Id("span").Qual(PackageTrace, "Span"),
+			Id("name").String(),
+			Id("id").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "ID"),
+			Id("method").String(),
+			Id("rpcError").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", r.pkgPath(outDir)), "RPCError"),
+		).Block(
+			Id("attributes").Op(":=").Index().Qual(PackageAttributeOTEL, "KeyValue").Values(
+				Qual(PackageAttributeOTEL, "Int64").Call(Lit("rpc.jsonrpc.request_id"), Int64().Call(Id("id"))),
+			),
+			If(Id("method").Op("!=").Lit("")).Block(
+				Id("attributes").Op("=").Append(Id("attributes"), Qual(PackageAttributeOTEL, "String").Call(Lit("rpc.method"), Id("method"))),
+			),
+			If(Id("rpcError").Op("!=").Nil()).Block(
+				Id("attributes").Op("=").Append(Id("attributes"), Qual(PackageAttributeOTEL, "Int").Call(Lit("rpc.jsonrpc.error_code"), Id("rpcError").Dot("Code"))),
+			),
+			Id("span").Dot("AddEvent").Call(Id("name"), Qual(PackageTrace, "WithAttributes").Call(Id("attributes").Op("..."))),
+		)
+	}
+
+	srcFile.Line().Comment("tracingTransport добавляет в запросы заголовки контекста трассировки и записывает в span статус ответа.")
+	srcFile.Type().Id("tracingTransport").Struct(
+		Id("base").Qual(PackageHttp, "RoundTripper"),
+		Id("propagator").Qual(PackagePropagationOTEL, "TextMapPropagator"),
+	)
+
+	srcFile.Line().Func().Params(Id("t").Op("*").Id("tracingTransport")).Id("RoundTrip").
+		Params(Id("request").Op("*").Qual(PackageHttp, "Request")).
+		Params(Op("*").Qual(PackageHttp, "Response"), Error()).Block(
+		Line().Id("base").Op(":=").Id("t").Dot("base"),
+		If(Id("base").Op("==").Nil()).Block(
+			Id("base").Op("=").Qual(PackageHttp, "DefaultTransport"),
+		),
+		Id("request").Op("=").Id("request").Dot("Clone").Call(Id("request").Dot("Context").Call()),
+		Id("t").Dot("propagator").Dot("Inject").Call(Id("request").Dot("Context").Call(), Qual(PackagePropagationOTEL, "HeaderCarrier").Call(Id("request").Dot("Header"))),
+		List(Id("response"), Err()).Op(":=").Id("base").Dot("RoundTrip").Call(Id("request")),
+		If(Err().Op("==").Nil()).Block(
+			Qual(PackageTrace, "SpanFromContext").Call(Id("request").Dot("Context").Call()).Dot("SetAttributes").Call(
+				Qual(PackageAttributeOTEL, "String").Call(Lit("http.request.method"), Id("request").Dot("Method")),
+				Qual(PackageAttributeOTEL, "Int").Call(Lit("http.response.status_code"), Id("response").Dot("StatusCode")),
+			),
+		),
+		Return(Id("response"), Err()),
+	)
+
+	return srcFile.Save(path.Join(outDir, "tracing.go"))
+}
+
+// traceSpan генерирует client span вызова метода контракта с аннотацией trace.
+func (r *ClientRenderer) traceSpan(cli Code, contract *core.Contract, method *core.Method, system string) Code {
+
+	if !r.contains(contract.Annotations, TagTrace) {
+		return Null()
+	}
+	return CustomFunc(Options{Multi: true}, func(g *Group) {
+		g.Var().Id("_span").Qual(PackageTrace, "Span")
+		g.List(Id(_ctx_), Id("_span")).Op("=").Add(cli).Dot("startSpan").CallFunc(func(cg *Group) {
+			cg.Id(_ctx_)
+			cg.Lit(r.jsonRPCMethodName(contract, method))
+			cg.Qual(PackageAttributeOTEL, "String").Call(Lit("rpc.system"), Lit(system))
+			cg.Qual(PackageAttributeOTEL, "String").Call(Lit("rpc.service"), Lit(r.contractNameToLowerCamel(contract)))
+			cg.Qual(PackageAttributeOTEL, "String").Call(Lit("rpc.method"), Lit(r.jsonRPCMethodName(contract, method)))
+			if system == "jsonrpc" {
+				cg.Qual(PackageAttributeOTEL, "String").Call(Lit("rpc.jsonrpc.version"), Lit("2.0"))
+			}
+		})
+		g.Defer().Func().Params().Block(
+			Id("endSpan").Call(Id("_span"), Err()),
+		).Call()
+	})
+}
+
+// traceRPCError генерирует запись ошибки JSON-RPC в span вызова.
+func (r *ClientRenderer) traceRPCError(contract *core.Contract) Code {
+
+	if !r.contains(contract.Annotations, TagTrace) {
+		return Null()
+	}
+	return Id("spanRPCError").Call(Id("_span"), Id("rpcResponse").Dot("Error"))
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/core"
+)
+
+// typedErrors возвращает объявленные ошибки метода с HTTP кодом, отсортированные по коду.
+// Ошибки без кода и ошибки с кодом, общим для нескольких типов, не декодируются: по ответу сервера их нельзя различить.
+func (r *ClientRenderer) typedErrors(method *core.Method) (errs []*core.ErrorInfo) {
+
+	byCode := r.errorsByCode(method)
+	for _, code := range slices.Sorted(maps.Keys(byCode)) {
+		if len(byCode[code]) == 1 {
+			errs = append(errs, byCode[code][0])
+		}
+	}
+	return
+}
+
+// ambiguousErrors возвращает ошибки метода, код которых объявлен у нескольких типов, сгруппированные по коду.
+func (r *ClientRenderer) ambiguousErrors(method *core.Method) (ambiguous map[int][]*core.ErrorInfo) {
+
+	ambiguous = make(map[int][]*core.ErrorInfo)
+	for code, errs := range r.errorsByCode(method) {
+		if len(errs) > 1 {
+			ambiguous[code] = errs
+		}
+	}
+	return
+}
+
+// errorsByCode группирует объявленные ошибки метода с HTTP кодом по коду, повторные объявления одного типа не учитываются.
+func (r *ClientRenderer) errorsByCode(method *core.Method) (byCode map[int][]*core.ErrorInfo) {
+
+	byCode = make(map[int][]*core.ErrorInfo)
+	for _, errInfo := range method.Errors {
+		if errInfo.HTTPCode == 0 {
+			continue
+		}
+		if slices.ContainsFunc(byCode[errInfo.HTTPCode], func(other *core.ErrorInfo) bool {
+			return other.PkgPath == errInfo.PkgPath && other.TypeName == errInfo.TypeName
+		}) {
+			continue
+		}
+		byCode[errInfo.HTTPCode] = append(byCode[errInfo.HTTPCode], errInfo)
+	}
+	for _, errs := range byCode {
+		sort.Slice(errs, func(i, j int) bool {
+			return errs[i].PkgPath+"."+errs[i].TypeName < errs[j].PkgPath+"."+errs[j].TypeName
+		})
+	}
+	return
+}
+
+// typedErrorName возвращает имя типа ошибки в клиенте; при совпадении имён из разных пакетов добавляется имя пакета.
+func (r *ClientRenderer) typedErrorName(errInfo *core.ErrorInfo) string {
+
+	for _, contract := range r.project.Contracts {
+		for _, method := range contract.Methods {
+			for _, other := range method.Errors {
+				if other.TypeName == errInfo.TypeName && other.PkgPath != errInfo.PkgPath {
+					return ToCamel(path.Base(errInfo.PkgPath)) + errInfo.TypeName
+				}
+			}
+		}
+	}
+	return errInfo.TypeName
+}
+
+// typedErrorsVar возвращает имя таблицы типизированных ошибок метода.
+func (r *ClientRenderer) typedErrorsVar(contract *core.Contract, method *core.Method) string {
+	return "errors" + contract.Name + method.Name
+}
+
+// hasTypedErrors проверяет, есть ли у метода ошибки для декодирования в типы.
+func (r *ClientRenderer) hasTypedErrors(method *core.Method) bool {
+	return len(r.typedErrors(method)) != 0
+}
+
+// renderTypedErrors генерирует типы объявленных ошибок и таблицы их декодирования по коду ответа.
+func (r *ClientRenderer) renderTypedErrors(srcFile *GoFile) {
+
+	type typedError struct {
+		name    string
+		errInfo *core.ErrorInfo
+	}
+	var declared []typedError
+	seen := make(map[string]bool)
+	for _, contractName := range r.contractKeys() {
+		contract := r.findContract(contractName)
+		if contract == nil {
+			continue
+		}
+		for _, method := range contract.Methods {
+			ambiguous := r.ambiguousErrors(method)
+			for _, code := range slices.Sorted(maps.Keys(ambiguous)) {
+				names := make([]string, 0, len(ambiguous[code]))
+				for _, errInfo := range ambiguous[code] {
+					names = append(names, errInfo.PkgPath+":"+errInfo.TypeName)
+				}
+				core.GetLogger().Warn(fmt.Sprintf("errors share response code and are not decoded into typed client errors: method=%s.%s code=%d errors=%s", contract.Name, method.Name, code, strings.Join(names, ",")))
+			}
+			for _, errInfo := range r.typedErrors(method) {
+				if name := r.typedErrorName(errInfo); !seen[name] {
+					seen[name] = true
+					declared = append(declared, typedError{name: name, errInfo: errInfo})
+				}
+			}
+		}
+	}
+	if len(declared) == 0 {
+		return
+	}
+	sort.Slice(declared, func(i, j int) bool { return declared[i].name < declared[j].name })
+
+	srcFile.Line().Comment("errorFactory создаёт типизированную ошибку по коду ответа, сообщению, данным ошибки и исходной ошибке транспорта.")
+	srcFile.Type().Id("errorFactory").Func().Params(Id("code").Int(), Id("message").String(), Id("data").Qual(PackageStdJSON, "RawMessage"), Id("cause").Error()).Error()
+
+	for _, typed := range declared {
+		srcFile.Line().Commentf("%s - ошибка %s.%s, объявленная в контракте. Используйте errors.As(err, &%s{}).", typed.name, typed.errInfo.PkgPath, typed.errInfo.TypeName, typed.name)
+		srcFile.Type().Id(typed.name).Struct(
+			Comment("Message - текст ошибки."),
+			Id("Message").String(),
+			Comment("Data - данные ошибки: data ответа JSON-RPC или тело ответа HTTP."),
+			Id("Data").Qual(PackageStdJSON, "RawMessage"),
+			Line().Id("code").Int(),
+			Id("cause").Error(),
+		)
+		srcFile.Line().Func().Params(Id("e").Id(typed.name)).Id("Error").Params().String().Block(
+			Return(Id("e").Dot("Message")),
+		)
+		srcFile.Line().Comment("Code возвращает код ответа сервера.")
+		srcFile.Func().Params(Id("e").Id(typed.name)).Id("Code").Params().Int().Block(
+			Return(Id("e").Dot("code")),
+		)
+		srcFile.Line().Comment("Unwrap возвращает исходную ошибку транспорта.")
+		srcFile.Func().Params(Id("e").Id(typed.name)).Id("Unwrap").Params().Error().Block(
+			Return(Id("e").Dot("cause")),
+		)
+		srcFile.Line().Func().Id("new"+typed.name).Params(Id("code").Int(), Id("message").String(), Id("data").Qual(PackageStdJSON, "RawMessage"), Id("cause").Error()).Error().Block(
+			Return(Id(typed.name).Values(Dict{
+				Id("Message"): Id("message"),
+				Id("Data"):    Id("data"),
+				Id("code"):    Id("code"),
+				Id("cause"):   Id("cause"),
+			})),
+		)
+	}
+
+	for _, contractName := range r.contractKeys() {
+		contract := r.findContract(contractName)
+		if contract == nil {
+			continue
+		}
+		for _, method := range contract.Methods {
+			errs := r.typedErrors(method)
+			if len(errs) == 0 {
+				continue
+			}
+			srcFile.Line().Commentf("%s - ошибки %s.%s по коду ответа.", r.typedErrorsVar(contract, method), contract.Name, method.Name)
+			srcFile.Var().Id(r.typedErrorsVar(contract, method)).Op("=").Map(Int()).Id("errorFactory").Values(DictFunc(func(dict Dict) {
+				for _, errInfo := range errs {
+					dict[Lit(errInfo.HTTPCode)] = Id("new" + r.typedErrorName(errInfo))
+				}
+			}))
+		}
+	}
+
+	if r.HasHTTP() {
+		srcFile.Line().Comment("httpErrorMessage извлекает текст ошибки из тела HTTP ответа (problem+json, поле message или текст тела).")
+		srcFile.Func().Id("httpErrorMessage").Params(Id("body").Index().Byte()).String().Block(
+			Var().Id("fields").Struct(
+				Id("Detail").String().Tag(map[string]string{"json": "detail"}),
+				Id("Title").String().Tag(map[string]string{"json": "title"}),
+				Id("Message").String().Tag(map[string]string{"json": "message"}),
+			),
+			If(Qual(PackageStdJSON, "Unmarshal").Call(Id("body"), Op("&").Id("fields")).Op("==").Nil()).Block(
+				For(List(Id("_"), Id("message")).Op(":=").Range().Index().String().Values(Id("fields").Dot("Detail"), Id("fields").Dot("Message"), Id("fields").Dot("Title"))).Block(
+					If(Id("message").Op("!=").Lit("")).Block(
+						Return(Id("message")),
+					),
+				),
+			),
+			Return(Qual(PackageStrings, "TrimSpace").Call(String().Call(Id("body")))),
+		)
+	}
+}
+
+// typedErrorDecode генерирует замену ошибки на типизированную, если код ответа объявлен в контракте.
+func (r *ClientRenderer) typedErrorDecode(contract *core.Contract, method *core.Method, code, message, data Code) Code {
+
+	if !r.hasTypedErrors(method) {
+		return Null()
+	}
+	return If(List(Id("factory"), Id("ok")).Op(":=").Id(r.typedErrorsVar(contract, method)).Index(code), Id("ok")).Block(
+		Err().Op("=").Id("factory").Call(code, message, data, Err()),
+	)
+}
+
+// typedErrorsDoc возвращает описание типизированной ошибки для README.
+func (r *ClientRenderer) typedErrorsDoc(errInfo *core.ErrorInfo) string {
+	return fmt.Sprintf("errors.As(err, &%s{})", r.typedErrorName(errInfo))
+}
@@ -24,17 +24,17 @@ func (r *ClientRenderer) RenderClientTypes(collectedTypeIDs map[string]bool) err
 		return nil
 	}
 
-	// Создаем директорию dto, если её нет
-	dtoDir := path.Join(r.outDir, "dto")
+	// Создаем директорию пакета типов, если её нет
+	dtoDir := path.Join(r.outDir, r.typesPackage())
 	if err := os.MkdirAll(dtoDir, 0755); err != nil {
-		return fmt.Errorf("не удалось создать директорию dto: %w", err)
+		return fmt.Errorf("не удалось создать директорию %s: %w", r.typesPackage(), err)
 	}
 
-	srcFile := NewSrcFile("dto")
+	srcFile := NewSrcFile(r.typesPackage())
 	srcFile.PackageComment(DoNotEdit)
 
 	ctx := context.WithValue(context.Background(), keyCode, srcFile) // nolint
-	ctx = context.WithValue(ctx, keyPackage, "dto")                  // nolint
+	ctx = context.WithValue(ctx, keyPackage, r.typesPackage())       // nolint
 
 	// Генерируем типы в детерминированном порядке (сортируем по typeID)
 	typeIDs := make([]string, 0, len(collectedTypeIDs))
@@ -133,10 +133,10 @@ func (r *ClientRenderer) RenderClientTypes(collectedTypeIDs map[string]bool) err
 
 		if typeCode != nil {
 			// Создаем отдельный файл для каждого типа
-			typeFile := NewSrcFile("dto")
+			typeFile := NewSrcFile(r.typesPackage())
 			typeFile.PackageComment(DoNotEdit)
 			typeCtx := context.WithValue(context.Background(), keyCode, typeFile) // nolint
-			typeCtx = context.WithValue(typeCtx, keyPackage, "dto")               // nolint
+			typeCtx = context.WithValue(typeCtx, keyPackage, r.typesPackage())    // nolint
 
 			// ВАЖНО: перегенерируем typeCode с правильным контекстом для алиасов
 			// Это нужно для правильной установки ImportName в правильном файле
@@ -427,12 +427,12 @@ func (r *ClientRenderer) fieldTypeForClient(ctx context.Context, typeID string,
 		if typ.TypeName != "" && typ.ImportPkgPath != "" {
 			if r.isTypeFromCurrentProject(typ.ImportPkgPath) {
 				// Тип из текущего проекта - используем имя типа
-				if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == "dto" {
+				if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == r.typesPackage() {
 					return c.Id(typ.TypeName)
 				}
 				if srcFile, ok := ctx.Value(keyCode).(GoFile); ok {
-					dtoPkgPath := fmt.Sprintf("%s/dto", r.pkgPath(r.outDir))
-					srcFile.ImportName(dtoPkgPath, "dto")
+					dtoPkgPath := fmt.Sprintf("%s/%s", r.pkgPath(r.outDir), r.typesPackage())
+					srcFile.ImportName(dtoPkgPath, r.typesPackage())
 					return c.Qual(dtoPkgPath, typ.TypeName)
 				}
 				return c.Id(typ.TypeName)
@@ -470,14 +470,14 @@ func (r *ClientRenderer) fieldTypeForClient(ctx context.Context, typeID string,
 				// Тип из текущего проекта
 				// ВАЖНО: если мы генерируем код в пакете dto, то типы из того же пакета
 				// используем без импорта (просто по имени), чтобы избежать циклических импортов
-				if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == "dto" {
+				if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == r.typesPackage() {
 					// Генерируем код в пакете dto - используем просто имя типа без импорта
 					return c.Id(typ.TypeName)
 				}
 				// Тип из текущего проекта, но не генерируется в пакете dto - используем dto пакет
 				if srcFile, ok := ctx.Value(keyCode).(GoFile); ok {
-					dtoPkgPath := fmt.Sprintf("%s/dto", r.pkgPath(r.outDir))
-					srcFile.ImportName(dtoPkgPath, "dto")
+					dtoPkgPath := fmt.Sprintf("%s/%s", r.pkgPath(r.outDir), r.typesPackage())
+					srcFile.ImportName(dtoPkgPath, r.typesPackage())
 					return c.Qual(dtoPkgPath, typ.TypeName)
 				}
 				return c.Id(typ.TypeName)
@@ -529,14 +529,14 @@ func (r *ClientRenderer) fieldTypeForClient(ctx context.Context, typeID string,
 			// Проверяем, является ли тип из текущего проекта
 			if r.isTypeFromCurrentProject(typ.ImportPkgPath) {
 				// Тип из текущего проекта - используем локальный тип из dto пакета
-				if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == "dto" {
+				if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == r.typesPackage() {
 					// Генерируем код в пакете dto - используем просто имя типа без импорта
 					return c.Id(typ.TypeName)
 				}
 				// Тип из текущего проекта, но не генерируется в пакете dto - используем dto пакет
 				if srcFile, ok := ctx.Value(keyCode).(GoFile); ok {
-					dtoPkgPath := fmt.Sprintf("%s/dto", r.pkgPath(r.outDir))
-					srcFile.ImportName(dtoPkgPath, "dto")
+					dtoPkgPath := fmt.Sprintf("%s/%s", r.pkgPath(r.outDir), r.typesPackage())
+					srcFile.ImportName(dtoPkgPath, r.typesPackage())
 					return c.Qual(dtoPkgPath, typ.TypeName)
 				}
 				return c.Id(typ.TypeName)
@@ -562,12 +562,12 @@ func (r *ClientRenderer) fieldTypeForClient(ctx context.Context, typeID string,
 
 				if isAliasFromCurrentProject {
 					// Алиас из текущего проекта - используем имя алиаса
-					if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == "dto" {
+					if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == r.typesPackage() {
 						return c.Id(typ.TypeName)
 					}
 					if srcFile, ok := ctx.Value(keyCode).(GoFile); ok {
-						dtoPkgPath := fmt.Sprintf("%s/dto", r.pkgPath(r.outDir))
-						srcFile.ImportName(dtoPkgPath, "dto")
+						dtoPkgPath := fmt.Sprintf("%s/%s", r.pkgPath(r.outDir), r.typesPackage())
+						srcFile.ImportName(dtoPkgPath, r.typesPackage())
 						return c.Qual(dtoPkgPath, typ.TypeName)
 					}
 					return c.Id(typ.TypeName)
@@ -591,7 +591,7 @@ func (r *ClientRenderer) fieldTypeForClient(ctx context.Context, typeID string,
 		}
 		// Если TypeName есть, но нет AliasOf, используем TypeName напрямую
 		if typ.TypeName != "" {
-			if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == "dto" {
+			if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == r.typesPackage() {
 				return c.Id(typ.TypeName)
 			}
 		}
@@ -618,14 +618,14 @@ func (r *ClientRenderer) fieldTypeForClient(ctx context.Context, typeID string,
 				// Тип из текущего проекта
 				// ВАЖНО: если мы генерируем код в пакете dto, то типы из того же пакета
 				// используем без импорта (просто по имени), чтобы избежать циклических импортов
-				if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == "dto" {
+				if currentPkg, ok := ctx.Value(keyPackage).(string); ok && currentPkg == r.typesPackage() {
 					// Генерируем код в пакете dto - используем просто имя типа без импорта
 					return c.Id(typ.TypeName)
 				}
 				// Тип из текущего проекта, но не генерируется в пакете dto - используем dto пакет
 				if srcFile, ok := ctx.Value(keyCode).(GoFile); ok {
-					dtoPkgPath := fmt.Sprintf("%s/dto", r.pkgPath(r.outDir))
-					srcFile.ImportName(dtoPkgPath, "dto")
+					dtoPkgPath := fmt.Sprintf("%s/%s", r.pkgPath(r.outDir), r.typesPackage())
+					srcFile.ImportName(dtoPkgPath, r.typesPackage())
 					return c.Qual(dtoPkgPath, typ.TypeName)
 				}
 				return c.Id(typ.TypeName)
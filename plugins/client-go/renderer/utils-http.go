@@ -126,3 +126,58 @@ func (r *ClientRenderer) methodNameToLower(method *core.Method) string {
 	}
 	return strings.ToLower(method.Name)
 }
+
+// Режимы тела HTTP запроса
+const (
+	bodyModeJSON      = ""
+	bodyModeRaw       = "raw"
+	bodyModeMultipart = "multipart"
+)
+
+// methodBodyMode возвращает режим тела HTTP запроса метода.
+func (r *ClientRenderer) methodBodyMode(method *core.Method) string {
+
+	if r.contains(method.Annotations, TagHttpMultipart) {
+		return bodyModeMultipart
+	}
+	if method.Annotations[TagHttpBody] == bodyModeRaw {
+		return bodyModeRaw
+	}
+	return bodyModeJSON
+}
+
+// isStreamVar проверяет, является ли переменная потоком (io.Reader, io.ReadCloser).
+func isStreamVar(v *core.Variable) bool {
+	return !v.IsSlice && v.NumberOfPointers == 0 && (v.TypeID == "io:Reader" || v.TypeID == "io:ReadCloser")
+}
+
+// isBinaryVar проверяет, является ли переменная бинарными данными (поток или []byte).
+func isBinaryVar(v *core.Variable) bool {
+
+	if isStreamVar(v) {
+		return true
+	}
+	return v.IsSlice && v.ArrayLen == 0 && !v.IsEllipsis && (v.TypeID == "byte" || v.TypeID == "uint8")
+}
+
+// streamResult возвращает результат метода, получаемый как файл, или nil.
+func (r *ClientRenderer) streamResult(method *core.Method) *core.Variable {
+
+	for _, ret := range r.resultsWithoutError(method) {
+		if isStreamVar(ret) {
+			return ret
+		}
+	}
+	return nil
+}
+
+// rawContentType возвращает Content-Type для тела запроса в режиме raw.
+func (r *ClientRenderer) rawContentType(method *core.Method) string {
+
+	for _, contentType := range strings.FieldsFunc(method.Annotations[TagHttpContentType], func(c rune) bool { return c == ',' || c == '|' }) {
+		if contentType = strings.TrimSpace(contentType); contentType != "" {
+			return contentType
+		}
+	}
+	return "application/octet-stream"
+}
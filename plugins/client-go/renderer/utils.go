@@ -289,8 +289,8 @@ func (r *ClientRenderer) fieldType(ctx context.Context, typeID string, numberOfP
 			if r.isTypeFromCurrentProject(typ.ImportPkgPath) {
 				// Тип из текущего проекта - используем dto пакет
 				if srcFile, ok := ctx.Value(keyCode).(GoFile); ok {
-					dtoPkgPath := fmt.Sprintf("%s/dto", r.pkgPath(r.outDir))
-					srcFile.ImportName(dtoPkgPath, "dto")
+					dtoPkgPath := fmt.Sprintf("%s/%s", r.pkgPath(r.outDir), r.typesPackage())
+					srcFile.ImportName(dtoPkgPath, r.typesPackage())
 					return c.Qual(dtoPkgPath, typ.TypeName)
 				}
 				return c.Id(typ.TypeName)
@@ -322,8 +322,8 @@ func (r *ClientRenderer) fieldType(ctx context.Context, typeID string, numberOfP
 			if r.isTypeFromCurrentProject(typ.ImportPkgPath) {
 				// Тип из текущего проекта - используем dto пакет
 				if srcFile, ok := ctx.Value(keyCode).(GoFile); ok {
-					dtoPkgPath := fmt.Sprintf("%s/dto", r.pkgPath(r.outDir))
-					srcFile.ImportName(dtoPkgPath, "dto")
+					dtoPkgPath := fmt.Sprintf("%s/%s", r.pkgPath(r.outDir), r.typesPackage())
+					srcFile.ImportName(dtoPkgPath, r.typesPackage())
 					return c.Qual(dtoPkgPath, typ.TypeName)
 				}
 				return c.Id(typ.TypeName)
@@ -382,8 +382,8 @@ func (r *ClientRenderer) fieldType(ctx context.Context, typeID string, numberOfP
 			if r.isTypeFromCurrentProject(typ.ImportPkgPath) {
 				// Тип из текущего проекта - используем dto пакет
 				if srcFile, ok := ctx.Value(keyCode).(GoFile); ok {
-					dtoPkgPath := fmt.Sprintf("%s/dto", r.pkgPath(r.outDir))
-					srcFile.ImportName(dtoPkgPath, "dto")
+					dtoPkgPath := fmt.Sprintf("%s/%s", r.pkgPath(r.outDir), r.typesPackage())
+					srcFile.ImportName(dtoPkgPath, r.typesPackage())
 					return c.Qual(dtoPkgPath, typ.TypeName)
 				}
 				return c.Id(typ.TypeName)
@@ -662,6 +662,10 @@ func (r *ClientRenderer) varsToFields(vars []*core.Variable, methodTags map[stri
 				}
 			}
 		}
+		// Потоки не сериализуются в JSON и передаются через тело HTTP запроса/ответа
+		if isStreamVar(v) {
+			field.tags["json"] = "-"
+		}
 		fields = append(fields, field)
 	}
 	return fields
@@ -15,6 +15,10 @@ func (r *ClientRenderer) RenderVersion() error {
 	srcFile.PackageComment(DoNotEdit)
 
 	srcFile.Const().Id("VersionTg").Op("=").Lit(r.project.Version)
+	if r.IsModule() {
+		srcFile.Line().Comment("Version - версия модуля клиента (git тег проекта при генерации).")
+		srcFile.Const().Id("Version").Op("=").Lit(r.moduleVersion())
+	}
 
 	return srcFile.Save(path.Join(outDir, "version.go"))
 }
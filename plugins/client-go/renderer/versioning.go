@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/markdown"
+
+	"tgp/core"
+)
+
+// methodVersion возвращает версию API метода (аннотация метода переопределяет аннотацию контракта).
+func (r *ClientRenderer) methodVersion(contract *core.Contract, method *core.Method) string {
+
+	if version := strings.TrimSpace(method.Annotations[TagVersion]); version != "" {
+		return version
+	}
+	return strings.TrimSpace(contract.Annotations[TagVersion])
+}
+
+// jsonRPCMethodName возвращает имя JSON-RPC метода с пространством имён версии.
+func (r *ClientRenderer) jsonRPCMethodName(contract *core.Contract, method *core.Method) string {
+
+	name := r.contractNameToLower(contract) + "." + r.methodNameToLower(method)
+	if version := r.methodVersion(contract, method); version != "" {
+		name = strings.ToLower(version) + "." + name
+	}
+	return name
+}
+
+// methodDeprecated проверяет, выводится ли метод из эксплуатации, и возвращает пояснение и дату отключения.
+func (r *ClientRenderer) methodDeprecated(contract *core.Contract, method *core.Method) (message, sunset string, deprecated bool) {
+
+	if r.contains(method.Annotations, TagDeprecated) {
+		message, deprecated = method.Annotations[TagDeprecated], true
+	} else if r.contains(contract.Annotations, TagDeprecated) {
+		message, deprecated = contract.Annotations[TagDeprecated], true
+	}
+	sunset = method.Annotations[TagSunset]
+	if sunset == "" {
+		sunset = contract.Annotations[TagSunset]
+	}
+	return strings.TrimSpace(message), strings.TrimSpace(sunset), deprecated
+}
+
+// deprecatedDoc генерирует doc-комментарий Deprecated для метода клиента.
+func (r *ClientRenderer) deprecatedDoc(contract *core.Contract, method *core.Method) Code {
+
+	message, sunset, deprecated := r.methodDeprecated(contract, method)
+	if !deprecated {
+		return Null()
+	}
+	text := "Deprecated: " + method.Name + " is deprecated."
+	if message != "" {
+		text = "Deprecated: " + message + "."
+	}
+	if sunset != "" {
+		text += " Sunset: " + sunset + "."
+	}
+	return Comment(text).Line()
+}
+
+// renderMethodDeprecation генерирует в документации пометку о выводе метода из эксплуатации.
+func (r *ClientRenderer) renderMethodDeprecation(md *markdown.Markdown, contract *core.Contract, method *core.Method) {
+
+	message, sunset, deprecated := r.methodDeprecated(contract, method)
+	if !deprecated {
+		return
+	}
+	text := markdown.Bold("Устарел:") + " метод выводится из эксплуатации."
+	if message != "" {
+		text = markdown.Bold("Устарел:") + " " + message + "."
+	}
+	if sunset != "" {
+		text += " Будет отключён " + sunset + "."
+	}
+	md.PlainText(text)
+	md.LF()
+}
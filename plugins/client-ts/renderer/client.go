@@ -140,6 +140,9 @@ func (r *ClientRenderer) renderBaseClientClass() *tsg.Statement {
 			}))
 		grp.Line()
 
+		r.renderCodecMethods(grp)
+		grp.Line()
+
 		// Метод Batch для выполнения batch запросов
 		if r.HasJsonRPC() {
 			grp.Add(r.renderBatchMethod())
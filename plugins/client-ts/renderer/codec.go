@@ -0,0 +1,67 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"tgp/plugins/client-ts/tsg"
+)
+
+// codecType генерирует тип Codec для options.ts.
+func (r *ClientRenderer) codecType() *tsg.Statement {
+
+	stmt := tsg.NewStatement()
+	stmt.Comment("Codec encodes request and response bodies in a non-JSON format (e.g. application/msgpack or application/cbor).")
+	stmt.Comment("Implementations must keep JSON field names.")
+	stmt.Export().Type("Codec")
+	stmt.Op("=")
+	stmt.BlockFunc(func(grp *tsg.Group) {
+		grp.Add(tsg.NewStatement().Id("contentType").Colon().Id("string").Semicolon())
+		encodeType := tsg.NewStatement()
+		encodeType.Params(func(fg *tsg.Group) {
+			fg.Add(tsg.NewStatement().Id("value").Colon().Id("unknown"))
+		}).Op("=>").Id("Uint8Array")
+		grp.Add(tsg.NewStatement().Id("encode").Colon().Add(encodeType).Semicolon())
+		decodeType := tsg.NewStatement()
+		decodeType.Params(func(fg *tsg.Group) {
+			fg.Add(tsg.NewStatement().Id("data").Colon().Id("Uint8Array"))
+		}).Op("=>").Id("unknown")
+		grp.Add(tsg.NewStatement().Id("decode").Colon().Add(decodeType).Semicolon())
+	})
+	return stmt
+}
+
+// renderCodecMethods генерирует методы кодирования тел запросов и ответов с учётом options.codec.
+func (r *ClientRenderer) renderCodecMethods(grp *tsg.Group) {
+
+	grp.Add(tsg.NewStatement().
+		Comment("Returns the content type of request and response bodies").
+		Id("getContentType").Call().Colon().Id("string").BlockFunc(func(mg *tsg.Group) {
+		mg.Return(tsg.NewStatement().Id("this.options.codec").Op("?").Id("this.options.codec.contentType").Op(":").Lit("application/json"))
+	}))
+	grp.Line()
+
+	grp.Add(tsg.NewStatement().
+		Comment("Encodes a request body with the configured codec or JSON").
+		Id("encodeBody").Params(func(pg *tsg.Group) {
+		pg.Add(tsg.NewStatement().Id("value").Colon().Id("unknown"))
+	}).Colon().Id("BodyInit").BlockFunc(func(mg *tsg.Group) {
+		mg.If(tsg.NewStatement().Id("this.options.codec"), func(ig *tsg.Group) {
+			ig.Return(tsg.NewStatement().Id("this.options.codec.encode").Call(tsg.NewStatement().Id("value")).Op("as").Id("BodyInit"))
+		})
+		mg.Return(tsg.NewStatement().Id("JSON.stringify").Call(tsg.NewStatement().Id("value")))
+	}))
+	grp.Line()
+
+	decodeParams := tsg.NewStatement().Params(func(pg *tsg.Group) {
+		pg.Add(tsg.NewStatement().Id("response").Colon().Id("Response"))
+	})
+	grp.Add(tsg.NewStatement().
+		Comment("Decodes a response body with the configured codec, falling back to JSON when the server answered in JSON").
+		AsyncMethodWithParams("decodeBody", decodeParams, tsg.NewStatement().Id("any"), func(mg *tsg.Group) {
+			mg.Add(tsg.NewStatement().Const("contentType").Op("=").Id("(response.headers.get(\"Content-Type\") || \"\")").Dot("split").Call(tsg.NewStatement().Lit(";")).Index(tsg.NewStatement().Lit(0)).Dot("trim").Call().Dot("toLowerCase").Call().Semicolon())
+			mg.If(tsg.NewStatement().Id("this.options.codec").Op("&&").Id("contentType").Op("===").Id("this.options.codec.contentType.toLowerCase").Call(), func(ig *tsg.Group) {
+				ig.Return(tsg.NewStatement().Id("this.options.codec.decode").Call(tsg.NewStatement().New("Uint8Array").Call(tsg.NewStatement().Await(tsg.NewStatement().Id("response").Dot("arrayBuffer").Call()))))
+			})
+			mg.Return(tsg.NewStatement().Id("response").Dot("json").Call())
+		}))
+}
@@ -20,4 +20,17 @@ const (
 	TagHttpPath               = "http-path"
 	TagHttpPrefix             = "http-prefix"
 	TagHttpSuccess            = "http-success"
+	TagHttpMultipart          = "http-multipart"
+	TagHttpBody               = "http-body"
+	TagHttpContentType        = "http-content-type"
+	TagHttpDownload           = "http-download"
+	TagIdempotent             = "idempotent"
+	TagVersion                = "version"
+	TagDeprecated             = "deprecated"
+	TagSunset                 = "sunset"
+	TagPaginate               = "paginate"
+	TagPaginateCursor         = "cursor"
+	TagPaginateNext           = "next"
+	TagPaginateItems          = "items"
+	TagPaginatePageSize       = "page-size"
 )
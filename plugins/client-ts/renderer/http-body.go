@@ -0,0 +1,164 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"strings"
+
+	"tgp/core"
+	"tgp/plugins/client-ts/tsg"
+)
+
+// Режимы тела HTTP запроса
+const (
+	bodyModeJSON      = ""
+	bodyModeRaw       = "raw"
+	bodyModeMultipart = "multipart"
+)
+
+// methodBodyMode возвращает режим тела HTTP запроса метода.
+func (r *ClientRenderer) methodBodyMode(method *core.Method) string {
+
+	if r.contains(method.Annotations, TagHttpMultipart) {
+		return bodyModeMultipart
+	}
+	if annotationValue(method.Annotations, TagHttpBody, "") == bodyModeRaw {
+		return bodyModeRaw
+	}
+	return bodyModeJSON
+}
+
+// isStreamVar проверяет, является ли переменная потоком (io.Reader, io.ReadCloser).
+func isStreamVar(v *core.Variable) bool {
+	return !v.IsSlice && v.NumberOfPointers == 0 && (v.TypeID == "io:Reader" || v.TypeID == "io:ReadCloser")
+}
+
+// isBinaryVar проверяет, является ли переменная бинарными данными (поток или []byte).
+func isBinaryVar(v *core.Variable) bool {
+
+	if isStreamVar(v) {
+		return true
+	}
+	return v.IsSlice && v.ArrayLen == 0 && !v.IsEllipsis && (v.TypeID == "byte" || v.TypeID == "uint8")
+}
+
+// streamResult возвращает результат метода, отдаваемый как файл, или nil.
+func (r *ClientRenderer) streamResult(method *core.Method) *core.Variable {
+
+	for _, ret := range r.resultsWithoutError(method) {
+		if isStreamVar(ret) {
+			return ret
+		}
+	}
+	return nil
+}
+
+// httpArgType возвращает TypeScript тип аргумента HTTP метода.
+func (r *ClientRenderer) httpArgType(method *core.Method, pkgPath string, arg *core.Variable) string {
+
+	if r.methodBodyMode(method) != bodyModeJSON && isBinaryVar(arg) {
+		return "Blob"
+	}
+	return r.walkVariable(arg.Name, pkgPath, arg, method.Annotations, true).typeLink()
+}
+
+// streamResultType возвращает тип результата метода, отдающего файл.
+func (r *ClientRenderer) streamResultType(method *core.Method, results []*core.Variable) *tsg.Statement {
+
+	if len(results) == 1 {
+		return tsg.NewStatement().Id("Blob")
+	}
+	stmt := tsg.NewStatement()
+	stmt.Values(func(grp *tsg.Group) {
+		for _, ret := range results {
+			typeStr := "Blob"
+			if !isStreamVar(ret) {
+				typeStr = r.walkVariable(ret.Name, r.contract.PkgPath, ret, method.Annotations, false).typeLink()
+			}
+			grp.Add(tsg.NewStatement().Id(ret.Name).Colon().Add(tsg.TypeFromString(typeStr)).Semicolon())
+		}
+	})
+	return stmt
+}
+
+// httpBody генерирует тело запроса для режимов multipart и raw.
+func (r *ClientRenderer) httpBody(mg *tsg.Group, method *core.Method, args []*core.Variable) {
+
+	if r.methodBodyMode(method) == bodyModeRaw {
+		for _, arg := range args {
+			if isBinaryVar(arg) {
+				mg.Add(tsg.NewStatement().Const("body").Op("=").Id(arg.Name).Semicolon())
+				return
+			}
+		}
+		mg.Add(tsg.NewStatement().Const("body").Op("=").Lit("null").Semicolon())
+		return
+	}
+	mg.Add(tsg.NewStatement().Const("body").Op("=").New("FormData").Call().Semicolon())
+	for _, arg := range args {
+		if isBinaryVar(arg) {
+			mg.Add(tsg.NewStatement().Id("body").Dot("append").Call(tsg.NewStatement().Lit(arg.Name), tsg.NewStatement().Id(arg.Name), tsg.NewStatement().Lit(arg.Name)).Semicolon())
+			continue
+		}
+		appendStmt := tsg.NewStatement().Id("body").Dot("append").Call(tsg.NewStatement().Lit(arg.Name), tsg.NewStatement().Id("String").Call(tsg.NewStatement().Id(arg.Name))).Semicolon()
+		if arg.NumberOfPointers > 0 || r.contains(method.Annotations, "nullable") {
+			mg.If(tsg.NewStatement().Id(arg.Name).Op("!=").Lit("null"), func(ig *tsg.Group) {
+				ig.Add(appendStmt)
+			})
+			continue
+		}
+		mg.Add(appendStmt)
+	}
+}
+
+// rawContentType возвращает Content-Type тела запроса для режима raw.
+func (r *ClientRenderer) rawContentType(method *core.Method) string {
+
+	for _, item := range strings.FieldsFunc(annotationValue(method.Annotations, TagHttpContentType, ""), func(c rune) bool { return c == ',' || c == '|' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			return item
+		}
+	}
+	return "application/octet-stream"
+}
+
+// streamResponse генерирует разбор ответа метода, отдающего файл.
+func (r *ClientRenderer) streamResponse(mg *tsg.Group, method *core.Method, results []*core.Variable) {
+
+	mg.Add(tsg.NewStatement().Const("blob").Op("=").Await(tsg.NewStatement().Id("response").Dot("blob").Call()).Semicolon())
+	if len(results) == 1 {
+		mg.Return(tsg.NewStatement().Id("blob"))
+		return
+	}
+	fileName := annotationValue(method.Annotations, TagHttpDownload, "")
+	headers := r.varHeaderMap(method)
+	returnObj := tsg.NewStatement()
+	returnObj.Values(func(rg *tsg.Group) {
+		for _, ret := range results {
+			switch {
+			case isStreamVar(ret):
+				rg.Add(tsg.NewStatement().Id(ret.Name).Colon().Id("blob"))
+			case ret.Name == fileName:
+				rg.Add(tsg.NewStatement().Id(ret.Name).Colon().Id(`/filename="?([^";]*)"?/.exec(response.headers.get("Content-Disposition") ?? "")?.[1] ?? ""`))
+			case headers[ret.Name] != "":
+				rg.Add(tsg.NewStatement().Id(ret.Name).Colon().Id("response").Dot("headers").Dot("get").Call(tsg.NewStatement().Lit(headers[ret.Name])).Op("??").Lit(""))
+			default:
+				rg.Add(tsg.NewStatement().Id(ret.Name).Colon().Lit(""))
+			}
+		}
+	})
+	mg.Return(returnObj)
+}
+
+// varHeaderMap возвращает маппинг переменных на HTTP заголовки.
+func (r *ClientRenderer) varHeaderMap(method *core.Method) map[string]string {
+	headers := make(map[string]string)
+	if httpHeaders, ok := method.Annotations[TagHttpHeader]; ok && httpHeaders != "" {
+		for _, pair := range strings.Split(httpHeaders, ",") {
+			if pairTokens := strings.Split(pair, "|"); len(pairTokens) == 2 {
+				headers[strings.TrimSpace(pairTokens[0])] = strings.TrimSpace(pairTokens[1])
+			}
+		}
+	}
+	return headers
+}
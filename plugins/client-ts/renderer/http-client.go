@@ -238,6 +238,9 @@ func (r *ClientRenderer) renderHTTPClientClass(contract *core.Contract) *tsg.Sta
 				continue
 			}
 			r.renderHTTPMethod(grp, method, currentContract)
+			r.renderPaginateMethod(grp, currentContract, method, func(arg *core.Variable) string {
+				return r.httpArgType(method, currentContract.PkgPath, arg)
+			})
 		}
 	})
 	stmt.Export()
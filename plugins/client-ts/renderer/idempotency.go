@@ -0,0 +1,37 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"tgp/core"
+	"tgp/plugins/client-ts/tsg"
+)
+
+// headerIdempotencyKey заголовок ключа идемпотентности
+const headerIdempotencyKey = "Idempotency-Key"
+
+// isIdempotent проверяет, помечен ли метод как идемпотентный.
+func (r *ClientRenderer) isIdempotent(method *core.Method) bool {
+	return r.contains(method.Annotations, TagIdempotent)
+}
+
+// idempotencyKeyParam возвращает необязательный параметр ключа идемпотентности.
+func (r *ClientRenderer) idempotencyKeyParam() *tsg.Statement {
+	return tsg.NewStatement().Id("idempotencyKey").Optional().Colon().Id("string")
+}
+
+// idempotencyKeyValue возвращает выражение ключа идемпотентности: переданный ключ или новый UUID.
+func (r *ClientRenderer) idempotencyKeyValue() *tsg.Statement {
+	return tsg.NewStatement().Id("idempotencyKey").Op("??").Id("crypto").Dot("randomUUID").Call()
+}
+
+// idempotencyKeyComment генерирует JSDoc параметра ключа идемпотентности идемпотентного метода.
+// Клиент не повторяет вызовы сам, поэтому сервер распознаёт повтор только по ключу, переданному вызывающим.
+func (r *ClientRenderer) idempotencyKeyComment(grp *tsg.Group, method *core.Method) {
+
+	if !r.isIdempotent(method) {
+		return
+	}
+	grp.Comment("@param idempotencyKey - " + headerIdempotencyKey + " header value. A new key is generated for each call when omitted,")
+	grp.Comment("so pass the same key when repeating the call to let the server return the stored response.")
+}
@@ -246,6 +246,9 @@ func (r *ClientRenderer) renderJsonRPCClientClass(contract *core.Contract) *tsg.
 			if r.methodIsJsonRPC(currentContract, method) {
 				r.renderJsonRPCMethod(grp, currentContract, method)
 				r.renderJsonRPCRequestMethod(grp, currentContract, method)
+				r.renderPaginateMethod(grp, currentContract, method, func(arg *core.Variable) string {
+					return r.walkVariable(arg.Name, currentContract.PkgPath, arg, method.Annotations, true).typeLink()
+				})
 			}
 		}
 
@@ -265,6 +268,8 @@ func (r *ClientRenderer) renderJsonRPCMethod(grp *tsg.Group, contract *core.Cont
 	} else {
 		grp.Comment(fmt.Sprintf("Calls %s.%s method", contract.Name, method.Name))
 	}
+	r.deprecatedComment(grp, contract, method)
+	r.idempotencyKeyComment(grp, method)
 
 	args := r.argsWithoutContext(method)
 	results := r.resultsWithoutError(method)
@@ -285,6 +290,9 @@ func (r *ClientRenderer) renderJsonRPCMethod(grp *tsg.Group, contract *core.Cont
 				pg.Add(paramStmt)
 			}
 		}
+		if r.isIdempotent(method) {
+			pg.Add(r.idempotencyKeyParam())
+		}
 	})
 
 	// Тип возвращаемого значения
@@ -314,13 +322,19 @@ func (r *ClientRenderer) renderJsonRPCMethod(grp *tsg.Group, contract *core.Cont
 		}
 
 		// const execResult = await this.client.exec('methodName', params);
-		methodName := r.lcName(contract.Name) + "." + r.lcName(method.Name)
+		methodName := r.jsonRPCMethodName(contract, method)
 		execCall := tsg.NewStatement()
 		execCall.This().Dot("client").Dot("exec")
-		execCall.Call(
+		execArgs := []*tsg.Statement{
 			tsg.NewStatement().Lit(methodName),
 			tsg.NewStatement().Id("params"),
-		)
+		}
+		if r.isIdempotent(method) {
+			execArgs = append(execArgs, tsg.NewStatement().ObjectLiteral(func(og *tsg.Group) {
+				og.Add(tsg.NewStatement().ObjectField(headerIdempotencyKey, r.idempotencyKeyValue()))
+			}))
+		}
+		execCall.Call(execArgs...)
 
 		mg.Add(
 			tsg.NewStatement().
@@ -395,6 +409,7 @@ func (r *ClientRenderer) renderJsonRPCRequestMethod(grp *tsg.Group, contract *co
 	} else {
 		grp.Comment(fmt.Sprintf("Creates a RequestRPC for %s.%s method", contract.Name, method.Name))
 	}
+	r.deprecatedComment(grp, contract, method)
 
 	args := r.argsWithoutContext(method)
 
@@ -467,7 +482,7 @@ func (r *ClientRenderer) renderJsonRPCRequestMethod(grp *tsg.Group, contract *co
 			rpcRequestStmt.Id("rpcRequest").Colon()
 			rpcRequestStmt.Values(func(rg *tsg.Group) {
 				rg.Add(tsg.NewStatement().ObjectField("jsonrpc", tsg.NewStatement().Lit("2.0")))
-				rg.Add(tsg.NewStatement().ObjectField("method", tsg.NewStatement().Lit(r.lcName(contract.Name)+"."+r.lcName(method.Name))))
+				rg.Add(tsg.NewStatement().ObjectField("method", tsg.NewStatement().Lit(r.jsonRPCMethodName(contract, method))))
 				rg.Add(tsg.NewStatement().ObjectField("params", tsg.NewStatement().Id("params")))
 				// Генерируем ID - используем публичный метод
 				idGen := tsg.NewStatement()
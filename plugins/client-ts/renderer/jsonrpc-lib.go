@@ -129,11 +129,15 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) error {
 		grp.Add(getHeadersMethod)
 		grp.Line()
 
+		r.renderCodecMethods(grp)
+		grp.Line()
+
 		// Метод call для одиночного запроса
 		callMethodParams := tsg.NewStatement().
 			Params(func(pg *tsg.Group) {
 				pg.Add(tsg.NewStatement().Id("method").Colon().Id("string"))
 				pg.Add(tsg.NewStatement().Id("params").Optional().Colon().Id("any"))
+				pg.Add(tsg.NewStatement().Id("extraHeaders").Optional().Colon().Id("Record").Generic("string", "string"))
 			})
 		callReturnType := tsg.NewStatement().
 			ObjectLiteral(func(og *tsg.Group) {
@@ -202,10 +206,12 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) error {
 								tsg.NewStatement().ObjectLiteral(func(og *tsg.Group) {
 									og.Add(tsg.NewStatement().ObjectField("method", tsg.NewStatement().Lit("POST")))
 									og.Add(tsg.NewStatement().ObjectField("headers", tsg.NewStatement().ObjectLiteral(func(hg *tsg.Group) {
-										hg.Add(tsg.NewStatement().ObjectField("Content-Type", tsg.NewStatement().Lit("application/json")))
+										hg.Add(tsg.NewStatement().ObjectField("Content-Type", tsg.NewStatement().This().Dot("getContentType").Call()))
+										hg.Add(tsg.NewStatement().ObjectField("Accept", tsg.NewStatement().This().Dot("getContentType").Call()))
 										hg.Add(tsg.NewStatement().Spread(tsg.NewStatement().Id("headers")))
+										hg.Add(tsg.NewStatement().Spread(tsg.NewStatement().Id("extraHeaders")))
 									})))
-									og.Add(tsg.NewStatement().ObjectField("body", tsg.NewStatement().Id("JSON.stringify").Call(tsg.NewStatement().Id("request"))))
+									og.Add(tsg.NewStatement().ObjectField("body", tsg.NewStatement().This().Dot("encodeBody").Call(tsg.NewStatement().Id("request"))))
 								}),
 							),
 					).
@@ -228,7 +234,7 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) error {
 					Colon().
 					Id("any").
 					Op("=").
-					Await(tsg.NewStatement().Id("response").Dot("json").Call()).
+					Await(tsg.NewStatement().This().Dot("decodeBody").Call(tsg.NewStatement().Id("response"))).
 					Semicolon()
 				bg.Add(parse)
 
@@ -288,10 +294,11 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) error {
 								tsg.NewStatement().ObjectLiteral(func(og *tsg.Group) {
 									og.Add(tsg.NewStatement().ObjectField("method", tsg.NewStatement().Lit("POST")))
 									og.Add(tsg.NewStatement().ObjectField("headers", tsg.NewStatement().ObjectLiteral(func(hg *tsg.Group) {
-										hg.Add(tsg.NewStatement().ObjectField("Content-Type", tsg.NewStatement().Lit("application/json")))
+										hg.Add(tsg.NewStatement().ObjectField("Content-Type", tsg.NewStatement().This().Dot("getContentType").Call()))
+										hg.Add(tsg.NewStatement().ObjectField("Accept", tsg.NewStatement().This().Dot("getContentType").Call()))
 										hg.Add(tsg.NewStatement().Spread(tsg.NewStatement().Id("headers")))
 									})))
-									og.Add(tsg.NewStatement().ObjectField("body", tsg.NewStatement().Id("JSON.stringify").Call(tsg.NewStatement().Id("requests"))))
+									og.Add(tsg.NewStatement().ObjectField("body", tsg.NewStatement().This().Dot("encodeBody").Call(tsg.NewStatement().Id("requests"))))
 								}),
 							),
 					).
@@ -312,7 +319,7 @@ func (r *ClientRenderer) renderJsonRPCClient(jsonrpcDir string) error {
 					Colon().
 					Id("ResponseRPC[]").
 					Op("=").
-					Await(tsg.NewStatement().Id("response").Dot("json").Call()).
+					Await(tsg.NewStatement().This().Dot("decodeBody").Call(tsg.NewStatement().Id("response"))).
 					Semicolon()
 				bg.Add(parse)
 
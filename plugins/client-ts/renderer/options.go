@@ -15,6 +15,9 @@ func (r *ClientRenderer) RenderClientOptions() error {
 	file := tsg.NewFile()
 	file.Comment("// GENERATED BY 'T'ool 'G'ateway. DO NOT EDIT.\n")
 
+	file.Add(r.codecType())
+	file.Line()
+
 	// Генерируем тип ClientOptions
 	stmt := tsg.NewStatement()
 	stmt.Export().Type("ClientOptions")
@@ -36,6 +39,7 @@ func (r *ClientRenderer) RenderClientOptions() error {
 		fnType := tsg.NewStatement()
 		fnType.Params(func(fg *tsg.Group) {}).Op("=>").Id("string")
 		grp.Add(tsg.NewStatement().Id("idGeneratorFn").Optional().Colon().Add(fnType).Semicolon())
+		grp.Add(tsg.NewStatement().Id("codec").Optional().Colon().Id("Codec").Semicolon())
 	})
 	file.Add(stmt)
 	file.Line()
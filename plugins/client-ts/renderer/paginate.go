@@ -0,0 +1,114 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+
+	"tgp/core"
+	"tgp/plugins/client-ts/tsg"
+)
+
+// pagination описывает курсорную пагинацию метода (аннотация paginate)
+type pagination struct {
+	cursor   *core.Variable
+	next     *core.Variable
+	items    *core.Variable
+	pageSize *core.Variable
+}
+
+// methodPagination возвращает пагинацию метода или nil, если метод не помечен paginate (сигнатура проверяется валидацией контракта)
+func (r *ClientRenderer) methodPagination(method *core.Method) *pagination {
+
+	if !r.contains(method.Annotations, TagPaginate) {
+		return nil
+	}
+	find := func(vars []*core.Variable, name string) *core.Variable {
+		for _, v := range vars {
+			if v.Name == name {
+				return v
+			}
+		}
+		return nil
+	}
+	isString := func(v *core.Variable) bool {
+		return v != nil && v.TypeID == "string" && !v.IsSlice && v.NumberOfPointers == 0 && v.MapKeyID == ""
+	}
+	p := &pagination{
+		cursor: find(r.argsWithoutContext(method), annotationValue(method.Annotations, TagPaginateCursor, "cursor")),
+		next:   find(r.resultsWithoutError(method), annotationValue(method.Annotations, TagPaginateNext, "nextCursor")),
+		items:  find(r.resultsWithoutError(method), annotationValue(method.Annotations, TagPaginateItems, "items")),
+	}
+	if !isString(p.cursor) || !isString(p.next) || p.items == nil || !p.items.IsSlice {
+		return nil
+	}
+	if pageSize := annotationValue(method.Annotations, TagPaginatePageSize, ""); pageSize != "" {
+		p.pageSize = find(r.argsWithoutContext(method), pageSize)
+	}
+	return p
+}
+
+// renderPaginateMethod генерирует async generator methodAll, обходящий элементы всех страниц метода
+func (r *ClientRenderer) renderPaginateMethod(grp *tsg.Group, contract *core.Contract, method *core.Method, argType func(arg *core.Variable) string) {
+
+	p := r.methodPagination(method)
+	if p == nil {
+		return
+	}
+	itemType := "any"
+	if item, ok := r.walkVariable(p.items.Name, contract.PkgPath, p.items, method.Annotations, false).properties["item"]; ok {
+		itemType = castTypeTs(item.typeLink())
+	}
+
+	grp.Comment(fmt.Sprintf("Обходит элементы всех страниц %s, запрашивая следующую страницу по курсору %s.", r.lcName(method.Name), p.next.Name))
+	if p.pageSize != nil {
+		grp.Comment(fmt.Sprintf("Размер страницы задаётся аргументом %s и одинаков для всех запросов.", p.pageSize.Name))
+	}
+	grp.Comment("Обход завершается на пустом или повторном курсоре, на первой ошибке или при отмене signal.")
+
+	methodParams := tsg.NewStatement()
+	methodParams.Params(func(pg *tsg.Group) {
+		for _, arg := range r.argsWithoutContext(method) {
+			if arg == p.cursor {
+				continue
+			}
+			paramStmt := tsg.NewStatement()
+			paramStmt.Id(arg.Name)
+			if r.contains(method.Annotations, "nullable") {
+				paramStmt.Optional()
+			}
+			paramStmt.Colon()
+			paramStmt.Add(tsg.TypeFromString(argType(arg)))
+			pg.Add(paramStmt)
+		}
+		pg.Add(tsg.NewStatement().Id("signal").Optional().Colon().Id("AbortSignal"))
+	})
+
+	methodStmt := tsg.NewStatement()
+	methodStmt.Public()
+	methodStmt.AsyncGeneratorMethodWithParams(r.lcName(method.Name)+"All", methodParams, tsg.TypeFromString(itemType), func(mg *tsg.Group) {
+		mg.Add(tsg.NewStatement().Var("_cursor").Op("=").Lit("").Semicolon())
+		mg.While(tsg.NewStatement().Id("true"), func(wg *tsg.Group) {
+			wg.Add(tsg.NewStatement().Id("signal?.throwIfAborted").Call().Semicolon())
+			var callArgs []*tsg.Statement
+			for _, arg := range r.argsWithoutContext(method) {
+				if arg == p.cursor {
+					callArgs = append(callArgs, tsg.NewStatement().Id("_cursor"))
+					continue
+				}
+				callArgs = append(callArgs, tsg.NewStatement().Id(arg.Name))
+			}
+			call := tsg.NewStatement().This().Dot(r.lcName(method.Name)).Call(callArgs...)
+			wg.Add(tsg.NewStatement().Const("_page").Op("=").Await(call).Semicolon())
+			wg.ForOf("_item", fmt.Sprintf("_page.%s ?? []", p.items.Name), func(fg *tsg.Group) {
+				fg.Yield(tsg.NewStatement().Id("_item"))
+			})
+			wg.If(tsg.NewStatement().Op("!").Id("_page").Dot(p.next.Name).Op("||").Id("_page").Dot(p.next.Name).Op("===").Id("_cursor"), func(ig *tsg.Group) {
+				ig.Return()
+			})
+			wg.Assign(tsg.NewStatement().Id("_cursor"), tsg.NewStatement().Id("_page").Dot(p.next.Name))
+		})
+	})
+	grp.Add(methodStmt)
+	grp.Line()
+}
@@ -677,9 +677,11 @@ func (r *ClientRenderer) renderMethodDocTS(md *markdown.Markdown, method *core.M
 		md.PlainText(desc)
 		md.LF()
 	}
+	r.renderMethodDeprecationTS(md, contract, method)
 
 	// Сигнатура метода
 	r.renderMethodSignatureTS(md, method, contract, false)
+	r.renderMethodPaginationTS(md, method, contract)
 
 	// Параметры и возвращаемые значения
 	r.renderMethodParamsAndResultsTS(md, method, contract)
@@ -726,9 +728,11 @@ func (r *ClientRenderer) renderHTTPMethodDocTS(md *markdown.Markdown, method *co
 		md.PlainText(desc)
 		md.LF()
 	}
+	r.renderMethodDeprecationTS(md, contract, method)
 
 	// Сигнатура метода
 	r.renderMethodSignatureTS(md, method, contract, true)
+	r.renderMethodPaginationTS(md, method, contract)
 
 	// Параметры и возвращаемые значения
 	r.renderMethodParamsAndResultsTS(md, method, contract)
@@ -813,6 +817,36 @@ func (r *ClientRenderer) renderMethodSignatureTS(md *markdown.Markdown, method *
 	md.LF()
 }
 
+// renderMethodPaginationTS генерирует описание async итератора по страницам метода с аннотацией paginate
+func (r *ClientRenderer) renderMethodPaginationTS(md *markdown.Markdown, method *core.Method, contract *core.Contract) {
+	p := r.methodPagination(method)
+	if p == nil {
+		return
+	}
+	iterName := r.lcName(method.Name) + "All"
+	description := fmt.Sprintf("%s возвращает AsyncIterable по элементам %s всех страниц: следующая страница запрашивается по курсору %s, обход завершается на пустом курсоре, ошибке или отмене AbortSignal.",
+		markdown.Code(iterName), markdown.Code(p.items.Name), markdown.Code(p.next.Name))
+	if p.pageSize != nil {
+		description += fmt.Sprintf(" Размер страницы задаётся параметром %s.", markdown.Code(p.pageSize.Name))
+	}
+	md.PlainText(markdown.Bold("Пагинация:") + " " + description)
+	md.LF()
+
+	var args []string
+	for _, arg := range r.argsWithoutContext(method) {
+		if arg != p.cursor {
+			args = append(args, arg.Name)
+		}
+	}
+	args = append(args, "controller.signal")
+	serviceVar := r.getClientMethodName(contract)
+	md.CodeBlocks(markdown.SyntaxHighlightTypeScript, fmt.Sprintf(`const controller = new AbortController();
+for await (const item of client.%s().%s(%s)) {
+    console.log(item);
+}`, serviceVar, iterName, strings.Join(args, ", ")))
+	md.LF()
+}
+
 // renderMethodParamsAndResultsTS генерирует таблицы параметров и возвращаемых значений
 func (r *ClientRenderer) renderMethodParamsAndResultsTS(md *markdown.Markdown, method *core.Method, contract *core.Contract) {
 	args := r.argsWithoutContext(method)
@@ -24,6 +24,8 @@ func (r *ClientRenderer) isHTTP(method *core.Method, contract *core.Contract) bo
 func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *core.Method, contract *core.Contract) {
 	args := r.argsWithoutContext(method)
 	results := r.resultsWithoutError(method)
+	bodyMode := r.methodBodyMode(method)
+	streamRet := r.streamResult(method)
 
 	// Комментарий к методу (без аннотаций @tg)
 	filteredDocs := r.filterDocsComments(method.Docs)
@@ -32,6 +34,8 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *core.Method, c
 	} else {
 		grp.Comment(fmt.Sprintf("Вызывает HTTP метод %s", method.Name))
 	}
+	r.deprecatedComment(grp, contract, method)
+	r.idempotencyKeyComment(grp, method)
 
 	// Добавляем информацию о возможных ошибках в JSDoc
 	methodErrors := r.collectMethodErrors(method, contract)
@@ -59,7 +63,7 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *core.Method, c
 		if len(args) > 0 {
 			// Генерируем отдельные параметры для каждого аргумента
 			for _, arg := range args {
-				typeStr := r.walkVariable(arg.Name, contract.PkgPath, arg, method.Annotations, true).typeLink()
+				typeStr := r.httpArgType(method, contract.PkgPath, arg)
 				paramStmt := tsg.NewStatement()
 				paramStmt.Id(arg.Name)
 				if r.contains(method.Annotations, "nullable") {
@@ -70,10 +74,16 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *core.Method, c
 				pg.Add(paramStmt)
 			}
 		}
+		if r.isIdempotent(method) {
+			pg.Add(r.idempotencyKeyParam())
+		}
 	})
 
 	// Тип возвращаемого значения
 	returnType := r.resultToTypeStatement(method, results)
+	if streamRet != nil {
+		returnType = r.streamResultType(method, results)
+	}
 
 	// Получаем типы из exchange только если они нужны
 	var requestTypeName string
@@ -90,7 +100,7 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *core.Method, c
 	methodStmt.Public()
 	methodStmt.AsyncMethodWithParams(r.lcName(method.Name), methodParams, returnType, func(mg *tsg.Group) {
 		// Собираем объект params из отдельных параметров с типизацией через exchange тип
-		if len(args) > 0 {
+		if len(args) > 0 && bodyMode == bodyModeJSON {
 			paramsObj := tsg.NewStatement()
 			paramsObj.Const("params").Colon().Id(requestTypeName).Op("=")
 			paramsObj.Values(func(vg *tsg.Group) {
@@ -118,18 +128,22 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *core.Method, c
 
 		// Формируем тело запроса
 		bodyStmt := tsg.NewStatement()
-		if len(args) > 0 {
+		if bodyMode != bodyModeJSON {
+			r.httpBody(mg, method, args)
+		} else if len(args) > 0 {
 			bodyObj := tsg.NewStatement()
 			bodyObj.Values(func(bg *tsg.Group) {
 				for _, arg := range args {
 					bg.Add(tsg.NewStatement().Id(arg.Name).Colon().Id("params").Dot(arg.Name))
 				}
 			})
-			bodyStmt.Const("body").Op("=").Id("JSON").Dot("stringify").Call(bodyObj)
+			bodyStmt.Const("body").Op("=").Id("this").Dot("baseClient").Dot("encodeBody").Call(bodyObj)
 		} else {
 			bodyStmt.Const("body").Op("=").Lit("null")
 		}
-		mg.Add(bodyStmt.Semicolon())
+		if bodyMode == bodyModeJSON {
+			mg.Add(bodyStmt.Semicolon())
+		}
 
 		// Получаем заголовки из базового клиента (поддерживает статичные и динамические)
 		headersVar := tsg.NewStatement().
@@ -146,8 +160,18 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *core.Method, c
 		headersStmt := tsg.NewStatement()
 		headersStmt.Const("headers").Op("=").Id("new Headers").Call().Semicolon()
 		mg.Add(headersStmt)
-		mg.Add(tsg.NewStatement().Id("headers").Dot("set").Call(tsg.NewStatement().Lit("Content-Type"), tsg.NewStatement().Lit("application/json")).Semicolon())
-		mg.Add(tsg.NewStatement().Id("headers").Dot("set").Call(tsg.NewStatement().Lit("Accept"), tsg.NewStatement().Lit("application/json")).Semicolon())
+		// Для multipart Content-Type с boundary выставляет fetch
+		switch bodyMode {
+		case bodyModeJSON:
+			mg.Add(tsg.NewStatement().Id("headers").Dot("set").Call(tsg.NewStatement().Lit("Content-Type"), tsg.NewStatement().Id("this").Dot("baseClient").Dot("getContentType").Call()).Semicolon())
+		case bodyModeRaw:
+			mg.Add(tsg.NewStatement().Id("headers").Dot("set").Call(tsg.NewStatement().Lit("Content-Type"), tsg.NewStatement().Lit(r.rawContentType(method))).Semicolon())
+		}
+		accept := tsg.NewStatement().Id("this").Dot("baseClient").Dot("getContentType").Call()
+		if streamRet != nil {
+			accept = tsg.NewStatement().Lit("*/*")
+		}
+		mg.Add(tsg.NewStatement().Id("headers").Dot("set").Call(tsg.NewStatement().Lit("Accept"), accept).Semicolon())
 
 		// Добавляем заголовки из клиента
 		mg.Add(tsg.NewStatement().
@@ -155,6 +179,9 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *core.Method, c
 				fg.Add(tsg.NewStatement().Id("headers").Dot("set").Call(tsg.NewStatement().Id("key"), tsg.NewStatement().Id("value")))
 			}).
 			Semicolon())
+		if r.isIdempotent(method) {
+			mg.Add(tsg.NewStatement().Id("headers").Dot("set").Call(tsg.NewStatement().Lit(headerIdempotencyKey), r.idempotencyKeyValue()).Semicolon())
+		}
 
 		// Выполняем запрос
 		fetchOptions := tsg.NewStatement()
@@ -204,11 +231,14 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *core.Method, c
 		})
 
 		// Обрабатываем ответ с типизацией через exchange тип
-		if len(results) == 0 {
+		switch {
+		case len(results) == 0:
 			mg.Return()
-		} else {
+		case streamRet != nil:
+			r.streamResponse(mg, method, results)
+		default:
 			// Типизируем responseData через exchange тип
-			mg.Add(tsg.NewStatement().Const("responseData").Colon().Id(responseTypeName).Op("=").Await(tsg.NewStatement().Id("response").Dot("json").Call()).Op("as").Id(responseTypeName).Semicolon())
+			mg.Add(tsg.NewStatement().Const("responseData").Colon().Id(responseTypeName).Op("=").Await(tsg.NewStatement().Id("this").Dot("baseClient").Dot("decodeBody").Call(tsg.NewStatement().Id("response"))).Op("as").Id(responseTypeName).Semicolon())
 			if len(results) == 1 {
 				mg.Return(tsg.NewStatement().Id("responseData"))
 			} else {
@@ -228,10 +258,14 @@ func (r *ClientRenderer) renderHTTPMethod(grp *tsg.Group, method *core.Method, c
 
 // httpPath возвращает путь для HTTP метода
 func (r *ClientRenderer) httpPath(method *core.Method, contract *core.Contract) string {
-	// Проверяем аннотацию http-path
+	// Проверяем аннотацию http-path, по умолчанию используем имя метода в lowerCamelCase
+	methodPath := "/" + r.lcName(method.Name)
 	if r.contains(method.Annotations, TagHttpPath) {
-		return annotationValue(method.Annotations, TagHttpPath, "")
+		methodPath = annotationValue(method.Annotations, TagHttpPath, "")
+	}
+	// Версия API добавляется сегментом перед путём метода
+	if version := r.methodVersion(contract, method); version != "" {
+		return "/" + version + methodPath
 	}
-	// По умолчанию используем имя метода в lowerCamelCase
-	return "/" + r.lcName(method.Name)
+	return methodPath
 }
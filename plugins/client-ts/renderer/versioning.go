@@ -0,0 +1,78 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"strings"
+
+	"tgp/internal/markdown"
+
+	"tgp/core"
+	"tgp/plugins/client-ts/tsg"
+)
+
+// methodVersion возвращает версию API метода (аннотация метода переопределяет аннотацию контракта).
+func (r *ClientRenderer) methodVersion(contract *core.Contract, method *core.Method) string {
+
+	if version := strings.TrimSpace(annotationValue(method.Annotations, TagVersion, "")); version != "" {
+		return version
+	}
+	return strings.TrimSpace(annotationValue(contract.Annotations, TagVersion, ""))
+}
+
+// jsonRPCMethodName возвращает имя JSON-RPC метода с пространством имён версии.
+func (r *ClientRenderer) jsonRPCMethodName(contract *core.Contract, method *core.Method) string {
+
+	name := r.lcName(contract.Name) + "." + r.lcName(method.Name)
+	if version := r.methodVersion(contract, method); version != "" {
+		name = version + "." + name
+	}
+	return name
+}
+
+// methodDeprecated проверяет, выводится ли метод из эксплуатации, и возвращает пояснение и дату отключения.
+func (r *ClientRenderer) methodDeprecated(contract *core.Contract, method *core.Method) (message, sunset string, deprecated bool) {
+
+	if r.contains(method.Annotations, TagDeprecated) {
+		message, deprecated = method.Annotations[TagDeprecated], true
+	} else if r.contains(contract.Annotations, TagDeprecated) {
+		message, deprecated = contract.Annotations[TagDeprecated], true
+	}
+	sunset = annotationValue(method.Annotations, TagSunset, annotationValue(contract.Annotations, TagSunset, ""))
+	return strings.TrimSpace(message), strings.TrimSpace(sunset), deprecated
+}
+
+// deprecatedComment добавляет к комментарию метода пометку @deprecated.
+func (r *ClientRenderer) deprecatedComment(grp *tsg.Group, contract *core.Contract, method *core.Method) {
+
+	message, sunset, deprecated := r.methodDeprecated(contract, method)
+	if !deprecated {
+		return
+	}
+	text := "@deprecated"
+	if message != "" {
+		text += " " + message
+	}
+	if sunset != "" {
+		text += " (sunset " + sunset + ")"
+	}
+	grp.Comment(text)
+}
+
+// renderMethodDeprecationTS генерирует в документации пометку о выводе метода из эксплуатации.
+func (r *ClientRenderer) renderMethodDeprecationTS(md *markdown.Markdown, contract *core.Contract, method *core.Method) {
+
+	message, sunset, deprecated := r.methodDeprecated(contract, method)
+	if !deprecated {
+		return
+	}
+	text := markdown.Bold("Устарел:") + " метод выводится из эксплуатации."
+	if message != "" {
+		text = markdown.Bold("Устарел:") + " " + message + "."
+	}
+	if sunset != "" {
+		text += " Будет отключён " + sunset + "."
+	}
+	md.PlainText(text)
+	md.LF()
+}
@@ -513,6 +513,33 @@ func (s *Statement) AsyncMethodWithParams(name string, params *Statement, return
 	return s
 }
 
+// AsyncGeneratorMethodWithParams создаёт async generator метод класса, возвращающий AsyncIterable
+func (s *Statement) AsyncGeneratorMethodWithParams(name string, params *Statement, itemType *Statement, fn func(*Group)) *Statement {
+	s.writeIndent()
+	s.code.WriteString("async *" + name)
+	if params != nil {
+		s.code.WriteString(params.String())
+	} else {
+		s.code.WriteString("()")
+	}
+	if itemType != nil {
+		s.code.WriteString(": AsyncIterable<")
+		s.code.WriteString(itemType.String())
+		s.code.WriteString(">")
+	}
+	s.code.WriteString(" {")
+	s.code.WriteString("\n")
+	s.indent++
+	if fn != nil {
+		g := &Group{statement: s, inObject: false}
+		fn(g)
+	}
+	s.indent--
+	s.writeIndent()
+	s.code.WriteString("}")
+	return s
+}
+
 // AsyncMethodWithGeneric создаёт async метод класса с generic параметрами
 func (s *Statement) AsyncMethodWithGeneric(name string, genericParams *Statement, params *Statement, returnType *Statement, fn func(*Group)) *Statement {
 	s.writeIndent()
@@ -1037,6 +1064,49 @@ func (g *Group) If(condition *Statement, fn func(*Group)) {
 	g.statement.code.WriteString("}\n")
 }
 
+// While добавляет цикл while
+func (g *Group) While(condition *Statement, fn func(*Group)) {
+	g.statement.writeIndent()
+	g.statement.code.WriteString("while (")
+	if condition != nil {
+		g.statement.code.WriteString(condition.String())
+	}
+	g.statement.code.WriteString(") {")
+	g.statement.code.WriteString("\n")
+	g.statement.indent++
+	if fn != nil {
+		fn(g)
+	}
+	g.statement.indent--
+	g.statement.writeIndent()
+	g.statement.code.WriteString("}\n")
+}
+
+// ForOf добавляет цикл for...of
+func (g *Group) ForOf(variable, iterable string, fn func(*Group)) {
+	g.statement.writeIndent()
+	g.statement.code.WriteString("for (const " + variable + " of " + iterable + ") {")
+	g.statement.code.WriteString("\n")
+	g.statement.indent++
+	if fn != nil {
+		fn(g)
+	}
+	g.statement.indent--
+	g.statement.writeIndent()
+	g.statement.code.WriteString("}\n")
+}
+
+// Yield добавляет yield
+func (g *Group) Yield(expr *Statement) {
+	g.statement.writeIndent()
+	g.statement.code.WriteString("yield")
+	if expr != nil {
+		g.statement.code.WriteString(" ")
+		g.statement.code.WriteString(expr.String())
+	}
+	g.statement.code.WriteString(";\n")
+}
+
 // Throw добавляет throw
 func (g *Group) Throw(expr *Statement) {
 	g.statement.writeIndent()
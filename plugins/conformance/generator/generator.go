@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"tgp/core"
+	"tgp/internal/parser"
+	"tgp/plugins/conformance/renderer"
+)
+
+// DeserializeProject десериализует Project из JSON.
+func DeserializeProject(projectData interface{}) (*parser.Project, error) {
+
+	projectBytes, err := json.Marshal(projectData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal project data: %w", err)
+	}
+
+	var project parser.Project
+	if err := json.Unmarshal(projectBytes, &project); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal project: %w", err)
+	}
+
+	return &project, nil
+}
+
+// GenerateConformance генерирует тесты соответствия клиента и сервера для контрактов проекта (всех или указанных по имени/ID).
+func GenerateConformance(project *parser.Project, outDir, transportDir, clientDir string, contracts ...string) error {
+
+	if project == nil {
+		return fmt.Errorf("project cannot be nil")
+	}
+	if outDir == "" || transportDir == "" || clientDir == "" {
+		return fmt.Errorf("outDir, transportDir and clientDir cannot be empty")
+	}
+
+	logger := core.GetLogger()
+	conformanceRenderer := renderer.NewConformanceRenderer(project, outDir, transportDir, clientDir)
+
+	if err := conformanceRenderer.RenderBase(); err != nil {
+		return fmt.Errorf("render conformance base: %w", err)
+	}
+	for _, contract := range project.Contracts {
+		if !contractSelected(contract, contracts) {
+			continue
+		}
+		logger.Info(fmt.Sprintf("generating conformance tests for contract: contract=%s", contract.ID))
+		if err := conformanceRenderer.RenderContract(contract); err != nil {
+			return fmt.Errorf("render conformance %s: %w", contract.Name, err)
+		}
+	}
+	return nil
+}
+
+// contractSelected проверяет, попадает ли контракт в фильтр.
+func contractSelected(contract *parser.Contract, contracts []string) bool {
+
+	if len(contracts) == 0 {
+		return true
+	}
+	for _, name := range contracts {
+		if contract.Name == name || contract.ID == name {
+			return true
+		}
+	}
+	return false
+}
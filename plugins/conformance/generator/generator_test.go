@@ -0,0 +1,268 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/core"
+	tgparser "tgp/internal/parser"
+	"tgp/internal/tags"
+	clientgen "tgp/plugins/client-go/generator"
+	servergen "tgp/plugins/server/generator"
+)
+
+// testLogger пишет сообщения генератора в лог теста.
+type testLogger struct {
+	t *testing.T
+}
+
+func (l testLogger) Debug(string)     {}
+func (l testLogger) Info(string)      {}
+func (l testLogger) Warn(msg string)  { l.t.Log("WARN", msg) }
+func (l testLogger) Error(msg string) { l.t.Log("ERROR", msg) }
+
+const testModulePath = "example.com/svc"
+
+// testContract возвращает контракт с методом Ping без аргументов и результатов (кроме context и error).
+func testContract(name string, annotations, methodAnnotations tags.DocTags) *tgparser.Contract {
+
+	id := testModulePath + "/contracts:" + name
+	return &tgparser.Contract{
+		Name:        name,
+		PkgPath:     testModulePath + "/contracts",
+		ID:          id,
+		Annotations: annotations,
+		Methods: []*tgparser.Method{{
+			Name:        "Ping",
+			ContractID:  id,
+			Args:        []*tgparser.Variable{{Name: "ctx", TypeID: "context:Context"}},
+			Results:     []*tgparser.Variable{{Name: "err", TypeID: "error"}},
+			Annotations: methodAnnotations,
+		}},
+	}
+}
+
+// generate генерирует тесты соответствия контрактов во временной директории и возвращает содержимое файлов по пути.
+func generate(t *testing.T, contracts ...*tgparser.Contract) map[string]string {
+
+	core.SetLogger(testLogger{t: t})
+	t.Chdir(t.TempDir())
+
+	project := &tgparser.Project{
+		ModulePath: testModulePath,
+		Contracts:  contracts,
+		Types: map[string]*tgparser.Type{
+			"context:Context": {Kind: tgparser.TypeKindInterface, TypeName: "Context", ImportPkgPath: "context", PkgName: "context"},
+		},
+	}
+	if err := GenerateConformance(project, "internal/conformance", "internal/transport", "pkg/client"); err != nil {
+		t.Fatalf("generate conformance: %v", err)
+	}
+
+	files := make(map[string]string)
+	err := filepath.WalkDir("internal/conformance", func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		if _, err = parser.ParseFile(token.NewFileSet(), filePath, data, parser.AllErrors); err != nil {
+			t.Errorf("generated file %s does not parse: %v", filePath, err)
+		}
+		files[filepath.ToSlash(filePath)] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestGenerateConformance_ZeroArgMethod(t *testing.T) {
+
+	files := generate(t, testContract("Svc", tags.DocTags{"jsonRPC-server": ""}, tags.DocTags{"summary": "Ping"}))
+
+	base, found := files["internal/conformance/conformance.go"]
+	if !found {
+		t.Fatal("conformance.go is not generated")
+	}
+	if !strings.Contains(base, "args, results = []any{}, []any{}") {
+		t.Error("call must start with empty args and results: methods without arguments are recorded by Fake as []any{}")
+	}
+	test := files["internal/conformance/svc/svc_test.go"]
+	if !strings.Contains(test, "f.fake.Call(\"Ping\", []any{})") {
+		t.Errorf("fake Ping must record empty args:\n%s", test)
+	}
+	if !strings.Contains(test, "func TestSvcPing(") {
+		t.Errorf("test for Ping is not generated:\n%s", test)
+	}
+}
+
+func TestGenerateConformance_TransportOption(t *testing.T) {
+
+	httpMethod := tags.DocTags{"http-method": "GET", "http-path": "/ping"}
+	files := generate(t,
+		testContract("Health", tags.DocTags{"http-server": ""}, httpMethod),
+		testContract("Rpc", tags.DocTags{"jsonRPC-server": "", "http-server": ""}, tags.DocTags{"summary": "Ping"}),
+		testContract("Status", tags.DocTags{"http-server": ""}, httpMethod),
+	)
+
+	if test := files["internal/conformance/rpc/rpc_test.go"]; !strings.Contains(test, "transport.Rpc(f)") {
+		t.Errorf("JSON-RPC contract must be registered with its own option:\n%s", test)
+	}
+	if test := files["internal/conformance/health/health_test.go"]; !strings.Contains(test, "transport.HTTPService(f)") {
+		t.Errorf("HTTP-only contract must be registered with HTTPService:\n%s", test)
+	}
+	// HTTPService регистрирует только первый контракт с http-server
+	if _, found := files["internal/conformance/status/status_test.go"]; found {
+		t.Error("tests must not be generated for HTTP-only contract without transport option")
+	}
+}
+
+// testUnannotatedContract - исходный код контракта для проекта TestGenerateConformance_UnannotatedMethod.
+const testUnannotatedContract = `package contracts
+
+import "context"
+
+type Svc interface {
+	Ping(ctx context.Context) (err error)
+	Sum(ctx context.Context, a int, b int) (c int, err error)
+}
+`
+
+// goCommand возвращает команду go в директории dir: зависимости берутся из кэша модулей, затем из GOPROXY окружения.
+func goCommand(t *testing.T, dir string, args ...string) *exec.Cmd {
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found")
+	}
+	modCache, err := exec.Command(goBin, "env", "GOMODCACHE").Output()
+	if err != nil {
+		t.Fatalf("go env GOMODCACHE: %v", err)
+	}
+	goProxy := "file://" + filepath.ToSlash(filepath.Join(strings.TrimSpace(string(modCache)), "cache", "download"))
+	if proxy := os.Getenv("GOPROXY"); proxy != "" {
+		goProxy += "," + proxy
+	} else {
+		goProxy += ",https://proxy.golang.org,direct"
+	}
+	cmd := exec.Command(goBin, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off", "GOPROXY="+goProxy, "GOSUMDB=off")
+	return cmd
+}
+
+// sharedProject возвращает проект в общем JSON представлении: флаги аннотаций (true) передаются пустой строкой.
+func sharedProject(t *testing.T, project *tgparser.Project) (shared any) {
+
+	data, err := json.Marshal(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(data, &shared); err != nil {
+		t.Fatal(err)
+	}
+	var walk func(value any)
+	walk = func(value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			for key, item := range value {
+				if annotations, ok := item.(map[string]any); ok && key == "annotations" {
+					for tag, flag := range annotations {
+						if flag == true {
+							annotations[tag] = ""
+						}
+					}
+				}
+				walk(item)
+			}
+		case []any:
+			for _, item := range value {
+				walk(item)
+			}
+		}
+	}
+	walk(shared)
+	return
+}
+
+func TestGenerateConformance_UnannotatedMethod(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("builds generated transport, client and conformance tests")
+	}
+	core.SetLogger(testLogger{t: t})
+	root := t.TempDir()
+	t.Chdir(root)
+	if err := os.WriteFile("go.mod", []byte("module "+testModulePath+"\n\ngo 1.25\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll("contracts", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("contracts", "svc.go"), []byte(testUnannotatedContract), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ping без аннотаций: клиент не генерирует для него метод, тест соответствия не должен его вызывать
+	contract := testContract("Svc", tags.DocTags{"jsonRPC-server": ""}, nil)
+	contract.FilePath = "contracts/svc.go"
+	contract.Methods = append(contract.Methods, &tgparser.Method{
+		Name:        "Sum",
+		ContractID:  contract.ID,
+		Args:        []*tgparser.Variable{{Name: "ctx", TypeID: "context:Context"}, {Name: "a", TypeID: "int"}, {Name: "b", TypeID: "int"}},
+		Results:     []*tgparser.Variable{{Name: "c", TypeID: "int"}, {Name: "err", TypeID: "error"}},
+		Annotations: tags.DocTags{"summary": "Sum"},
+	})
+	project := &tgparser.Project{
+		ModulePath:   testModulePath,
+		ContractsDir: "contracts",
+		Contracts:    []*tgparser.Contract{contract},
+		Types: map[string]*tgparser.Type{
+			"context:Context": {Kind: tgparser.TypeKindInterface, TypeName: "Context", ImportPkgPath: "context", PkgName: "context"},
+		},
+	}
+	if err := servergen.GenerateTransportFiles(project, "internal/transport", "."); err != nil {
+		t.Fatalf("generate transport: %v", err)
+	}
+	if err := servergen.GenerateServer(project, contract.ID, "internal/transport", "."); err != nil {
+		t.Fatalf("generate server: %v", err)
+	}
+	clientProject, err := clientgen.DeserializeProject(sharedProject(t, project))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = clientgen.GenerateClient(clientProject, "pkg/client", ".", clientgen.DocOptions{}, clientgen.ModuleOptions{}); err != nil {
+		t.Fatalf("generate client: %v", err)
+	}
+	if err = GenerateConformance(project, "internal/conformance", "internal/transport", "pkg/client"); err != nil {
+		t.Fatalf("generate conformance: %v", err)
+	}
+
+	test, err := os.ReadFile(filepath.Join("internal", "conformance", "svc", "svc_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(test), "func TestSvcPing(") || !strings.Contains(string(test), "func TestSvcSum(") {
+		t.Errorf("tests must be generated only for methods of the client:\n%s", test)
+	}
+
+	// зависимости транспорта недоступны без сети и кэша модулей
+	if output, err := goCommand(t, root, "mod", "tidy").CombinedOutput(); err != nil {
+		t.Skipf("resolve dependencies: %v\n%s", err, output)
+	}
+	if output, err := goCommand(t, root, "test", "-count=1", "./internal/conformance/...").CombinedOutput(); err != nil {
+		t.Fatalf("generated conformance tests: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,9 @@
+package main
+
+import (
+	"tgp/core"
+)
+
+func init() {
+	core.SetPluginInstance(pluginInstance)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"tgp/core"
+	"tgp/internal/cleanup"
+	"tgp/plugins/conformance/generator"
+)
+
+//go:embed plugin.md
+var pluginDoc string
+
+// ConformancePlugin реализует интерфейс Plugin.
+type ConformancePlugin struct{}
+
+// Info возвращает информацию о плагине.
+func (p *ConformancePlugin) Info() core.PluginInfo {
+	return core.PluginInfo{
+		Name:         "conformance",
+		Version:      "2.4.0",
+		Doc:          pluginDoc,
+		Description:  translate("Client-server conformance test generator for contracts"),
+		Author:       "AlexK (seniorGolang@gmail.com)",
+		License:      "MIT",
+		Category:     "testing",
+		Dependencies: []string{"astg"},
+		Commands: []core.Command{
+			{
+				Path:        []string{"conformance"},
+				Description: translate("Generate contract conformance tests"),
+				Options: []core.Option{
+					{
+						Name:        "out",
+						Short:       "o",
+						Type:        "string",
+						Description: translate("Path to output directory"),
+						Required:    true,
+					},
+					{
+						Name:        "transport",
+						Type:        "string",
+						Description: translate("Path to generated server transport directory"),
+						Required:    true,
+					},
+					{
+						Name:        "client",
+						Type:        "string",
+						Description: translate("Path to generated Go client directory"),
+						Required:    true,
+					},
+					{
+						Name:        "ifaces",
+						Type:        "string",
+						Description: translate("Comma-separated list of interfaces for filtering (e.g., \"Contract1,Contract2\")"),
+						Required:    false,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Execute выполняет основную логику плагина.
+func (p *ConformancePlugin) Execute(rootDir string, request core.Storage, path ...string) (response core.Storage, err error) {
+
+	logger := core.GetLogger()
+
+	logger.Info(translate("conformance plugin started"))
+
+	projectVal, ok := request.Get("project")
+	if !ok {
+		return nil, fmt.Errorf("project is required in request")
+	}
+
+	coreProject, err := generator.DeserializeProject(projectVal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize project: %w", err)
+	}
+
+	dirs := make(map[string]string)
+	for _, name := range []string{"out", "transport", "client"} {
+		dirVal, ok := request.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("%s option is required", name)
+		}
+		dir, ok := dirVal.(string)
+		if !ok || dir == "" {
+			return nil, fmt.Errorf("%s option is required and must be a string", name)
+		}
+		// В WASM файловая система монтируется в корень "/", поэтому используем относительные пути
+		if filepath.IsAbs(dir) {
+			relPath, err := filepath.Rel(rootDir, dir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute relative path from rootDir: %w", err)
+			}
+			dir = relPath
+		}
+		dirs[name] = dir
+	}
+
+	var ifaces []string
+	if ifacesVal, ok := request.Get("ifaces"); ok {
+		if ifacesStr, ok := ifacesVal.(string); ok && ifacesStr != "" {
+			for _, part := range strings.FieldsFunc(ifacesStr, func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			}) {
+				if part = strings.TrimSpace(part); part != "" {
+					ifaces = append(ifaces, part)
+				}
+			}
+		}
+	}
+
+	// Очищаем старые сгенерированные тесты перед новой генерацией
+	if err := cleanup.CleanupGeneratedFiles(dirs["out"]); err != nil {
+		logger.Warn(fmt.Sprintf("failed to cleanup generated files: error=%v", err))
+	}
+
+	logger.Info(fmt.Sprintf("generating conformance tests: outDir=%s transport=%s client=%s ifaces=%v", dirs["out"], dirs["transport"], dirs["client"], ifaces))
+	if err = generator.GenerateConformance(coreProject, dirs["out"], dirs["transport"], dirs["client"], ifaces...); err != nil {
+		logger.Error(fmt.Sprintf("failed to generate conformance tests: outDir=%s error=%v", dirs["out"], err))
+		return nil, err
+	}
+
+	logger.Info(translate("conformance plugin completed"))
+
+	response = core.NewStorage()
+	if err = response.Set("outDir", dirs["out"]); err != nil {
+		return nil, fmt.Errorf("failed to set response: %w", err)
+	}
+
+	return response, nil
+}
+
+// pluginInstance - экземпляр плагина для регистрации.
+var pluginInstance core.Plugin = &ConformancePlugin{}
@@ -0,0 +1,325 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+)
+
+// ConformanceRenderer генерирует тесты соответствия сгенерированных клиента и сервера.
+type ConformanceRenderer struct {
+	project      *parser.Project
+	outDir       string
+	transportDir string
+	clientDir    string
+}
+
+// NewConformanceRenderer создает новый рендерер тестов соответствия.
+func NewConformanceRenderer(project *parser.Project, outDir, transportDir, clientDir string) *ConformanceRenderer {
+	return &ConformanceRenderer{
+		project:      project,
+		outDir:       outDir,
+		transportDir: transportDir,
+		clientDir:    clientDir,
+	}
+}
+
+// pkgPath возвращает путь пакета для указанной директории.
+func (r *ConformanceRenderer) pkgPath(dir string) string {
+
+	pkgDir := strings.TrimPrefix(filepath.ToSlash(dir), "./")
+	if pkgDir == "" || pkgDir == "." {
+		return r.project.ModulePath
+	}
+	return r.project.ModulePath + "/" + strings.TrimPrefix(pkgDir, "/")
+}
+
+// RenderBase генерирует общий для всех тестов пакет: запись вызовов fake реализаций, заполнение тестовыми значениями и проверки.
+func (r *ConformanceRenderer) RenderBase() error {
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageFmt, "fmt")
+	srcFile.ImportName(PackageSync, "sync")
+	srcFile.ImportName(PackageTime, "time")
+	srcFile.ImportName(PackageJson, "json")
+	srcFile.ImportName(PackageErrors, "errors")
+	srcFile.ImportName(PackageReflect, "reflect")
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageTesting, "testing")
+
+	srcFile.Line().Const().Id("maxDepth").Op("=").Lit(3).Comment("глубина заполнения вложенных значений")
+
+	srcFile.Line().Var().Defs(
+		Id("timeType").Op("=").Qual(PackageReflect, "TypeOf").Call(Qual(PackageTime, "Time").Values()),
+		Id("errorType").Op("=").Qual(PackageReflect, "TypeOf").Call(Parens(Op("*").Error()).Call(Nil())).Dot("Elem").Call(),
+		Id("contextType").Op("=").Qual(PackageReflect, "TypeOf").Call(Parens(Op("*").Qual(PackageContext, "Context")).Call(Nil())).Dot("Elem").Call(),
+	)
+
+	r.renderFake(&srcFile)
+	r.renderChecks(&srcFile)
+	r.renderFill(&srcFile)
+
+	return srcFile.Save(path.Join(r.outDir, "conformance.go"))
+}
+
+// renderFake генерирует общую часть fake реализаций контрактов.
+func (r *ConformanceRenderer) renderFake(srcFile *GoFile) {
+
+	srcFile.Line().Comment("Fake - общая часть fake реализаций контрактов: записывает аргументы вызовов, заполняет результаты тестовыми значениями и возвращает заданные ошибки.")
+	srcFile.Type().Id("Fake").Struct(
+		Id("mu").Qual(PackageSync, "Mutex"),
+		Id("args").Map(String()).Index().Any(),
+		Id("results").Map(String()).Index().Any(),
+		Id("errs").Map(String()).Error(),
+	)
+
+	srcFile.Line().Comment("Call записывает аргументы вызова метода, заполняет результаты (указатели) тестовыми значениями и возвращает ошибку, заданную через Fail.")
+	srcFile.Func().Params(Id("f").Op("*").Id("Fake")).Id("Call").Params(Id("method").String(), Id("args").Index().Any(), Id("results").Op("...").Any()).Error().Block(
+		Line().Id("f").Dot("mu").Dot("Lock").Call(),
+		Defer().Id("f").Dot("mu").Dot("Unlock").Call(),
+		If(Id("f").Dot("args").Op("==").Nil()).Block(
+			Id("f").Dot("args").Op("=").Make(Map(String()).Index().Any()),
+			Id("f").Dot("results").Op("=").Make(Map(String()).Index().Any()),
+		),
+		Id("values").Op(":=").Make(Index().Any(), Lit(0), Len(Id("results"))),
+		For(List(Id("i"), Id("result")).Op(":=").Range().Id("results")).Block(
+			Id("value").Op(":=").Qual(PackageReflect, "ValueOf").Call(Id("result")).Dot("Elem").Call(),
+			Id("fill").Call(Id("value"), Lit(100).Op("+").Id("i").Op("*").Lit(10), Lit(0)),
+			Id("values").Op("=").Append(Id("values"), Id("value").Dot("Interface").Call()),
+		),
+		Id("f").Dot("args").Index(Id("method")).Op("=").Id("args"),
+		Id("f").Dot("results").Index(Id("method")).Op("=").Id("values"),
+		Return(Id("f").Dot("errs").Index(Id("method"))),
+	)
+
+	srcFile.Line().Comment("Fail задаёт ошибку, которую вернут вызовы метода (nil - успешный вызов).")
+	srcFile.Func().Params(Id("f").Op("*").Id("Fake")).Id("Fail").Params(Id("method").String(), Err().Error()).Block(
+		Line().Id("f").Dot("mu").Dot("Lock").Call(),
+		Defer().Id("f").Dot("mu").Dot("Unlock").Call(),
+		If(Id("f").Dot("errs").Op("==").Nil()).Block(
+			Id("f").Dot("errs").Op("=").Make(Map(String()).Error()),
+		),
+		Id("f").Dot("errs").Index(Id("method")).Op("=").Err(),
+	)
+
+	srcFile.Line().Comment("take возвращает и забывает аргументы и результаты последнего вызова метода.")
+	srcFile.Func().Params(Id("f").Op("*").Id("Fake")).Id("take").Params(Id("method").String()).Params(Id("args"), Id("results").Index().Any(), Id("found").Bool()).Block(
+		Line().Id("f").Dot("mu").Dot("Lock").Call(),
+		Defer().Id("f").Dot("mu").Dot("Unlock").Call(),
+		If(List(Id("args"), Id("found")).Op("=").Id("f").Dot("args").Index(Id("method")), Op("!").Id("found")).Block(
+			Return(),
+		),
+		Id("results").Op("=").Id("f").Dot("results").Index(Id("method")),
+		Delete(Id("f").Dot("args"), Id("method")),
+		Delete(Id("f").Dot("results"), Id("method")),
+		Return(),
+	)
+}
+
+// renderChecks генерирует проверки вызовов клиента.
+func (r *ConformanceRenderer) renderChecks(srcFile *GoFile) {
+
+	srcFile.Line().Comment("Check вызывает метод клиента fn с тестовыми аргументами и сравнивает аргументы, полученные сервером, и результаты, полученные клиентом, с отправленными.")
+	srcFile.Comment("Сравнение выполняется по JSON представлению, поэтому расхождения тегов, omitempty и сериализаторов клиента и сервера приводят к ошибке теста.")
+	srcFile.Func().Id("Check").Params(Id("t").Qual(PackageTesting, "TB"), Id("fake").Op("*").Id("Fake"), Id("method").String(), Id("fn").Any()).Block(
+		Line().Id("t").Dot("Helper").Call(),
+		Id("fake").Dot("take").Call(Id("method")),
+		List(Id("args"), Id("out")).Op(":=").Id("call").Call(Id("t"), Id("method"), Id("fn")),
+		If(List(Err(), Id("_")).Op(":=").Id("out").Index(Len(Id("out")).Op("-").Lit(1)).Assert(Error()), Err().Op("!=").Nil()).Block(
+			Id("t").Dot("Fatalf").Call(Lit("%s: unexpected error: %v"), Id("method"), Err()),
+		),
+		List(Id("serverArgs"), Id("serverResults"), Id("found")).Op(":=").Id("fake").Dot("take").Call(Id("method")),
+		If(Op("!").Id("found")).Block(
+			Id("t").Dot("Fatalf").Call(Lit("%s: call did not reach the server implementation"), Id("method")),
+		),
+		Id("compare").Call(Id("t"), Id("method").Op("+").Lit(" args"), Id("args"), Id("serverArgs")),
+		Id("compare").Call(Id("t"), Id("method").Op("+").Lit(" results"), Id("serverResults"), Id("out").Index(Empty(), Len(Id("out")).Op("-").Lit(1))),
+	)
+
+	srcFile.Line().Comment("CheckError вызывает метод клиента fn, для которого сервер возвращает объявленную ошибку err, и проверяет код и текст ошибки, полученной клиентом.")
+	srcFile.Func().Id("CheckError").Params(Id("t").Qual(PackageTesting, "TB"), Id("fake").Op("*").Id("Fake"), Id("method").String(), Id("fn").Any(), Err().Error(), Id("code").Int()).Block(
+		Line().Id("t").Dot("Helper").Call(),
+		Id("fake").Dot("Fail").Call(Id("method"), Err()),
+		Defer().Id("fake").Dot("Fail").Call(Id("method"), Nil()),
+		List(Id("_"), Id("out")).Op(":=").Id("call").Call(Id("t"), Id("method"), Id("fn")),
+		List(Id("clientErr"), Id("_")).Op(":=").Id("out").Index(Len(Id("out")).Op("-").Lit(1)).Assert(Error()),
+		If(Id("clientErr").Op("==").Nil()).Block(
+			Id("t").Dot("Fatalf").Call(Lit("%s: expected error %T, got nil"), Id("method"), Err()),
+		),
+		Var().Id("coder").Interface(Id("Code").Params().Int()),
+		If(Op("!").Qual(PackageErrors, "As").Call(Id("clientErr"), Op("&").Id("coder")).Op("||").Id("coder").Dot("Code").Call().Op("!=").Id("code")).Block(
+			Id("t").Dot("Errorf").Call(Lit("%s: expected error with code %d, got %T: %v"), Id("method"), Id("code"), Id("clientErr"), Id("clientErr")),
+		),
+		If(Id("clientErr").Dot("Error").Call().Op("!=").Err().Dot("Error").Call()).Block(
+			Id("t").Dot("Errorf").Call(Lit("%s: error message %q, expected %q"), Id("method"), Id("clientErr").Dot("Error").Call(), Err().Dot("Error").Call()),
+		),
+	)
+
+	srcFile.Line().Comment("Declared возвращает заполненный тестовыми значениями экземпляр ошибки типа T: значение или указатель на него, реализующий error.")
+	srcFile.Func().Id("Declared").Types(Id("T").Any()).Params().Error().Block(
+		Line().Id("value").Op(":=").Qual(PackageReflect, "New").Call(Qual(PackageReflect, "TypeOf").Call(Parens(Op("*").Id("T")).Call(Nil())).Dot("Elem").Call()),
+		Id("fill").Call(Id("value").Dot("Elem").Call(), Lit(1), Lit(0)),
+		If(List(Err(), Id("ok")).Op(":=").Id("value").Dot("Elem").Call().Dot("Interface").Call().Assert(Error()), Id("ok")).Block(
+			Return(Err()),
+		),
+		If(List(Err(), Id("ok")).Op(":=").Id("value").Dot("Interface").Call().Assert(Error()), Id("ok")).Block(
+			Return(Err()),
+		),
+		Panic(Qual(PackageFmt, "Sprintf").Call(Lit("conformance: %s does not implement error"), Id("value").Dot("Elem").Call().Dot("Type").Call())),
+	)
+
+	srcFile.Line().Comment("call вызывает метод клиента fn (context.Context первым аргументом, error последним результатом) с тестовыми аргументами.")
+	srcFile.Comment("Методы с аргументами или результатами интерфейсных, функциональных типов и каналов (потоки, загрузка файлов) пропускаются.")
+	srcFile.Func().Id("call").Params(Id("t").Qual(PackageTesting, "TB"), Id("method").String(), Id("fn").Any()).Params(Id("args"), Id("results").Index().Any()).Block(
+		Line().Id("t").Dot("Helper").Call(),
+		Id("fnValue").Op(":=").Qual(PackageReflect, "ValueOf").Call(Id("fn")),
+		Id("fnType").Op(":=").Id("fnValue").Dot("Type").Call(),
+		If(Id("fnType").Dot("Kind").Call().Op("!=").Qual(PackageReflect, "Func").Op("||").
+			Id("fnType").Dot("NumIn").Call().Op("==").Lit(0).Op("||").Id("fnType").Dot("In").Call(Lit(0)).Op("!=").Id("contextType").Op("||").
+			Id("fnType").Dot("NumOut").Call().Op("==").Lit(0).Op("||").Id("fnType").Dot("Out").Call(Id("fnType").Dot("NumOut").Call().Op("-").Lit(1)).Op("!=").Id("errorType")).Block(
+			Id("t").Dot("Fatalf").Call(Lit("%s: unsupported client method signature %s"), Id("method"), Id("fnType")),
+		),
+		For(Id("i").Op(":=").Lit(0), Id("i").Op("<").Id("fnType").Dot("NumOut").Call().Op("-").Lit(1), Id("i").Op("++")).Block(
+			If(Op("!").Id("supported").Call(Id("fnType").Dot("Out").Call(Id("i")))).Block(
+				Id("t").Dot("Skipf").Call(Lit("%s: result type %s is not supported"), Id("method"), Id("fnType").Dot("Out").Call(Id("i"))),
+			),
+		),
+		// Пустые, а не nil срезы: fake записывает аргументы методов без аргументов как []any{}
+		List(Id("args"), Id("results")).Op("=").List(Index().Any().Values(), Index().Any().Values()),
+		Id("in").Op(":=").Index().Qual(PackageReflect, "Value").Values(Qual(PackageReflect, "ValueOf").Call(Qual(PackageContext, "Background").Call())),
+		For(Id("i").Op(":=").Lit(1), Id("i").Op("<").Id("fnType").Dot("NumIn").Call(), Id("i").Op("++")).Block(
+			If(Op("!").Id("supported").Call(Id("fnType").Dot("In").Call(Id("i")))).Block(
+				Id("t").Dot("Skipf").Call(Lit("%s: argument type %s is not supported"), Id("method"), Id("fnType").Dot("In").Call(Id("i"))),
+			),
+			Id("arg").Op(":=").Qual(PackageReflect, "New").Call(Id("fnType").Dot("In").Call(Id("i"))).Dot("Elem").Call(),
+			Id("fill").Call(Id("arg"), Id("i").Op("*").Lit(10), Lit(0)),
+			Id("in").Op("=").Append(Id("in"), Id("arg")),
+			Id("args").Op("=").Append(Id("args"), Id("arg").Dot("Interface").Call()),
+		),
+		Var().Id("out").Index().Qual(PackageReflect, "Value"),
+		If(Id("fnType").Dot("IsVariadic").Call()).Block(
+			Id("out").Op("=").Id("fnValue").Dot("CallSlice").Call(Id("in")),
+		).Else().Block(
+			Id("out").Op("=").Id("fnValue").Dot("Call").Call(Id("in")),
+		),
+		For(List(Id("_"), Id("value")).Op(":=").Range().Id("out")).Block(
+			Id("results").Op("=").Append(Id("results"), Id("value").Dot("Interface").Call()),
+		),
+		Return(),
+	)
+
+	srcFile.Line().Comment("supported проверяет, что значения типа можно заполнить и сравнить по JSON представлению.")
+	srcFile.Func().Id("supported").Params(Id("typ").Qual(PackageReflect, "Type")).Bool().Block(
+		Line().Switch(Id("typ").Dot("Kind").Call()).Block(
+			Case(Qual(PackageReflect, "Interface"), Qual(PackageReflect, "Func"), Qual(PackageReflect, "Chan"), Qual(PackageReflect, "UnsafePointer")).Block(
+				Return(False()),
+			),
+			Case(Qual(PackageReflect, "Pointer"), Qual(PackageReflect, "Slice"), Qual(PackageReflect, "Array")).Block(
+				Return(Id("supported").Call(Id("typ").Dot("Elem").Call())),
+			),
+			Case(Qual(PackageReflect, "Map")).Block(
+				Return(Id("supported").Call(Id("typ").Dot("Key").Call()).Op("&&").Id("supported").Call(Id("typ").Dot("Elem").Call())),
+			),
+		),
+		Return(True()),
+	)
+
+	srcFile.Line().Comment("compare сравнивает JSON представления отправленных и полученных значений.")
+	srcFile.Func().Id("compare").Params(Id("t").Qual(PackageTesting, "TB"), Id("what").String(), List(Id("sent"), Id("received")).Index().Any()).Block(
+		Line().Id("t").Dot("Helper").Call(),
+		If(List(Id("sentJSON"), Id("receivedJSON")).Op(":=").List(Id("canonical").Call(Id("t"), Id("sent")), Id("canonical").Call(Id("t"), Id("received"))), Id("sentJSON").Op("!=").Id("receivedJSON")).Block(
+			Id("t").Dot("Errorf").Call(Lit("%s mismatch:\n\tsent:     %s\n\treceived: %s"), Id("what"), Id("sentJSON"), Id("receivedJSON")),
+		),
+	)
+
+	srcFile.Line().Comment("canonical возвращает JSON представление значений с упорядоченными ключами объектов.")
+	srcFile.Func().Id("canonical").Params(Id("t").Qual(PackageTesting, "TB"), Id("values").Index().Any()).String().Block(
+		Line().Id("t").Dot("Helper").Call(),
+		List(Id("data"), Err()).Op(":=").Qual(PackageJson, "Marshal").Call(Id("values")),
+		If(Err().Op("!=").Nil()).Block(
+			Id("t").Dot("Fatalf").Call(Lit("marshal %v: %v"), Id("values"), Err()),
+		),
+		Var().Id("decoded").Any(),
+		If(Err().Op("=").Qual(PackageJson, "Unmarshal").Call(Id("data"), Op("&").Id("decoded")), Err().Op("!=").Nil()).Block(
+			Id("t").Dot("Fatalf").Call(Lit("unmarshal %s: %v"), Id("data"), Err()),
+		),
+		List(Id("data"), Id("_")).Op("=").Qual(PackageJson, "Marshal").Call(Id("decoded")),
+		Return(String().Call(Id("data"))),
+	)
+}
+
+// renderFill генерирует заполнение значений детерминированными тестовыми данными.
+func (r *ConformanceRenderer) renderFill(srcFile *GoFile) {
+
+	srcFile.Line().Comment("fill заполняет значение детерминированными тестовыми данными, зависящими от seed. Интерфейсы и неэкспортируемые поля остаются нулевыми.")
+	srcFile.Func().Id("fill").Params(Id("value").Qual(PackageReflect, "Value"), List(Id("seed"), Id("depth")).Int()).Block(
+		Line().If(Id("depth").Op(">").Id("maxDepth")).Block(
+			Return(),
+		),
+		If(Id("value").Dot("Type").Call().Op("==").Id("timeType")).Block(
+			Id("value").Dot("Set").Call(Qual(PackageReflect, "ValueOf").Call(
+				Qual(PackageTime, "Date").Call(Lit(2024), Qual(PackageTime, "January"), Lit(2), Lit(3), Lit(4), Lit(5), Lit(0), Qual(PackageTime, "UTC")).
+					Dot("Add").Call(Qual(PackageTime, "Duration").Call(Id("seed")).Op("*").Qual(PackageTime, "Minute")),
+			)),
+			Return(),
+		),
+		Switch(Id("value").Dot("Kind").Call()).Block(
+			Case(Qual(PackageReflect, "Bool")).Block(
+				Id("value").Dot("SetBool").Call(True()),
+			),
+			Case(Qual(PackageReflect, "Int"), Qual(PackageReflect, "Int8"), Qual(PackageReflect, "Int16"), Qual(PackageReflect, "Int32"), Qual(PackageReflect, "Int64")).Block(
+				Id("value").Dot("SetInt").Call(Int64().Call(Id("seed").Op("%").Lit(100).Op("+").Lit(1))),
+			),
+			Case(Qual(PackageReflect, "Uint"), Qual(PackageReflect, "Uint8"), Qual(PackageReflect, "Uint16"), Qual(PackageReflect, "Uint32"), Qual(PackageReflect, "Uint64")).Block(
+				Id("value").Dot("SetUint").Call(Uint64().Call(Id("seed").Op("%").Lit(100).Op("+").Lit(1))),
+			),
+			Case(Qual(PackageReflect, "Float32"), Qual(PackageReflect, "Float64")).Block(
+				Id("value").Dot("SetFloat").Call(Float64().Call(Id("seed")).Op("+").Lit(0.5)),
+			),
+			Case(Qual(PackageReflect, "String")).Block(
+				Id("value").Dot("SetString").Call(Qual(PackageFmt, "Sprintf").Call(Lit("v%d"), Id("seed"))),
+			),
+			Case(Qual(PackageReflect, "Pointer")).Block(
+				Id("elem").Op(":=").Qual(PackageReflect, "New").Call(Id("value").Dot("Type").Call().Dot("Elem").Call()),
+				Id("fill").Call(Id("elem").Dot("Elem").Call(), Id("seed"), Id("depth").Op("+").Lit(1)),
+				Id("value").Dot("Set").Call(Id("elem")),
+			),
+			Case(Qual(PackageReflect, "Slice")).Block(
+				Id("items").Op(":=").Qual(PackageReflect, "MakeSlice").Call(Id("value").Dot("Type").Call(), Lit(2), Lit(2)),
+				For(Id("i").Op(":=").Lit(0), Id("i").Op("<").Id("items").Dot("Len").Call(), Id("i").Op("++")).Block(
+					Id("fill").Call(Id("items").Dot("Index").Call(Id("i")), Id("seed").Op("+").Id("i").Op("+").Lit(1), Id("depth").Op("+").Lit(1)),
+				),
+				Id("value").Dot("Set").Call(Id("items")),
+			),
+			Case(Qual(PackageReflect, "Array")).Block(
+				For(Id("i").Op(":=").Lit(0), Id("i").Op("<").Id("value").Dot("Len").Call(), Id("i").Op("++")).Block(
+					Id("fill").Call(Id("value").Dot("Index").Call(Id("i")), Id("seed").Op("+").Id("i").Op("+").Lit(1), Id("depth").Op("+").Lit(1)),
+				),
+			),
+			Case(Qual(PackageReflect, "Map")).Block(
+				Id("key").Op(":=").Qual(PackageReflect, "New").Call(Id("value").Dot("Type").Call().Dot("Key").Call()).Dot("Elem").Call(),
+				Id("fill").Call(Id("key"), Id("seed").Op("+").Lit(1), Id("depth").Op("+").Lit(1)),
+				Id("item").Op(":=").Qual(PackageReflect, "New").Call(Id("value").Dot("Type").Call().Dot("Elem").Call()).Dot("Elem").Call(),
+				Id("fill").Call(Id("item"), Id("seed").Op("+").Lit(2), Id("depth").Op("+").Lit(1)),
+				Id("items").Op(":=").Qual(PackageReflect, "MakeMap").Call(Id("value").Dot("Type").Call()),
+				Id("items").Dot("SetMapIndex").Call(Id("key"), Id("item")),
+				Id("value").Dot("Set").Call(Id("items")),
+			),
+			Case(Qual(PackageReflect, "Struct")).Block(
+				For(Id("i").Op(":=").Lit(0), Id("i").Op("<").Id("value").Dot("NumField").Call(), Id("i").Op("++")).Block(
+					If(Id("field").Op(":=").Id("value").Dot("Field").Call(Id("i")), Id("field").Dot("CanSet").Call()).Block(
+						Id("fill").Call(Id("field"), Id("seed").Op("+").Id("i").Op("+").Lit(1), Id("depth").Op("+").Lit(1)),
+					),
+				),
+			),
+		),
+	)
+}
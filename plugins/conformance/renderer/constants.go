@@ -0,0 +1,28 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+const DoNotEdit = "GENERATED BY 'T'ool 'G'ateway. DO NOT EDIT."
+
+// Package paths
+const (
+	PackageFmt     = "fmt"
+	PackageSync    = "sync"
+	PackageTime    = "time"
+	PackageJson    = "encoding/json"
+	PackageErrors  = "errors"
+	PackageReflect = "reflect"
+	PackageContext = "context"
+	PackageTesting = "testing"
+)
+
+const (
+	TagServerJsonRPC = "jsonRPC-server"
+	TagServerHTTP    = "http-server"
+	TagMethodHTTP    = "http-method"
+)
+
+const (
+	typeIDContext = "context:Context"
+	typeIDError   = "error"
+)
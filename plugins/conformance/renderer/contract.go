@@ -0,0 +1,219 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/core"
+	"tgp/internal/parser"
+	"tgp/plugins/server/renderer/types"
+)
+
+// reservedNames - имена, занятые в сгенерированных методах fake реализации.
+var reservedNames = map[string]bool{"f": true}
+
+// fakeVar - аргумент или результат метода с именем, пригодным для генерации.
+type fakeVar struct {
+	name string
+	*parser.Variable
+}
+
+// RenderContract генерирует fake реализацию и тесты соответствия методов контракта, доступных в клиенте.
+func (r *ConformanceRenderer) RenderContract(contract *parser.Contract) error {
+
+	if !contract.Annotations.IsSet(TagServerJsonRPC) && !contract.Annotations.IsSet(TagServerHTTP) {
+		return nil
+	}
+	var methods []*parser.Method
+	for _, method := range contract.Methods {
+		if r.methodInClient(contract, method) {
+			methods = append(methods, method)
+		}
+	}
+	if len(methods) == 0 {
+		return nil
+	}
+	transportOption, ok := r.transportOption(contract)
+	if !ok {
+		core.GetLogger().Warn(fmt.Sprintf("conformance tests skipped: transport has no option for contract %s", contract.Name))
+		return nil
+	}
+
+	pkgName := strings.ToLower(contract.Name)
+	srcFile := NewSrcFile(pkgName + "_test")
+	srcFile.PackageComment(DoNotEdit)
+
+	conformancePkg := r.pkgPath(r.outDir)
+	transportPkg := r.pkgPath(r.transportDir)
+	clientPkg := r.pkgPath(r.clientDir)
+
+	srcFile.ImportAlias(PackageTesting, "gotesting")
+	srcFile.ImportName(conformancePkg, filepath.Base(r.outDir))
+	srcFile.ImportName(transportPkg, filepath.Base(r.transportDir))
+	srcFile.ImportName(transportPkg+"/testing", "testing")
+	srcFile.ImportName(clientPkg, filepath.Base(r.clientDir))
+	srcFile.ImportName(contract.PkgPath, filepath.Base(contract.PkgPath))
+
+	typeGen := types.NewGenerator(r.project, &srcFile)
+	fakeName := "fake" + contract.Name
+
+	srcFile.Line().Commentf("%s - реализация контракта %s, записывающая аргументы вызовов и возвращающая тестовые результаты.", fakeName, contract.Name)
+	srcFile.Type().Id(fakeName).Struct(Id("fake").Qual(conformancePkg, "Fake"))
+
+	srcFile.Line().Var().Id("_").Qual(contract.PkgPath, contract.Name).Op("=").Parens(Op("*").Id(fakeName)).Call(Nil())
+
+	for _, method := range contract.Methods {
+		srcFile.Line().Add(r.fakeMethod(typeGen, fakeName, method))
+	}
+
+	newName := "new" + contract.Name
+	srcFile.Line().Commentf("%s запускает транспорт с fake реализацией %s и возвращает её вместе с клиентом контракта.", newName, contract.Name)
+	srcFile.Func().Id(newName).Params(Id("t").Op("*").Qual(PackageTesting, "T")).Params(Op("*").Id(fakeName), Op("*").Qual(clientPkg, "Client"+contract.Name)).Block(
+		Line().Id("f").Op(":=").Op("&").Id(fakeName).Values(),
+		Id("srv").Op(":=").Qual(transportPkg+"/testing", "New").Call(Id("t"), Qual(transportPkg, transportOption).Call(Id("f"))),
+		Return(Id("f"), Qual(clientPkg, "New").Call(Id("srv").Dot("URL").Call(), Qual(clientPkg, "ClientHTTP").Call(Id("srv").Dot("Client").Call())).Dot(contract.Name).Call()),
+	)
+
+	for _, method := range methods {
+		r.renderMethodTest(&srcFile, contract, method)
+	}
+
+	return srcFile.Save(path.Join(r.outDir, pkgName, pkgName+"_test.go"))
+}
+
+// renderMethodTest генерирует тест метода: успешный вызов и вызовы с объявленными ошибками.
+func (r *ConformanceRenderer) renderMethodTest(srcFile *GoFile, contract *parser.Contract, method *parser.Method) {
+
+	transport := "JSON-RPC"
+	if method.Annotations.IsSet(TagMethodHTTP) {
+		transport = "HTTP " + strings.ToUpper(method.Annotations.Value(TagMethodHTTP))
+	}
+	testName := "Test" + contract.Name + method.Name
+
+	srcFile.Line().Commentf("%s проверяет соответствие аргументов, результатов и объявленных ошибок %s.%s клиента и сервера (%s).", testName, contract.Name, method.Name, transport)
+	srcFile.Func().Id(testName).Params(Id("t").Op("*").Qual(PackageTesting, "T")).BlockFunc(func(bg *Group) {
+		bg.Line()
+		bg.List(Id("f"), Id("cli")).Op(":=").Id("new" + contract.Name).Call(Id("t"))
+		bg.Qual(r.pkgPath(r.outDir), "Check").Call(Id("t"), Op("&").Id("f").Dot("fake"), Lit(method.Name), Id("cli").Dot(method.Name))
+		for _, errInfo := range declaredErrors(method) {
+			bg.Id("t").Dot("Run").Call(Lit(errInfo.TypeName), Func().Params(Id("t").Op("*").Qual(PackageTesting, "T")).Block(
+				Qual(r.pkgPath(r.outDir), "CheckError").Call(
+					Id("t"),
+					Op("&").Id("f").Dot("fake"),
+					Lit(method.Name),
+					Id("cli").Dot(method.Name),
+					Qual(r.pkgPath(r.outDir), "Declared").Types(Qual(errInfo.PkgPath, errInfo.TypeName)).Call(),
+					Lit(errInfo.HTTPCode),
+				),
+			))
+		}
+	})
+}
+
+// fakeMethod генерирует метод fake реализации, передающий аргументы и указатели на результаты в conformance.Fake.
+func (r *ConformanceRenderer) fakeMethod(typeGen *types.Generator, fakeName string, method *parser.Method) Code {
+
+	args := fakeVars(method.Args, "arg")
+	results := fakeVars(method.Results, "result")
+
+	return Func().Params(Id("f").Op("*").Id(fakeName)).Id(method.Name).
+		Params(r.params(typeGen, args, true)).
+		Params(r.params(typeGen, results, false)).
+		BlockFunc(func(bg *Group) {
+			callArgs := []Code{
+				Lit(method.Name),
+				Index().Any().ValuesFunc(func(vg *Group) {
+					for _, arg := range args {
+						if arg.TypeID != typeIDContext {
+							vg.Id(arg.name)
+						}
+					}
+				}),
+			}
+			errResult := Id("_")
+			for _, result := range results {
+				if result.TypeID == typeIDError {
+					errResult = Id(result.name)
+					continue
+				}
+				callArgs = append(callArgs, Op("&").Id(result.name))
+			}
+			bg.Add(errResult).Op("=").Id("f").Dot("fake").Dot("Call").Call(callArgs...)
+			bg.Return()
+		})
+}
+
+// params генерирует список именованных параметров.
+func (r *ConformanceRenderer) params(typeGen *types.Generator, vars []fakeVar, allowEllipsis bool) *Statement {
+
+	return ListFunc(func(lg *Group) {
+		for _, v := range vars {
+			lg.Id(v.name).Add(typeGen.FieldTypeFromVariable(v.Variable, allowEllipsis))
+		}
+	})
+}
+
+// transportOption возвращает имя опции транспорта, регистрирующей реализацию контракта (как в опциях сервера):
+// опция контракта для JSON-RPC контрактов, HTTPService для первого контракта с http-server.
+func (r *ConformanceRenderer) transportOption(contract *parser.Contract) (name string, ok bool) {
+
+	if contract.Annotations.Contains(TagServerJsonRPC) {
+		return contract.Name, true
+	}
+	for _, c := range r.project.Contracts {
+		if c.Annotations.Contains(TagServerHTTP) {
+			return "HTTPService", c == contract
+		}
+	}
+	return "", false
+}
+
+// methodInClient проверяет, что Go клиент генерирует метод: JSON-RPC метод контракта с jsonRPC-server или метод с http-method.
+// Как и в клиенте, методы без аннотаций не генерируются.
+func (r *ConformanceRenderer) methodInClient(contract *parser.Contract, method *parser.Method) bool {
+
+	if method == nil || method.Annotations == nil {
+		return false
+	}
+	if method.Annotations.IsSet(TagMethodHTTP) {
+		return true
+	}
+	return contract.Annotations.IsSet(TagServerJsonRPC)
+}
+
+// declaredErrors возвращает объявленные ошибки метода с HTTP кодом, которые клиент декодирует в типизированные ошибки.
+func declaredErrors(method *parser.Method) (errs []*parser.ErrorInfo) {
+
+	seen := make(map[string]bool)
+	for _, errInfo := range method.Errors {
+		if errInfo == nil || errInfo.HTTPCode == 0 || errInfo.PkgPath == "" || seen[errInfo.FullName] {
+			continue
+		}
+		seen[errInfo.FullName] = true
+		errs = append(errs, errInfo)
+	}
+	return
+}
+
+// fakeVars назначает переменным имена, не конфликтующие с именами в сгенерированном коде.
+func fakeVars(vars []*parser.Variable, prefix string) []fakeVar {
+
+	result := make([]fakeVar, 0, len(vars))
+	for i, v := range vars {
+		name := v.Name
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("%s%d", prefix, i)
+		}
+		if reservedNames[name] {
+			name += "Value"
+		}
+		result = append(result, fakeVar{name: name, Variable: v})
+	}
+	return result
+}
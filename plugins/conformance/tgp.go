@@ -0,0 +1,5 @@
+package main
+
+//go:generate env GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o ../../dist/conformance.tgp .
+//go:generate sh -c "shasum -a 256 ../../dist/conformance.tgp | cut -c 1-64 > ../../dist/conformance.sha256"
+//go:generate sh -c "cp plugin.json ../../dist/conformance.json"
@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"tgp/core"
+	"tgp/internal/parser"
+	"tgp/plugins/mock/renderer"
+)
+
+// DeserializeProject десериализует Project из JSON.
+func DeserializeProject(projectData interface{}) (*parser.Project, error) {
+
+	projectBytes, err := json.Marshal(projectData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal project data: %w", err)
+	}
+
+	var project parser.Project
+	if err := json.Unmarshal(projectBytes, &project); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal project: %w", err)
+	}
+
+	return &project, nil
+}
+
+// GenerateMocks генерирует моки для контрактов проекта (всех или указанных по имени/ID).
+func GenerateMocks(project *parser.Project, outDir string, contracts ...string) error {
+
+	if project == nil {
+		return fmt.Errorf("project cannot be nil")
+	}
+	if outDir == "" {
+		return fmt.Errorf("outDir cannot be empty")
+	}
+
+	logger := core.GetLogger()
+	mockRenderer := renderer.NewMockRenderer(project, outDir)
+
+	if err := mockRenderer.RenderBase(); err != nil {
+		return fmt.Errorf("render mock base: %w", err)
+	}
+	for _, contract := range project.Contracts {
+		if !contractSelected(contract, contracts) {
+			continue
+		}
+		logger.Info(fmt.Sprintf("generating mock for contract: contract=%s", contract.ID))
+		if err := mockRenderer.RenderContract(contract); err != nil {
+			return fmt.Errorf("render mock %s: %w", contract.Name, err)
+		}
+	}
+	return nil
+}
+
+// contractSelected проверяет, попадает ли контракт в фильтр.
+func contractSelected(contract *parser.Contract, contracts []string) bool {
+
+	if len(contracts) == 0 {
+		return true
+	}
+	for _, name := range contracts {
+		if contract.Name == name || contract.ID == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"tgp/core"
+	"tgp/internal/cleanup"
+	"tgp/plugins/mock/generator"
+)
+
+//go:embed plugin.md
+var pluginDoc string
+
+// MockPlugin реализует интерфейс Plugin.
+type MockPlugin struct{}
+
+// Info возвращает информацию о плагине.
+func (p *MockPlugin) Info() core.PluginInfo {
+	return core.PluginInfo{
+		Name:         "mock",
+		Version:      "2.4.0",
+		Doc:          pluginDoc,
+		Description:  translate("Typed mock generator for contracts"),
+		Author:       "AlexK (seniorGolang@gmail.com)",
+		License:      "MIT",
+		Category:     "testing",
+		Dependencies: []string{"astg"},
+		Commands: []core.Command{
+			{
+				Path:        []string{"mock"},
+				Description: translate("Generate contract mocks"),
+				Options: []core.Option{
+					{
+						Name:        "out",
+						Short:       "o",
+						Type:        "string",
+						Description: translate("Path to output directory"),
+						Required:    true,
+					},
+					{
+						Name:        "ifaces",
+						Type:        "string",
+						Description: translate("Comma-separated list of interfaces for filtering (e.g., \"Contract1,Contract2\")"),
+						Required:    false,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Execute выполняет основную логику плагина.
+func (p *MockPlugin) Execute(rootDir string, request core.Storage, path ...string) (response core.Storage, err error) {
+
+	logger := core.GetLogger()
+
+	logger.Info(translate("mock plugin started"))
+
+	projectVal, ok := request.Get("project")
+	if !ok {
+		return nil, fmt.Errorf("project is required in request")
+	}
+
+	coreProject, err := generator.DeserializeProject(projectVal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize project: %w", err)
+	}
+
+	outDirVal, ok := request.Get("out")
+	if !ok {
+		return nil, fmt.Errorf("out option is required")
+	}
+	outDir, ok := outDirVal.(string)
+	if !ok || outDir == "" {
+		return nil, fmt.Errorf("out option is required and must be a string")
+	}
+
+	// В WASM файловая система монтируется в корень "/", поэтому используем относительные пути
+	if filepath.IsAbs(outDir) {
+		relPath, err := filepath.Rel(rootDir, outDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute relative path from rootDir: %w", err)
+		}
+		outDir = relPath
+	}
+
+	var ifaces []string
+	if ifacesVal, ok := request.Get("ifaces"); ok {
+		if ifacesStr, ok := ifacesVal.(string); ok && ifacesStr != "" {
+			for _, part := range strings.FieldsFunc(ifacesStr, func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			}) {
+				if part = strings.TrimSpace(part); part != "" {
+					ifaces = append(ifaces, part)
+				}
+			}
+		}
+	}
+
+	// Очищаем старые сгенерированные моки перед новой генерацией
+	if err := cleanup.CleanupGeneratedFiles(outDir); err != nil {
+		logger.Warn(fmt.Sprintf("failed to cleanup generated files: error=%v", err))
+	}
+
+	logger.Info(fmt.Sprintf("generating mocks: outDir=%s ifaces=%v", outDir, ifaces))
+	if err = generator.GenerateMocks(coreProject, outDir, ifaces...); err != nil {
+		logger.Error(fmt.Sprintf("failed to generate mocks: outDir=%s error=%v", outDir, err))
+		return nil, err
+	}
+
+	logger.Info(translate("mock plugin completed"))
+
+	response = core.NewStorage()
+	if err = response.Set("outDir", outDir); err != nil {
+		return nil, fmt.Errorf("failed to set response: %w", err)
+	}
+
+	return response, nil
+}
+
+// pluginInstance - экземпляр плагина для регистрации.
+var pluginInstance core.Plugin = &MockPlugin{}
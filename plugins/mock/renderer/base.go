@@ -0,0 +1,235 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+)
+
+// MockRenderer генерирует моки контрактов.
+type MockRenderer struct {
+	project *parser.Project
+	outDir  string
+}
+
+// NewMockRenderer создает новый рендерер моков.
+func NewMockRenderer(project *parser.Project, outDir string) *MockRenderer {
+	return &MockRenderer{
+		project: project,
+		outDir:  outDir,
+	}
+}
+
+// RenderBase генерирует общий для всех моков файл: матчеры аргументов, запись вызовов и ожидания.
+func (r *MockRenderer) RenderBase() error {
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageFmt, "fmt")
+	srcFile.ImportName(PackageSync, "sync")
+	srcFile.ImportName(PackageReflect, "reflect")
+	srcFile.ImportName(PackageErrors, "errors")
+
+	srcFile.Line().Comment("ErrNotImplemented возвращается Fake реализацией, если функция метода не задана.")
+	srcFile.Var().Id("ErrNotImplemented").Op("=").Qual(PackageErrors, "New").Call(Lit("method not implemented"))
+
+	srcFile.Line().Comment("TB - часть testing.TB, используемая моками.")
+	srcFile.Type().Id("TB").Interface(
+		Id("Helper").Params(),
+		Id("Errorf").Params(Id("format").String(), Id("args").Op("...").Any()),
+		Id("Cleanup").Params(Func().Params()),
+	)
+
+	srcFile.Line().Comment("Call - записанный вызов метода мока (аргументы без context.Context).")
+	srcFile.Type().Id("Call").Struct(
+		Id("Method").String(),
+		Id("Args").Index().Any(),
+	)
+
+	r.renderMatchers(&srcFile)
+	r.renderExpectation(&srcFile)
+	r.renderMock(&srcFile)
+
+	return srcFile.Save(path.Join(r.outDir, "mock.go"))
+}
+
+// renderMatchers генерирует матчеры аргументов.
+func (r *MockRenderer) renderMatchers(srcFile *GoFile) {
+
+	srcFile.Line().Comment("Matcher проверяет аргумент вызова. Аргументы ожидания, не являющиеся Matcher, сравниваются через Eq.")
+	srcFile.Type().Id("Matcher").Interface(
+		Id("Match").Params(Id("value").Any()).Bool(),
+		Id("String").Params().String(),
+	)
+
+	srcFile.Line().Type().Id("anyMatcher").Struct()
+	srcFile.Line().Func().Params(Id("anyMatcher")).Id("Match").Params(Any()).Bool().Block(Return(True()))
+	srcFile.Func().Params(Id("anyMatcher")).Id("String").Params().String().Block(Return(Lit("Any()")))
+
+	srcFile.Line().Comment("Any совпадает с любым значением аргумента.")
+	srcFile.Func().Id("Any").Params().Id("Matcher").Block(
+		Return(Id("anyMatcher").Values()),
+	)
+
+	srcFile.Line().Type().Id("eqMatcher").Struct(Id("expected").Any())
+	srcFile.Line().Func().Params(Id("m").Id("eqMatcher")).Id("Match").Params(Id("value").Any()).Bool().Block(
+		Return(Qual(PackageReflect, "DeepEqual").Call(Id("m").Dot("expected"), Id("value"))),
+	)
+	srcFile.Func().Params(Id("m").Id("eqMatcher")).Id("String").Params().String().Block(
+		Return(Qual(PackageFmt, "Sprintf").Call(Lit("Eq(%#v)"), Id("m").Dot("expected"))),
+	)
+
+	srcFile.Line().Comment("Eq совпадает со значением, равным expected (reflect.DeepEqual).")
+	srcFile.Func().Id("Eq").Params(Id("expected").Any()).Id("Matcher").Block(
+		Return(Id("eqMatcher").Values(Dict{Id("expected"): Id("expected")})),
+	)
+
+	srcFile.Line().Type().Id("funcMatcher").Types(Id("T").Any()).Struct(Id("fn").Func().Params(Id("T")).Bool())
+	srcFile.Line().Func().Params(Id("m").Id("funcMatcher").Types(Id("T"))).Id("Match").Params(Id("value").Any()).Bool().Block(
+		List(Id("typed"), Id("ok")).Op(":=").Id("value").Assert(Id("T")),
+		Return(Id("ok").Op("&&").Id("m").Dot("fn").Call(Id("typed"))),
+	)
+	srcFile.Func().Params(Id("m").Id("funcMatcher").Types(Id("T"))).Id("String").Params().String().Block(
+		Return(Lit("MatchFunc()")),
+	)
+
+	srcFile.Line().Comment("MatchFunc совпадает со значением типа T, для которого fn возвращает true.")
+	srcFile.Func().Id("MatchFunc").Types(Id("T").Any()).Params(Id("fn").Func().Params(Id("value").Id("T")).Bool()).Id("Matcher").Block(
+		Return(Id("funcMatcher").Types(Id("T")).Values(Dict{Id("fn"): Id("fn")})),
+	)
+}
+
+// renderExpectation генерирует общую часть ожидания вызова.
+func (r *MockRenderer) renderExpectation(srcFile *GoFile) {
+
+	srcFile.Line().Comment("expectation - общая часть ожидания вызова метода.")
+	srcFile.Type().Id("expectation").Struct(
+		Id("method").String(),
+		Id("args").Index().Any(),
+		Id("times").Int().Comment("0 - без ограничения количества вызовов"),
+		Id("calls").Int(),
+		Id("call").Any().Comment("типизированное ожидание метода"),
+	)
+
+	srcFile.Line().Func().Params(Id("e").Op("*").Id("expectation")).Id("match").Params(Id("method").String(), Id("args").Index().Any()).Bool().Block(
+		Line().If(Id("e").Dot("method").Op("!=").Id("method").Op("||").Len(Id("e").Dot("args")).Op("!=").Len(Id("args"))).Block(
+			Return(False()),
+		),
+		If(Id("e").Dot("times").Op(">").Lit(0).Op("&&").Id("e").Dot("calls").Op(">=").Id("e").Dot("times")).Block(
+			Return(False()),
+		),
+		For(List(Id("i"), Id("expected")).Op(":=").Range().Id("e").Dot("args")).Block(
+			List(Id("matcher"), Id("ok")).Op(":=").Id("expected").Assert(Id("Matcher")),
+			If(Op("!").Id("ok")).Block(
+				Id("matcher").Op("=").Id("Eq").Call(Id("expected")),
+			),
+			If(Op("!").Id("matcher").Dot("Match").Call(Id("args").Index(Id("i")))).Block(
+				Return(False()),
+			),
+		),
+		Return(True()),
+	)
+
+	srcFile.Line().Func().Params(Id("e").Op("*").Id("expectation")).Id("satisfied").Params().Bool().Block(
+		Line().If(Id("e").Dot("times").Op(">").Lit(0)).Block(
+			Return(Id("e").Dot("calls").Op(">=").Id("e").Dot("times")),
+		),
+		Return(Id("e").Dot("calls").Op(">").Lit(0)),
+	)
+}
+
+// renderMock генерирует общую часть моков: регистрацию ожиданий, запись вызовов и проверку.
+func (r *MockRenderer) renderMock(srcFile *GoFile) {
+
+	srcFile.Line().Comment("mock - общая часть моков контрактов.")
+	srcFile.Type().Id("mock").Struct(
+		Id("mu").Qual(PackageSync, "Mutex"),
+		Id("t").Id("TB"),
+		Id("calls").Index().Id("Call"),
+		Id("expectations").Index().Op("*").Id("expectation"),
+	)
+
+	srcFile.Line().Func().Params(Id("m").Op("*").Id("mock")).Id("init").Params(Id("t").Id("TB")).Block(
+		Line().Id("m").Dot("t").Op("=").Id("t"),
+		If(Id("t").Op("!=").Nil()).Block(
+			Id("t").Dot("Cleanup").Call(Id("m").Dot("AssertExpectations")),
+		),
+	)
+
+	srcFile.Line().Func().Params(Id("m").Op("*").Id("mock")).Id("expect").Params(Id("method").String(), Id("args").Index().Any(), Id("call").Any()).Op("*").Id("expectation").Block(
+		Line().Id("m").Dot("mu").Dot("Lock").Call(),
+		Defer().Id("m").Dot("mu").Dot("Unlock").Call(),
+		Id("e").Op(":=").Op("&").Id("expectation").Values(Dict{
+			Id("method"): Id("method"),
+			Id("args"):   Id("args"),
+			Id("call"):   Id("call"),
+		}),
+		Id("m").Dot("expectations").Op("=").Append(Id("m").Dot("expectations"), Id("e")),
+		Return(Id("e")),
+	)
+
+	srcFile.Line().Comment("called записывает вызов и возвращает первое подходящее ожидание или nil.")
+	srcFile.Func().Params(Id("m").Op("*").Id("mock")).Id("called").Params(Id("method").String(), Id("args").Index().Any()).Any().Block(
+		Line().Id("m").Dot("mu").Dot("Lock").Call(),
+		Id("m").Dot("calls").Op("=").Append(Id("m").Dot("calls"), Id("Call").Values(Dict{Id("Method"): Id("method"), Id("Args"): Id("args")})),
+		For(List(Id("_"), Id("e")).Op(":=").Range().Id("m").Dot("expectations")).Block(
+			If(Id("e").Dot("match").Call(Id("method"), Id("args"))).Block(
+				Id("e").Dot("calls").Op("++"),
+				Id("m").Dot("mu").Dot("Unlock").Call(),
+				Return(Id("e").Dot("call")),
+			),
+		),
+		Id("m").Dot("mu").Dot("Unlock").Call(),
+		If(Id("m").Dot("t").Op("==").Nil()).Block(
+			Panic(Qual(PackageFmt, "Sprintf").Call(Lit("mock: unexpected call %s%v"), Id("method"), Id("args"))),
+		),
+		Id("m").Dot("t").Dot("Helper").Call(),
+		Id("m").Dot("t").Dot("Errorf").Call(Lit("mock: unexpected call %s%v"), Id("method"), Id("args")),
+		Return(Nil()),
+	)
+
+	srcFile.Line().Comment("Calls возвращает все записанные вызовы в порядке поступления.")
+	srcFile.Func().Params(Id("m").Op("*").Id("mock")).Id("Calls").Params().Index().Id("Call").Block(
+		Line().Id("m").Dot("mu").Dot("Lock").Call(),
+		Defer().Id("m").Dot("mu").Dot("Unlock").Call(),
+		Return(Append(Index().Id("Call").Call(Nil()), Id("m").Dot("calls").Op("..."))),
+	)
+
+	srcFile.Line().Comment("CallsOf возвращает записанные вызовы указанного метода.")
+	srcFile.Func().Params(Id("m").Op("*").Id("mock")).Id("CallsOf").Params(Id("method").String()).Params(Id("calls").Index().Id("Call")).Block(
+		Line().For(List(Id("_"), Id("call")).Op(":=").Range().Id("m").Dot("Calls").Call()).Block(
+			If(Id("call").Dot("Method").Op("==").Id("method")).Block(
+				Id("calls").Op("=").Append(Id("calls"), Id("call")),
+			),
+		),
+		Return(),
+	)
+
+	srcFile.Line().Comment("AssertExpectations проверяет, что все ожидания вызваны нужное количество раз. Вызывается автоматически при завершении теста.")
+	srcFile.Func().Params(Id("m").Op("*").Id("mock")).Id("AssertExpectations").Params().Block(
+		Line().If(Id("m").Dot("t").Op("==").Nil()).Block(
+			Return(),
+		),
+		Id("m").Dot("t").Dot("Helper").Call(),
+		Id("m").Dot("mu").Dot("Lock").Call(),
+		Defer().Id("m").Dot("mu").Dot("Unlock").Call(),
+		For(List(Id("_"), Id("e")).Op(":=").Range().Id("m").Dot("expectations")).Block(
+			If(Op("!").Id("e").Dot("satisfied").Call()).Block(
+				Id("m").Dot("t").Dot("Errorf").Call(Lit("mock: expected call %s%v: called %d time(s), expected %s"), Id("e").Dot("method"), Id("e").Dot("args"), Id("e").Dot("calls"), Id("expectedTimes").Call(Id("e").Dot("times"))),
+			),
+		),
+	)
+
+	srcFile.Line().Func().Id("expectedTimes").Params(Id("times").Int()).String().Block(
+		Line().If(Id("times").Op("==").Lit(0)).Block(
+			Return(Lit("at least once")),
+		),
+		Return(Qual(PackageFmt, "Sprint").Call(Id("times"))),
+	)
+}
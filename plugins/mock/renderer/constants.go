@@ -0,0 +1,18 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+const DoNotEdit = "GENERATED BY 'T'ool 'G'ateway. DO NOT EDIT."
+
+// Package paths
+const (
+	PackageFmt     = "fmt"
+	PackageSync    = "sync"
+	PackageReflect = "reflect"
+	PackageErrors  = "errors"
+)
+
+const (
+	typeIDContext = "context:Context"
+	typeIDError   = "error"
+)
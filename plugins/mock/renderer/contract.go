@@ -0,0 +1,253 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+	"tgp/plugins/server/renderer/types"
+)
+
+// reservedNames - имена, занятые в сгенерированных методах мока.
+var reservedNames = map[string]bool{"m": true, "f": true, "c": true, "call": true, "ok": true, "fn": true, "n": true}
+
+// mockVar - аргумент или результат метода с именем, пригодным для генерации.
+type mockVar struct {
+	name string
+	*parser.Variable
+}
+
+// RenderContract генерирует мок и Fake реализацию контракта.
+func (r *MockRenderer) RenderContract(contract *parser.Contract) error {
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageFmt, "fmt")
+	srcFile.ImportName(contract.PkgPath, filepath.Base(contract.PkgPath))
+
+	typeGen := types.NewGenerator(r.project, &srcFile)
+
+	srcFile.Line().Commentf("%s - мок контракта %s с ожиданиями вызовов и записью вызовов.", contract.Name, contract.Name)
+	srcFile.Type().Id(contract.Name).Struct(Id("mock"))
+
+	srcFile.Line().Var().Id("_").Qual(contract.PkgPath, contract.Name).Op("=").Parens(Op("*").Id(contract.Name)).Call(Nil())
+
+	srcFile.Line().Commentf("New%s создаёт мок контракта %s. Если t не nil, ожидания проверяются при завершении теста.", contract.Name, contract.Name)
+	srcFile.Func().Id("New"+contract.Name).Params(Id("t").Id("TB")).Params(Id("m").Op("*").Id(contract.Name)).Block(
+		Line().Id("m").Op("=").Op("&").Id(contract.Name).Values(),
+		Id("m").Dot("init").Call(Id("t")),
+		Return(),
+	)
+
+	for _, method := range contract.Methods {
+		r.renderMethodMock(&srcFile, typeGen, contract, method)
+	}
+
+	srcFile.Line().Commentf("Fake%s - реализация контракта %s на пользовательских функциях.", contract.Name, contract.Name)
+	srcFile.Type().Id("Fake" + contract.Name).StructFunc(func(sg *Group) {
+		for _, method := range contract.Methods {
+			sg.Id(method.Name + "Func").Add(r.funcType(typeGen, method))
+		}
+	})
+
+	srcFile.Line().Var().Id("_").Qual(contract.PkgPath, contract.Name).Op("=").Parens(Op("*").Id("Fake" + contract.Name)).Call(Nil())
+
+	for _, method := range contract.Methods {
+		srcFile.Line().Add(r.fakeMethod(typeGen, contract, method))
+	}
+
+	return srcFile.Save(path.Join(r.outDir, strings.ToLower(contract.Name)+".go"))
+}
+
+// renderMethodMock генерирует типизированное ожидание, метод Expect и реализацию метода мока.
+func (r *MockRenderer) renderMethodMock(srcFile *GoFile, typeGen *types.Generator, contract *parser.Contract, method *parser.Method) {
+
+	callName := contract.Name + method.Name + "Call"
+	args := mockVars(method.Args, "arg")
+	results := mockVars(method.Results, "result")
+	matchArgs := argsWithoutContext(args)
+
+	srcFile.Line().Commentf("%s - ожидание вызова %s.%s.", callName, contract.Name, method.Name)
+	srcFile.Type().Id(callName).StructFunc(func(sg *Group) {
+		sg.Op("*").Id("expectation")
+		sg.Id("fn").Add(r.funcType(typeGen, method))
+		for _, ret := range results {
+			sg.Id("ret" + toCamel(ret.name)).Add(typeGen.FieldTypeFromVariable(ret.Variable, false))
+		}
+	})
+
+	srcFile.Line().Comment("Return задаёт возвращаемые значения.")
+	srcFile.Func().Params(Id("c").Op("*").Id(callName)).Id("Return").
+		Params(r.params(typeGen, results, false)).
+		Op("*").Id(callName).
+		BlockFunc(func(bg *Group) {
+			for _, ret := range results {
+				bg.Id("c").Dot("ret" + toCamel(ret.name)).Op("=").Id(ret.name)
+			}
+			bg.Return(Id("c"))
+		})
+
+	srcFile.Line().Comment("Do задаёт функцию, вычисляющую результат по аргументам вызова.")
+	srcFile.Func().Params(Id("c").Op("*").Id(callName)).Id("Do").
+		Params(Id("fn").Add(r.funcType(typeGen, method))).
+		Op("*").Id(callName).
+		Block(
+			Id("c").Dot("fn").Op("=").Id("fn"),
+			Return(Id("c")),
+		)
+
+	srcFile.Line().Comment("Times задаёт точное количество ожидаемых вызовов.")
+	srcFile.Func().Params(Id("c").Op("*").Id(callName)).Id("Times").Params(Id("n").Int()).Op("*").Id(callName).Block(
+		Id("c").Dot("times").Op("=").Id("n"),
+		Return(Id("c")),
+	)
+
+	srcFile.Line().Comment("Once ожидает ровно один вызов.")
+	srcFile.Func().Params(Id("c").Op("*").Id(callName)).Id("Once").Params().Op("*").Id(callName).Block(
+		Return(Id("c").Dot("Times").Call(Lit(1))),
+	)
+
+	srcFile.Line().Commentf("Expect%s регистрирует ожидание вызова %s. Аргументы - значения или Matcher (context.Context не сравнивается).", method.Name, method.Name)
+	srcFile.Func().Params(Id("m").Op("*").Id(contract.Name)).Id("Expect"+method.Name).
+		ParamsFunc(func(pg *Group) {
+			for _, arg := range matchArgs {
+				pg.Id(arg.name).Any()
+			}
+		}).
+		Op("*").Id(callName).
+		Block(
+			Line().Id("call").Op(":=").Op("&").Id(callName).Values(),
+			Id("call").Dot("expectation").Op("=").Id("m").Dot("expect").Call(Lit(method.Name), argsSlice(matchArgs), Id("call")),
+			Return(Id("call")),
+		)
+
+	srcFile.Line().Func().Params(Id("m").Op("*").Id(contract.Name)).Id(method.Name).
+		Params(r.params(typeGen, args, true)).
+		Params(r.params(typeGen, results, false)).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.List(Id("call"), Id("ok")).Op(":=").Id("m").Dot("called").Call(Lit(method.Name), argsSlice(matchArgs)).Assert(Op("*").Id(callName))
+			bg.If(Op("!").Id("ok")).Block(Return())
+			if len(results) == 0 {
+				bg.If(Id("call").Dot("fn").Op("!=").Nil()).Block(
+					Id("call").Dot("fn").Call(callArgs(args)...),
+				)
+				return
+			}
+			bg.If(Id("call").Dot("fn").Op("!=").Nil()).Block(
+				Return(Id("call").Dot("fn").Call(callArgs(args)...)),
+			)
+			bg.ReturnFunc(func(rg *Group) {
+				for _, ret := range results {
+					rg.Id("call").Dot("ret" + toCamel(ret.name))
+				}
+			})
+		})
+}
+
+// fakeMethod генерирует метод Fake реализации, вызывающий пользовательскую функцию.
+func (r *MockRenderer) fakeMethod(typeGen *types.Generator, contract *parser.Contract, method *parser.Method) Code {
+
+	args := mockVars(method.Args, "arg")
+	results := mockVars(method.Results, "result")
+	fakeName := "Fake" + contract.Name
+
+	return Func().Params(Id("f").Op("*").Id(fakeName)).Id(method.Name).
+		Params(r.params(typeGen, args, true)).
+		Params(r.params(typeGen, results, false)).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.If(Id("f").Dot(method.Name + "Func").Op("==").Nil()).BlockFunc(func(ig *Group) {
+				if len(results) != 0 && results[len(results)-1].TypeID == typeIDError {
+					ig.Id(results[len(results)-1].name).Op("=").Qual(PackageFmt, "Errorf").Call(Lit(fmt.Sprintf("%s.%s: %%w", fakeName, method.Name)), Id("ErrNotImplemented"))
+				}
+				ig.Return()
+			})
+			if len(results) == 0 {
+				bg.Id("f").Dot(method.Name + "Func").Call(callArgs(args)...)
+				return
+			}
+			bg.Return(Id("f").Dot(method.Name + "Func").Call(callArgs(args)...))
+		})
+}
+
+// funcType генерирует тип функции с сигнатурой метода.
+func (r *MockRenderer) funcType(typeGen *types.Generator, method *parser.Method) *Statement {
+	return Func().Params(r.params(typeGen, mockVars(method.Args, "arg"), true)).Params(r.params(typeGen, mockVars(method.Results, "result"), false))
+}
+
+// params генерирует список именованных параметров.
+func (r *MockRenderer) params(typeGen *types.Generator, vars []mockVar, allowEllipsis bool) *Statement {
+
+	return ListFunc(func(lg *Group) {
+		for _, v := range vars {
+			lg.Id(v.name).Add(typeGen.FieldTypeFromVariable(v.Variable, allowEllipsis))
+		}
+	})
+}
+
+// mockVars назначает переменным имена, не конфликтующие с именами в сгенерированном коде.
+func mockVars(vars []*parser.Variable, prefix string) []mockVar {
+
+	result := make([]mockVar, 0, len(vars))
+	for i, v := range vars {
+		name := v.Name
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("%s%d", prefix, i)
+		}
+		if reservedNames[name] {
+			name += "Value"
+		}
+		result = append(result, mockVar{name: name, Variable: v})
+	}
+	return result
+}
+
+// argsWithoutContext возвращает аргументы без первого context.Context, если он есть.
+func argsWithoutContext(args []mockVar) []mockVar {
+
+	if len(args) != 0 && args[0].TypeID == typeIDContext {
+		return args[1:]
+	}
+	return args
+}
+
+// argsSlice генерирует срез аргументов для сопоставления с ожиданиями.
+func argsSlice(args []mockVar) *Statement {
+
+	return Index().Any().ValuesFunc(func(vg *Group) {
+		for _, arg := range args {
+			vg.Id(arg.name)
+		}
+	})
+}
+
+// callArgs генерирует аргументы вызова функции с раскрытием variadic аргумента.
+func callArgs(args []mockVar) []Code {
+
+	codes := make([]Code, 0, len(args))
+	for _, arg := range args {
+		if arg.IsEllipsis {
+			codes = append(codes, Id(arg.name).Op("..."))
+			continue
+		}
+		codes = append(codes, Id(arg.name))
+	}
+	return codes
+}
+
+// toCamel переводит первую букву имени в верхний регистр.
+func toCamel(s string) string {
+
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -145,6 +145,11 @@ func (g *generator) generate() error {
 		return fmt.Errorf("render middleware: %w", err)
 	}
 
+	logVerbose("rendering hooks: contract=%s", g.contract.ID)
+	if err := g.renderer.RenderHooks(); err != nil {
+		return fmt.Errorf("render hooks: %w", err)
+	}
+
 	if g.contract.Annotations.Contains("trace") {
 		logVerbose("rendering trace: contract=%s", g.contract.ID)
 		if err := g.renderer.RenderTrace(); err != nil {
@@ -176,6 +181,16 @@ func (g *generator) generate() error {
 		}
 	}
 
+	logVerbose("rendering limits: contract=%s", g.contract.ID)
+	if err := g.renderer.RenderLimits(); err != nil {
+		return fmt.Errorf("render limits: %w", err)
+	}
+
+	logVerbose("rendering testing helpers: contract=%s", g.contract.ID)
+	if err := g.renderer.RenderTesting(); err != nil {
+		return fmt.Errorf("render testing helpers: %w", err)
+	}
+
 	return nil
 }
 
@@ -222,11 +237,51 @@ func (g *generator) generateTransport() error {
 		return fmt.Errorf("render transport options: %w", err)
 	}
 
+	logVerbose("rendering transport health")
+	if err := g.renderer.RenderTransportHealth(); err != nil {
+		return fmt.Errorf("render transport health: %w", err)
+	}
+
+	logVerbose("rendering transport codec")
+	if err := g.renderer.RenderTransportCodec(); err != nil {
+		return fmt.Errorf("render transport codec: %w", err)
+	}
+
+	logVerbose("rendering transport middleware")
+	if err := g.renderer.RenderTransportMiddleware(); err != nil {
+		return fmt.Errorf("render transport middleware: %w", err)
+	}
+
+	logVerbose("rendering transport hooks")
+	if err := g.renderer.RenderTransportHooks(); err != nil {
+		return fmt.Errorf("render transport hooks: %w", err)
+	}
+
 	logVerbose("rendering transport metrics")
 	if err := g.renderer.RenderTransportMetrics(); err != nil {
 		return fmt.Errorf("render transport metrics: %w", err)
 	}
 
+	logVerbose("rendering transport limits")
+	if err := g.renderer.RenderTransportLimits(); err != nil {
+		return fmt.Errorf("render transport limits: %w", err)
+	}
+
+	logVerbose("rendering transport idempotency")
+	if err := g.renderer.RenderTransportIdempotency(); err != nil {
+		return fmt.Errorf("render transport idempotency: %w", err)
+	}
+
+	logVerbose("rendering transport cache")
+	if err := g.renderer.RenderTransportCache(); err != nil {
+		return fmt.Errorf("render transport cache: %w", err)
+	}
+
+	logVerbose("rendering transport problem")
+	if err := g.renderer.RenderTransportProblem(); err != nil {
+		return fmt.Errorf("render transport problem: %w", err)
+	}
+
 	logVerbose("rendering transport version")
 	if err := g.renderer.RenderTransportVersion(); err != nil {
 		return fmt.Errorf("render transport version: %w", err)
@@ -239,6 +294,11 @@ func (g *generator) generateTransport() error {
 		}
 	}
 
+	logVerbose("rendering transport testing")
+	if err := g.renderer.RenderTransportTesting(); err != nil {
+		return fmt.Errorf("render transport testing: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,165 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tgp/core"
+	"tgp/internal/parser"
+	"tgp/internal/tags"
+)
+
+// testLogger пишет сообщения генератора в лог теста.
+type testLogger struct {
+	t *testing.T
+}
+
+func (l testLogger) Debug(string)     {}
+func (l testLogger) Info(string)      {}
+func (l testLogger) Warn(msg string)  { l.t.Log("WARN", msg) }
+func (l testLogger) Error(msg string) { l.t.Log("ERROR", msg) }
+
+const testModulePath = "example.com/svc"
+
+// testContract - исходный код контракта и его реализации, соответствующий testProject.
+const testContract = `package contracts
+
+import "context"
+
+type Svc interface {
+	Ping(ctx context.Context) (err error)
+	Sum(ctx context.Context, a int, b int) (c int, err error)
+}
+
+type SvcImpl struct{}
+
+func (SvcImpl) Ping(ctx context.Context) (err error) { return nil }
+
+func (SvcImpl) Sum(ctx context.Context, a int, b int) (c int, err error) { return a + b, nil }
+`
+
+// testConformance - тест проекта, запускающий проверки JSON-RPC 2.0 против сгенерированного транспорта.
+const testConformance = `package transport_test
+
+import (
+	"testing"
+
+	"example.com/svc/contracts"
+	"example.com/svc/internal/transport"
+	transporttest "example.com/svc/internal/transport/testing"
+)
+
+func TestJsonRPCConformance(t *testing.T) {
+	transporttest.JsonRPCConformance(t, transport.Svc(contracts.SvcImpl{}))
+}
+
+func TestJsonRPCConformanceUnregistered(t *testing.T) {
+	transporttest.JsonRPCConformance(t)
+}
+`
+
+// testProject возвращает проект с JSON-RPC контрактом Svc.
+func testProject() *parser.Project {
+
+	contractID := testModulePath + "/contracts:Svc"
+	ctxArg := &parser.Variable{Name: "ctx", TypeID: "context:Context"}
+	errResult := &parser.Variable{Name: "err", TypeID: "error"}
+	return &parser.Project{
+		ModulePath:   testModulePath,
+		ContractsDir: "contracts",
+		Contracts: []*parser.Contract{{
+			Name:        "Svc",
+			PkgPath:     testModulePath + "/contracts",
+			FilePath:    "contracts/svc.go",
+			ID:          contractID,
+			Annotations: tags.DocTags{"jsonRPC-server": ""},
+			Methods: []*parser.Method{
+				{
+					Name:        "Ping",
+					ContractID:  contractID,
+					Args:        []*parser.Variable{ctxArg},
+					Results:     []*parser.Variable{errResult},
+					Annotations: tags.DocTags{},
+				},
+				{
+					Name:        "Sum",
+					ContractID:  contractID,
+					Args:        []*parser.Variable{ctxArg, {Name: "a", TypeID: "int"}, {Name: "b", TypeID: "int"}},
+					Results:     []*parser.Variable{{Name: "c", TypeID: "int"}, errResult},
+					Annotations: tags.DocTags{},
+				},
+			},
+		}},
+		Types: map[string]*parser.Type{
+			"context:Context": {Kind: parser.TypeKindInterface, TypeName: "Context", ImportPkgPath: "context", PkgName: "context"},
+		},
+	}
+}
+
+// writeFile записывает файл модуля, создавая директории.
+func writeFile(t *testing.T, filePath, content string) {
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// goCommand возвращает команду go в директории dir: зависимости берутся из кэша модулей, затем из GOPROXY окружения.
+func goCommand(t *testing.T, dir string, args ...string) *exec.Cmd {
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found")
+	}
+	modCache, err := exec.Command(goBin, "env", "GOMODCACHE").Output()
+	if err != nil {
+		t.Fatalf("go env GOMODCACHE: %v", err)
+	}
+	goProxy := "file://" + filepath.ToSlash(filepath.Join(strings.TrimSpace(string(modCache)), "cache", "download"))
+	if proxy := os.Getenv("GOPROXY"); proxy != "" {
+		goProxy += "," + proxy
+	} else {
+		goProxy += ",https://proxy.golang.org,direct"
+	}
+	cmd := exec.Command(goBin, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off", "GOPROXY="+goProxy, "GOSUMDB=off")
+	return cmd
+}
+
+func TestGenerateTransport_JsonRPCConformance(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("builds generated transport")
+	}
+	core.SetLogger(testLogger{t: t})
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module "+testModulePath+"\n\ngo 1.25\n")
+	writeFile(t, filepath.Join(root, "contracts", "svc.go"), testContract)
+	t.Chdir(root)
+
+	project := testProject()
+	if err := GenerateTransportFiles(project, "internal/transport", "."); err != nil {
+		t.Fatalf("generate transport: %v", err)
+	}
+	if err := GenerateServer(project, project.Contracts[0].ID, "internal/transport", "."); err != nil {
+		t.Fatalf("generate server: %v", err)
+	}
+	writeFile(t, filepath.Join(root, "internal", "transport", "conformance_test.go"), testConformance)
+
+	// зависимости транспорта недоступны без сети и кэша модулей
+	if output, err := goCommand(t, root, "mod", "tidy").CombinedOutput(); err != nil {
+		t.Skipf("resolve transport dependencies: %v\n%s", err, output)
+	}
+	if output, err := goCommand(t, root, "test", "-count=1", "./internal/transport/").CombinedOutput(); err != nil {
+		t.Fatalf("conformance of generated transport: %v\n%s", err, output)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"tgp/internal/parser"
+	"tgp/plugins/server/utils"
 )
 
 //go:embed pkg/context pkg/logger pkg/tracer pkg/viewer
@@ -112,6 +113,20 @@ func (r *baseRenderer) hasMetrics() bool {
 	return false
 }
 
+// metricsOTel проверяет, используют ли метрики методов OpenTelemetry вместо Prometheus.
+func (r *baseRenderer) metricsOTel() bool {
+
+	for _, contract := range r.project.Contracts {
+		if !contract.Annotations.Contains(TagMetrics) {
+			continue
+		}
+		if backend, _ := utils.MetricsBackend(contract); backend == utils.MetricsOTel {
+			return true
+		}
+	}
+	return false
+}
+
 // hasTrace проверяет, есть ли контракты с трейсингом.
 func (r *baseRenderer) hasTrace() bool {
 
@@ -0,0 +1,118 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"sort"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+	"tgp/plugins/server/utils"
+)
+
+// methodHasCache проверяет, заданы ли для метода кэширование ответа или ETag.
+func methodHasCache(method *parser.Method) bool {
+	return method.Annotations.IsSet(TagHttpCache) || method.Annotations.IsSet(TagEtag)
+}
+
+// contractHasCache проверяет, есть ли в контракте методы с кэшированием ответов.
+func contractHasCache(contract *parser.Contract) bool {
+
+	for _, method := range contract.Methods {
+		if methodHasCache(method) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCache проверяет, есть ли контракты с кэшированием ответов.
+func (r *baseRenderer) hasCache() bool {
+
+	for _, contract := range r.project.Contracts {
+		if contractHasCache(contract) {
+			return true
+		}
+	}
+	return false
+}
+
+// cachePolicy генерирует параметры кэширования ответа метода.
+func (r *contractRenderer) cachePolicy(method *parser.Method) Code {
+
+	return Id("cachePolicy").Values(DictFunc(func(d Dict) {
+		if method.Annotations.IsSet(TagHttpCache) {
+			control := strings.TrimSpace(method.Annotations.Value(TagHttpCache))
+			d[Id("control")] = Lit(control)
+			if ttl, _ := utils.ParseCacheControl(control); ttl > 0 {
+				d[Id("ttl")] = durationCode(ttl)
+			}
+		}
+		if method.Annotations.IsSet(TagEtag) {
+			d[Id("etag")] = True()
+		}
+		if headers := r.cacheVary(method, r.varHeaderMap(method)); len(headers) > 0 {
+			d[Id("headers")] = Index().String().ValuesFunc(func(g *Group) {
+				for _, header := range headers {
+					g.Lit(header)
+				}
+			})
+		}
+		if results := r.cacheResultHeaders(method); len(results) > 0 {
+			d[Id("results")] = Index().String().ValuesFunc(func(g *Group) {
+				for _, header := range results {
+					g.Lit(header)
+				}
+			})
+		}
+		if cookies := r.cacheVary(method, r.varCookieMap(method)); len(cookies) > 0 {
+			d[Id("cookies")] = Index().String().ValuesFunc(func(g *Group) {
+				for _, cookie := range cookies {
+					g.Lit(cookie)
+				}
+			})
+		}
+	}))
+}
+
+// cacheVary возвращает отсортированные имена заголовков или cookies, из которых берутся аргументы метода.
+func (r *contractRenderer) cacheVary(method *parser.Method, varMap map[string]string) (values []string) {
+
+	for varName, value := range varMap {
+		if r.argByName(method, varName) != nil {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return
+}
+
+// cacheResultHeaders возвращает отсортированные имена заголовков, в которые пишутся результаты метода.
+func (r *contractRenderer) cacheResultHeaders(method *parser.Method) (headers []string) {
+
+	for varName, header := range r.varHeaderMap(method) {
+		if r.resultByName(method, varName) != nil {
+			headers = append(headers, header)
+		}
+	}
+	sort.Strings(headers)
+	return
+}
+
+// cacheCheckHTTP генерирует выдачу закэшированного ответа и установку заголовков кэширования REST метода.
+func (r *contractRenderer) cacheCheckHTTP(method *parser.Method) Code {
+
+	if !methodHasCache(method) {
+		return Null()
+	}
+	scope := toLowerCamel(r.contract.Name) + "." + toLowerCamel(method.Name)
+	return List(Id("cached"), Id("handled")).Op(":=").Id("http").Dot("srv").Dot("cachedHTTP").Call(Id(VarNameFtx), Lit(scope), r.cachePolicy(method)).Line().
+		If(Id("handled")).Block(
+		Return(),
+	).Line().
+		Defer().Func().Params().Block(
+		Id("cached").Dot("completeHTTP").Call(Id(VarNameFtx), Err()),
+	).Call()
+}
@@ -11,6 +11,10 @@ const (
 	TagMetrics                = "metrics"
 	TagLogger                 = "log"
 	TagLogSkip                = "log-skip"
+	TagLogMask                = "log-mask"
+	TagLogSample              = "log-sample"
+	TagLogTruncate            = "log-truncate"
+	TagLogMaxLen              = "log-max-len"
 	TagServerJsonRPC          = "jsonRPC-server"
 	TagServerHTTP             = "http-server"
 	TagHttpPrefix             = "http-prefix"
@@ -25,6 +29,22 @@ const (
 	TagHttpCookies            = "http-cookies"
 	TagMethodHTTP             = "http-method"
 	TagNoOmitempty            = "tagNoOmitempty"
+	TagHttpMultipart          = "http-multipart"
+	TagHttpBody               = "http-body"
+	TagHttpMaxSize            = "http-max-size"
+	TagHttpContentType        = "http-content-type"
+	TagHttpDownload           = "http-download"
+	TagRateLimit              = "rate-limit"
+	TagRateLimitBurst         = "burst"
+	TagRateLimitKey           = "key"
+	TagMaxInflight            = "max-inflight"
+	TagIdempotent             = "idempotent"
+	TagHttpCache              = "http-cache"
+	TagEtag                   = "etag"
+	TagVersion                = "version"
+	TagDeprecated             = "deprecated"
+	TagSunset                 = "sunset"
+	TagHttpErrors             = "http-errors"
 )
 
 // Package paths
@@ -33,6 +53,10 @@ const (
 	PackageTime           = "time"
 	PackageContext        = "context"
 	PackageStrconv        = "strconv"
+	PackageRand           = "math/rand"
+	PackageList           = "container/list"
+	PackageNetHTTP        = "net/http"
+	PackageNetURL         = "net/url"
 	PackageFiber          = "github.com/gofiber/fiber/v2"
 	PackageFiberAdaptor   = "github.com/gofiber/adaptor/v2"
 	PackageSlog           = "log/slog"
@@ -43,16 +67,36 @@ const (
 	PackagePrometheusAuto = "github.com/prometheus/client_golang/prometheus/promauto"
 	PackagePrometheusHttp = "github.com/prometheus/client_golang/prometheus/promhttp"
 	PackageAttributeOTEL  = "go.opentelemetry.io/otel/attribute"
+	PackageMetricOTEL     = "go.opentelemetry.io/otel/metric"
 	PackageTraceSDK       = "go.opentelemetry.io/otel/sdk/trace"
 	PackageErrors         = "github.com/pkg/errors"
 	PackageStrings        = "strings"
 	PackageBytes          = "bytes"
 	PackageSync           = "sync"
+	PackageAtomic         = "sync/atomic"
 	PackageUUID           = "github.com/google/uuid"
 	PackageStdJSON        = "encoding/json"
 	PackageCors           = "github.com/lab259/cors"
 	PackageReflect        = "reflect"
 	PackageZeroLog        = "github.com/rs/zerolog"
+	PackageIO             = "io"
+	PackageMime           = "mime"
+	PackageMath           = "math"
+	PackageSha256         = "crypto/sha256"
+	PackageHex            = "encoding/hex"
+	PackageDebug          = "runtime/debug"
+	PackageTesting        = "testing"
+	PackageNet            = "net"
+	PackageFasthttputil   = "github.com/valyala/fasthttp/fasthttputil"
+	PackageSort           = "sort"
+	PackageSlices         = "slices"
+	PackageMultipart      = "mime/multipart"
+	PackageTextproto      = "net/textproto"
+)
+
+// Режимы ответов REST методов с ошибкой
+const (
+	HttpErrorsProblem = "problem"
 )
 
 // Variable names for generated code
@@ -120,6 +120,10 @@ func (r *contractRenderer) varsToFields(vars []*parser.Variable, methodTags tags
 				}
 			}
 		}
+		// Потоки не сериализуются в JSON и передаются через тело HTTP запроса/ответа
+		if isStreamVar(v) {
+			field.tags["json"] = "-"
+		}
 		fields = append(fields, field)
 	}
 	return fields
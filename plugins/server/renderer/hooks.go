@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+	"tgp/plugins/server/renderer/types"
+)
+
+// RenderHooks генерирует хуки Before/After методов контракта с типизированным доступом к структурам обмена.
+func (r *contractRenderer) RenderHooks() error {
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageContext, "context")
+
+	typeGen := types.NewGenerator(r.project, &srcFile)
+
+	for _, method := range r.contract.Methods {
+		methodName := r.contract.Name + "." + method.Name
+		requestName := r.contract.Name + method.Name + "Request"
+		responseName := r.contract.Name + method.Name + "Response"
+
+		srcFile.Line().Commentf("%s - аргументы метода %s.", requestName, methodName)
+		srcFile.Type().Id(requestName).Op("=").Id(requestStructName(r.contract.Name, method.Name))
+		srcFile.Line().Commentf("%s - результаты метода %s.", responseName, methodName)
+		srcFile.Type().Id(responseName).Op("=").Id(responseStructName(r.contract.Name, method.Name))
+
+		srcFile.Line().Add(r.exchangeFromValues(typeGen, "new"+requestName, requestName, argsWithoutContext(method)))
+		srcFile.Line().Add(r.exchangeToValues(requestName, argsWithoutContext(method)))
+		srcFile.Line().Add(r.exchangeFromValues(typeGen, "new"+responseName, responseName, resultsWithoutError(method)))
+		srcFile.Line().Add(r.exchangeToValues(responseName, resultsWithoutError(method)))
+
+		srcFile.Line().Commentf("Before%s%s регистрирует хук, вызываемый перед %s. Хук может изменить аргументы, ошибка прерывает вызов.", r.contract.Name, method.Name, methodName)
+		srcFile.Func().Id("Before" + r.contract.Name + method.Name).
+			Params(Id("hook").Func().Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("request").Op("*").Id(requestName)).Error()).
+			Id("Option").
+			Block(
+				Return(Id("WithMethodMiddleware").Call(Lit(methodName), Func().Params(Id(VarNameNext).Id("CallHandler")).Id("CallHandler").Block(
+					Return(Func().Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("method").String(), Id("args").Index().Any()).Params(Index().Any(), Error()).Block(
+						Id("request").Op(":=").Id("new"+requestName).Call(Id("args")),
+						If(Err().Op(":=").Id("hook").Call(Id(VarNameCtx), Op("&").Id("request")), Err().Op("!=").Nil()).Block(
+							Return(Nil(), Err()),
+						),
+						Return(Id(VarNameNext).Call(Id(VarNameCtx), Id("method"), Id("request").Dot("values").Call())),
+					)),
+				))),
+			)
+
+		srcFile.Line().Commentf("After%s%s регистрирует хук, вызываемый после %s. Хук получает аргументы, результаты и ошибку и может изменить результаты.", r.contract.Name, method.Name, methodName)
+		srcFile.Func().Id("After" + r.contract.Name + method.Name).
+			Params(Id("hook").Func().Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("request").Id(requestName), Id("response").Op("*").Id(responseName), Err().Error())).
+			Id("Option").
+			Block(
+				Return(Id("WithMethodMiddleware").Call(Lit(methodName), Func().Params(Id(VarNameNext).Id("CallHandler")).Id("CallHandler").Block(
+					Return(Func().Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("method").String(), Id("args").Index().Any()).Params(Index().Any(), Error()).Block(
+						List(Id("results"), Err()).Op(":=").Id(VarNameNext).Call(Id(VarNameCtx), Id("method"), Id("args")),
+						Id("response").Op(":=").Id("new"+responseName).Call(Id("results")),
+						Id("hook").Call(Id(VarNameCtx), Id("new"+requestName).Call(Id("args")), Op("&").Id("response"), Err()),
+						Return(Id("response").Dot("values").Call(), Err()),
+					)),
+				))),
+			)
+	}
+
+	return srcFile.Save(path.Join(r.outDir, strings.ToLower(r.contract.Name)+"-hooks.go"))
+}
+
+// exchangeFromValues генерирует сборку структуры обмена из значений обобщённого вызова.
+func (r *contractRenderer) exchangeFromValues(typeGen *types.Generator, funcName string, typeName string, vars []*parser.Variable) Code {
+
+	return Func().Id(funcName).Params(Id("values").Index().Any()).Id(typeName).Block(
+		Return(Id(typeName).Values(DictFunc(func(dict Dict) {
+			for i, v := range vars {
+				dict[Id(toCamel(v.Name))] = Id("callValue").Types(typeGen.FieldTypeFromVariable(v, false)).Call(Id("values"), Lit(i))
+			}
+		}))),
+	)
+}
+
+// exchangeToValues генерирует преобразование структуры обмена в значения обобщённого вызова.
+func (r *contractRenderer) exchangeToValues(typeName string, vars []*parser.Variable) Code {
+
+	receiver := strings.ToLower(typeName[:1])
+	return Func().Params(Id(receiver).Id(typeName)).Id("values").Params().Index().Any().Block(
+		Return(Index().Any().ValuesFunc(func(vg *Group) {
+			for _, v := range vars {
+				vg.Id(receiver).Dot(toCamel(v.Name))
+			}
+		})),
+	)
+}
@@ -40,9 +40,14 @@ func (r *contractRenderer) renderHTTPTypes(srcFile *GoFile) {
 		Id("svc").Op("*").Id("server" + r.contract.Name),
 		Id("base").Qual(r.contract.PkgPath, r.contract.Name),
 	}
-	if r.contract.Annotations.Contains(TagServerJsonRPC) {
+	if r.contract.Annotations.Contains(TagServerJsonRPC) || contractNeedsServer(r.contract) {
 		fields = append(fields, Id("srv").Op("*").Id("Server"))
 	}
+	for _, method := range r.contract.Methods {
+		if method.Annotations.IsSet(TagMaxInflight) {
+			fields = append(fields, Id(inflightFieldName(method)).Chan().Struct())
+		}
+	}
 	srcFile.Type().Id("http" + r.contract.Name).Struct(fields...)
 }
 
@@ -53,10 +58,15 @@ func (r *contractRenderer) renderHTTPNewFunc(srcFile *GoFile) {
 		Params(Id("svc"+r.contract.Name).Qual(r.contract.PkgPath, r.contract.Name)).
 		Params(Id("srv").Op("*").Id("http"+r.contract.Name)).
 		Block(
-			Line().Id("srv").Op("=").Op("&").Id("http"+r.contract.Name).Values(Dict{
-				Id("base"): Id("svc" + r.contract.Name),
-				Id("svc"):  Id("newServer" + r.contract.Name).Call(Id("svc" + r.contract.Name)),
-			}),
+			Line().Id("srv").Op("=").Op("&").Id("http"+r.contract.Name).Values(DictFunc(func(dict Dict) {
+				dict[Id("base")] = Id("svc" + r.contract.Name)
+				dict[Id("svc")] = Id("newServer" + r.contract.Name).Call(Id("svc" + r.contract.Name))
+				for _, method := range r.contract.Methods {
+					if method.Annotations.IsSet(TagMaxInflight) {
+						dict[Id(inflightFieldName(method))] = Make(Chan().Struct(), Lit(method.Annotations.ValueInt(TagMaxInflight)))
+					}
+				}
+			})),
 			Return(),
 		)
 }
@@ -146,7 +156,10 @@ func (r *contractRenderer) httpWithLogFunc() Code {
 		Params().
 		Params(Op("*").Id("http" + r.contract.Name)).
 		BlockFunc(func(bg *Group) {
-			bg.Id("http").Dot("svc").Dot("WithLog").Call()
+			// Без аннотации log сервер контракта не содержит middleware логирования
+			if r.contract.Annotations.Contains(TagLogger) {
+				bg.Id("http").Dot("svc").Dot("WithLog").Call()
+			}
 			bg.Return(Id("http"))
 		})
 }
@@ -0,0 +1,76 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+)
+
+// methodIsIdempotent проверяет, помечен ли метод как идемпотентный.
+func methodIsIdempotent(method *parser.Method) bool {
+	return method.Annotations.IsSet(TagIdempotent)
+}
+
+// contractHasIdempotent проверяет, есть ли в контракте идемпотентные методы.
+func contractHasIdempotent(contract *parser.Contract) bool {
+
+	for _, method := range contract.Methods {
+		if methodIsIdempotent(method) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasIdempotent проверяет, есть ли контракты с идемпотентными методами.
+func (r *baseRenderer) hasIdempotent() bool {
+
+	for _, contract := range r.project.Contracts {
+		if contractHasIdempotent(contract) {
+			return true
+		}
+	}
+	return false
+}
+
+// contractNeedsServer проверяет, нужна ли обработчику контракта ссылка на сервер.
+func contractNeedsServer(contract *parser.Contract) bool {
+	return contractHasLimits(contract) || contractHasIdempotent(contract) || contractHasCache(contract)
+}
+
+// idempotencyScope возвращает область ключей идемпотентности метода.
+func (r *contractRenderer) idempotencyScope(method *parser.Method) string {
+	return toLowerCamel(r.contract.Name) + "." + toLowerCamel(method.Name)
+}
+
+// idempotencyCheckJsonRPC генерирует повтор сохранённого ответа идемпотентного JSON-RPC метода.
+func (r *contractRenderer) idempotencyCheckJsonRPC(method *parser.Method, ctx Code) Code {
+
+	if !methodIsIdempotent(method) {
+		return Null()
+	}
+	return Line().List(Id("idempotency"), Id("replay")).Op(":=").Id("http").Dot("srv").Dot("idempotentJsonRPC").Call(ctx, Lit(r.idempotencyScope(method)), Id("requestBase")).Line().
+		If(Id("replay").Op("!=").Nil()).Block(
+		Return(Id("replay")),
+	).Line().
+		Defer().Func().Params().Block(
+		Id("idempotency").Dot("completeJsonRPC").Call(ctx, Id("responseBase")),
+	).Call()
+}
+
+// idempotencyCheckHTTP генерирует повтор сохранённого ответа идемпотентного REST метода.
+func (r *contractRenderer) idempotencyCheckHTTP(method *parser.Method) Code {
+
+	if !methodIsIdempotent(method) {
+		return Null()
+	}
+	return List(Id("idempotency"), Id("handled"), Err()).Op(":=").Id("http").Dot("srv").Dot("idempotentHTTP").Call(Id(VarNameFtx), Lit(r.idempotencyScope(method))).Line().
+		If(Id("handled")).Block(
+		Return(),
+	).Line().
+		Defer().Func().Params().Block(
+		Id("idempotency").Dot("completeHTTP").Call(Id(VarNameFtx), Err()),
+	).Call()
+}
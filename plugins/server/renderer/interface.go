@@ -15,6 +15,8 @@ type Renderer interface {
 
 	// RenderMiddleware генерирует типы middleware.
 	RenderMiddleware() error
+	// RenderHooks генерирует хуки Before/After методов контракта.
+	RenderHooks() error
 
 	// RenderTrace генерирует middleware для трейсинга.
 	RenderTrace() error
@@ -31,6 +33,12 @@ type Renderer interface {
 	// RenderREST генерирует REST обработчики.
 	RenderREST() error
 
+	// RenderLimits генерирует ограничители вызовов методов.
+	RenderLimits() error
+
+	// RenderTesting генерирует типизированные помощники вызова методов для пакета testing.
+	RenderTesting() error
+
 	// Транспортные файлы (генерируются один раз для всех контрактов)
 	RenderTransportHTTP() error
 	RenderTransportContext() error
@@ -43,4 +51,13 @@ type Renderer interface {
 	RenderTransportMetrics() error
 	RenderTransportVersion() error
 	RenderTransportJsonRPC() error
+	RenderTransportLimits() error
+	RenderTransportIdempotency() error
+	RenderTransportCache() error
+	RenderTransportProblem() error
+	RenderTransportHealth() error
+	RenderTransportCodec() error
+	RenderTransportMiddleware() error
+	RenderTransportHooks() error
+	RenderTransportTesting() error
 }
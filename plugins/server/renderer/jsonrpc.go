@@ -78,14 +78,11 @@ func (r *contractRenderer) rpcMethodFuncWithFiberCtx(typeGen *types.Generator, m
 			bg.If(Id("methodCtx").Dot("Err").Call().Op("!=").Nil()).Block(
 				Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("invalidRequestError"), Lit("request context cancelled"), Nil())),
 			)
+			bg.Add(r.deprecationHeaders(method))
+			bg.Add(r.limitsCheckJsonRPC(method, Id("methodCtx"), true))
+			bg.Add(r.idempotencyCheckJsonRPC(method, Id("methodCtx")))
 			bg.Line()
-			bg.If(Id("requestBase").Dot("Params").Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Id("dec").Op(":=").Qual(jsonPkg, "NewDecoder").Call(Qual(PackageBytes, "NewReader").Call(Id("requestBase").Dot("Params")))
-				ig.Id("dec").Dot("DisallowUnknownFields").Call()
-				ig.If(Err().Op("=").Id("dec").Dot("Decode").Call(Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(eg *Group) {
-					eg.Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("invalidParamsError"), Lit("invalid params: ").Op("+").Err().Dot("Error").Call(), Nil()))
-				})
-			})
+			bg.Add(r.rpcDecodeParams(method, jsonPkg))
 			bg.Line()
 			bg.ListFunc(func(lg *Group) {
 				for _, ret := range resultsWithoutError(method) {
@@ -120,9 +117,7 @@ func (r *contractRenderer) rpcMethodFuncWithFiberCtx(typeGen *types.Generator, m
 			if len(resultsWithoutError(method)) == 1 && method.Annotations.Contains(TagHttpEnableInlineSingle) {
 				resp = Id("response").Dot(toCamel(resultsWithoutError(method)[0].Name))
 			}
-			bg.If(List(Id("responseBase").Dot("Result"), Err()).Op("=").Qual(jsonPkg, "Marshal").Call(resp).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("parseError"), Lit("response body could not be encoded: ").Op("+").Err().Dot("Error").Call(), Nil()))
-			})
+			bg.Add(r.rpcEncodeResult(Id("methodCtx"), resp, jsonPkg))
 			bg.Line()
 			bg.Return()
 		})
@@ -144,14 +139,10 @@ func (r *contractRenderer) rpcMethodFuncWithContext(typeGen *types.Generator, me
 			bg.If(Id(VarNameCtx).Dot("Err").Call().Op("!=").Nil()).Block(
 				Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("invalidRequestError"), Lit("request context cancelled"), Nil())),
 			)
+			bg.Add(r.limitsCheckJsonRPC(method, Id(VarNameCtx), false))
+			bg.Add(r.idempotencyCheckJsonRPC(method, Id(VarNameCtx)))
 			bg.Line()
-			bg.If(Id("requestBase").Dot("Params").Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Id("dec").Op(":=").Qual(jsonPkg, "NewDecoder").Call(Qual(PackageBytes, "NewReader").Call(Id("requestBase").Dot("Params")))
-				ig.Id("dec").Dot("DisallowUnknownFields").Call()
-				ig.If(Err().Op("=").Id("dec").Dot("Decode").Call(Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(eg *Group) {
-					eg.Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("invalidParamsError"), Lit("invalid params: ").Op("+").Err().Dot("Error").Call(), Nil()))
-				})
-			})
+			bg.Add(r.rpcDecodeParams(method, jsonPkg))
 			bg.Line()
 			bg.ListFunc(func(lg *Group) {
 				for _, ret := range resultsWithoutError(method) {
@@ -186,9 +177,7 @@ func (r *contractRenderer) rpcMethodFuncWithContext(typeGen *types.Generator, me
 			if len(resultsWithoutError(method)) == 1 && method.Annotations.Contains(TagHttpEnableInlineSingle) {
 				resp = Id("response").Dot(toCamel(resultsWithoutError(method)[0].Name))
 			}
-			bg.If(List(Id("responseBase").Dot("Result"), Err()).Op("=").Qual(jsonPkg, "Marshal").Call(resp).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("parseError"), Lit("response body could not be encoded: ").Op("+").Err().Dot("Error").Call(), Nil()))
-			})
+			bg.Add(r.rpcEncodeResult(Id(VarNameCtx), resp, jsonPkg))
 			bg.Line()
 			bg.Return()
 		})
@@ -213,26 +202,31 @@ func (r *contractRenderer) serveMethodFunc(jsonPkg string) Code {
 				ig.If(List(Id("_"), Err()).Op("=").Id(VarNameFtx).Dot("WriteString").Call(Lit("only POST method supported")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
+				ig.Return()
 			})
-			bg.Var().Id("request").Id("baseJsonRPC")
-			bg.Var().Id("response").Op("*").Id("baseJsonRPC")
-			bg.If(Err().Op("=").Qual(jsonPkg, "Unmarshal").Call(Id(VarNameFtx).Dot("Body").Call(), Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Return().Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call())
-			})
-			bg.If(Err().Op("=").Id("validateJsonRPCRequest").Call(Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Return().Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call())
-			})
+			bg.List(Id("requests"), Id("single"), Id("errResponse")).Op(":=").Id("decodeRequestsJsonRPC").Call(Id(VarNameFtx))
+			bg.If(Id("errResponse").Op("!=").Nil()).Block(
+				Return(Id("sendResponse").Call(Id(VarNameFtx), Id("errResponse"))),
+			)
+			bg.If(Op("!").Id("single")).Block(
+				Return(Id("sendResponse").Call(Id(VarNameFtx), Id("makeErrorResponseJsonRPC").Call(Id("nullIDJsonRPC"), Id("invalidRequestError"), Lit("batch request is not supported by method endpoint"), Nil()))),
+			)
+			bg.Id("request").Op(":=").Id("requests").Index(Lit(0))
+			bg.If(Err().Op("=").Id("validateJsonRPCRequest").Call(Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(Id("sendResponse").Call(Id(VarNameFtx), Id("makeErrorResponseJsonRPC").Call(Id("responseIDJsonRPC").Call(Id("request").Dot("ID")), Id("invalidRequestError"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call(), Nil()))),
+			)
 			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
 			bg.Id("method").Op(":=").Id("toLowercaseMethod").Call(Id("request").Dot("Method"))
 
 			bg.If(Id("method").Op("!=").Lit("").Op("&&").Id("method").Op("!=").Id("methodName")).BlockFunc(func(ig *Group) {
 				ig.Return().Id("sendResponse").Call(Id(VarNameFtx), Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("methodNotFoundError"), Lit("invalid method ").Op("+").Id("methodNameOrigin"), Nil()))
 			})
-			bg.Id("response").Op("=").Id("methodHandler").Call(Id(VarNameFtx), Id("request"))
-			bg.If(Id("response").Op("!=").Nil()).Block(
-				Return().Id("sendResponse").Call(Id(VarNameFtx), Id("response")),
+			bg.Id("response").Op(":=").Id("methodHandler").Call(Id(VarNameFtx), Id("request"))
+			// Notification выполняется, но не получает ответа
+			bg.If(Id("request").Dot("ID").Op("==").Nil()).Block(
+				Id("response").Op("=").Nil(),
 			)
-			bg.Return()
+			bg.Return(Id("sendResponse").Call(Id(VarNameFtx), Id("response")))
 		})
 }
 
@@ -255,63 +249,9 @@ func (r *contractRenderer) serviceServeBatchFunc(jsonPkg string) Code {
 		Id("serveBatch").
 		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
 		Params(Id("err").Error()).
-		BlockFunc(func(bg *Group) {
-			bg.Line()
-			bg.Var().Id("single").Bool()
-			bg.Var().Id("requests").Op("[]").Id("baseJsonRPC")
-			bg.Id("methodHTTP").Op(":=").Id(VarNameFtx).Dot("Method").Call()
-			bg.If(Id("methodHTTP").Op("!=").Qual(PackageFiber, "MethodPost")).BlockFunc(func(ig *Group) {
-				ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(PackageFiber, "StatusMethodNotAllowed"))
-				ig.If(List(Id("_"), Err()).Op("=").Id(VarNameFtx).Dot("WriteString").Call(Lit("only POST method supported")).Op(";").Err().Op("!=").Nil()).Block(
-					Return(),
-				)
-				ig.Return()
-			})
-			bg.Id("body").Op(":=").Qual(PackageBytes, "TrimSpace").Call(Id(VarNameFtx).Dot("Body").Call())
-			bg.If(Len(Id("body")).Op("==").Lit(0)).Block(
-				Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("request body could not be decoded: empty body"))),
-			)
-			bg.Id("decoder").Op(":=").Qual(jsonPkg, "NewDecoder").Call(Qual(PackageBytes, "NewReader").Call(Id("body")))
-			bg.Id("decoder").Dot("DisallowUnknownFields").Call()
-			bg.Var().Id("token").Interface()
-			bg.List(Id("token"), Err()).Op("=").Id("decoder").Dot("Token").Call()
-			bg.If(Err().Op("!=").Nil()).Block(
-				Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call())),
-			)
-			bg.If(Id("token").Op("==").Qual(jsonPkg, "Delim").Call(Lit('['))).BlockFunc(func(ig *Group) {
-				ig.For(Id("decoder").Dot("More").Call()).BlockFunc(func(fg *Group) {
-					fg.Var().Id("request").Id("baseJsonRPC")
-					fg.If(Err().Op("=").Id("decoder").Dot("Decode").Call(Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(dg *Group) {
-						dg.Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call()))
-					})
-					fg.Id("requests").Op("=").Append(Id("requests"), Id("request"))
-				})
-			}).Else().BlockFunc(func(ig *Group) {
-				ig.Var().Id("request").Id("baseJsonRPC")
-				ig.If(Err().Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("body"), Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ug *Group) {
-					ug.Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call()))
-				})
-				ig.Id("single").Op("=").True()
-				ig.Id("requests").Op("=").Append(Id("requests"), Id("request"))
-			})
-			bg.If(Len(Id("requests")).Op("==").Lit(0)).Block(
-				Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("empty batch request"))),
-			)
-			bg.If(Len(Id("requests")).Op(">").Id("http").Dot("srv").Dot("maxBatchSize")).Block(
-				Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("batch size exceeded"))),
-			)
-			bg.If(Id("single")).BlockFunc(func(ig *Group) {
-				ig.If(Err().Op("=").Id("validateJsonRPCRequest").Call(Id("requests").Op("[").Lit(0).Op("]")).Op(";").Err().Op("!=").Nil()).Block(
-					Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call())),
-				)
-				ig.Return(Id("sendResponse").Call(Id(VarNameFtx), Id("http").Dot("srv").Dot("doSingleBatch").
-					Call(Id(VarNameFtx).Dot("UserContext").Call(), Id("requests").Op("[").Lit(0).Op("]")),
-				))
-			})
-			bg.Return(Id("sendResponse").Call(Id(VarNameFtx), Id("http").Dot("doBatch").
-				Call(Id(VarNameFtx), Id("requests")),
-			))
-		})
+		Block(
+			Return(Id("http").Dot("srv").Dot("serveBatch").Call(Id(VarNameFtx))),
+		)
 }
 
 // serviceSingleBatchFunc генерирует функцию обработки одиночного batch запроса.
@@ -325,7 +265,7 @@ func (r *contractRenderer) serviceSingleBatchFunc(typeGen *types.Generator) Code
 			bg.Line()
 			bg.Var().Err().Error()
 			bg.If(Err().Op("=").Id("validateJsonRPCRequest").Call(Id("request")).Op(";").Err().Op("!=").Nil()).Block(
-				Return(Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("invalidRequestError"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call(), Nil())),
+				Return(Id("makeErrorResponseJsonRPC").Call(Id("responseIDJsonRPC").Call(Id("request").Dot("ID")), Id("invalidRequestError"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call(), Nil())),
 			)
 			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
 			bg.Id("method").Op(":=").Id("toLowercaseMethod").Call(Id("request").Dot("Method"))
@@ -345,3 +285,40 @@ func (r *contractRenderer) serviceSingleBatchFunc(typeGen *types.Generator) Code
 			})
 		})
 }
+
+// rpcDecodeParams генерирует разбор параметров JSON-RPC метода в JSON или согласованном кодеке.
+func (r *contractRenderer) rpcDecodeParams(method *parser.Method, jsonPkg string) Code {
+
+	return If(Id("requestBase").Dot("Params").Op("!=").Nil()).BlockFunc(func(ig *Group) {
+		ig.If(Id("requestBase").Dot("codec").Op("!=").Nil()).Block(
+			Err().Op("=").Id("requestBase").Dot("codec").Dot("Unmarshal").Call(Id("requestBase").Dot("Params"), Op("&").Id("request")),
+		).Else().If(Id("positionalParamsJsonRPC").Call(Id("requestBase").Dot("Params"))).Block(
+			Err().Op("=").Id("decodePositionalJsonRPC").CallFunc(func(cg *Group) {
+				cg.Id("requestBase").Dot("Params")
+				for _, arg := range argsWithoutContext(method) {
+					if isStreamVar(arg) {
+						continue
+					}
+					cg.Op("&").Id("request").Dot(toCamel(arg.Name))
+				}
+			}),
+		).Else().Block(
+			Id("dec").Op(":=").Qual(jsonPkg, "NewDecoder").Call(Qual(PackageBytes, "NewReader").Call(Id("requestBase").Dot("Params"))),
+			Id("dec").Dot("DisallowUnknownFields").Call(),
+			Err().Op("=").Id("dec").Dot("Decode").Call(Op("&").Id("request")),
+		)
+		ig.If(Err().Op("!=").Nil()).Block(
+			Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("invalidParamsError"), Lit("invalid params: ").Op("+").Err().Dot("Error").Call(), Nil())),
+		)
+	})
+}
+
+// rpcEncodeResult генерирует кодирование результата JSON-RPC метода: для бинарного кодека значение кодируется вместе с конвертом.
+func (r *contractRenderer) rpcEncodeResult(ctx *Statement, resp *Statement, jsonPkg string) Code {
+
+	return If(Id("responseCodec").Call(ctx).Op("!=").Nil()).Block(
+		Id("responseBase").Dot("resultValue").Op("=").Add(resp),
+	).Else().If(List(Id("responseBase").Dot("Result"), Err()).Op("=").Qual(jsonPkg, "Marshal").Call(resp).Op(";").Err().Op("!=").Nil()).Block(
+		Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("parseError"), Lit("response body could not be encoded: ").Op("+").Err().Dot("Error").Call(), Nil())),
+	)
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+	"tgp/plugins/server/utils"
+)
+
+// rateLimitKeySource источник ключа ограничителя частоты вызовов.
+type rateLimitKeySource struct {
+	value  string
+	source string
+	name   string
+}
+
+// fromFiber возвращает выражение получения ключа из Fiber контекста.
+func (s rateLimitKeySource) fromFiber(ftx *Statement) Code {
+
+	switch s.source {
+	case "header":
+		return ftx.Dot("Get").Call(Lit(s.name))
+	case "cookie":
+		return ftx.Dot("Cookies").Call(Lit(s.name))
+	default:
+		return ftx.Dot("IP").Call()
+	}
+}
+
+// methodHasLimits проверяет, заданы ли для метода ограничения частоты или параллельности вызовов.
+func methodHasLimits(method *parser.Method) bool {
+	return method.Annotations.IsSet(TagRateLimit) || method.Annotations.IsSet(TagMaxInflight)
+}
+
+// contractHasLimits проверяет, есть ли в контракте методы с ограничениями.
+func contractHasLimits(contract *parser.Contract) bool {
+
+	for _, method := range contract.Methods {
+		if methodHasLimits(method) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLimits проверяет, есть ли контракты с ограничениями вызовов методов.
+func (r *baseRenderer) hasLimits() bool {
+
+	for _, contract := range r.project.Contracts {
+		if contractHasLimits(contract) {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimitKeySources возвращает источники ключей ограничителей, используемые в проекте.
+func (r *baseRenderer) rateLimitKeySources() (sources []rateLimitKeySource) {
+
+	seen := make(map[string]bool)
+	for _, contract := range r.project.Contracts {
+		for _, method := range contract.Methods {
+			if !method.Annotations.IsSet(TagRateLimit) {
+				continue
+			}
+			value := strings.TrimSpace(method.Annotations.Value(TagRateLimitKey, ""))
+			source, name, err := utils.ParseRateLimitKey(value)
+			if err != nil || value == "" || seen[value] {
+				continue
+			}
+			seen[value] = true
+			sources = append(sources, rateLimitKeySource{value: value, source: source, name: name})
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].value < sources[j].value })
+	return
+}
+
+// RenderLimits генерирует ограничители вызовов методов контракта.
+func (r *contractRenderer) RenderLimits() error {
+
+	if !contractHasLimits(r.contract) {
+		return nil
+	}
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageTime, "time")
+
+	for _, method := range r.contract.Methods {
+		if !method.Annotations.IsSet(TagRateLimit) {
+			continue
+		}
+		limit, period, _ := utils.ParseRateLimit(method.Annotations.Value(TagRateLimit))
+		srcFile.Line().Var().Id(r.rateLimitVarName(method)).Op("=").Id("RateLimit").Values(Dict{
+			Id("Limit"):  Lit(limit),
+			Id("Period"): durationCode(period),
+			Id("Burst"):  Lit(method.Annotations.ValueInt(TagRateLimitBurst, limit)),
+		})
+	}
+
+	for _, method := range r.contract.Methods {
+		if !methodHasLimits(method) {
+			continue
+		}
+		srcFile.Line().Add(r.limitMethodFunc(method))
+	}
+
+	return srcFile.Save(path.Join(r.outDir, strings.ToLower(r.contract.Name)+"-limits.go"))
+}
+
+// rateLimitVarName возвращает имя переменной с параметрами ограничителя метода.
+func (r *contractRenderer) rateLimitVarName(method *parser.Method) string {
+	return "rateLimit" + r.contract.Name + method.Name
+}
+
+// inflightFieldName возвращает имя поля семафора параллельных вызовов метода.
+func inflightFieldName(method *parser.Method) string {
+	return "inflight" + method.Name
+}
+
+// limitMethodFunc генерирует функцию проверки ограничений метода.
+func (r *contractRenderer) limitMethodFunc(method *parser.Method) Code {
+
+	serviceName := toLowerCamel(r.contract.Name)
+	methodName := toLowerCamel(method.Name)
+	return Func().Params(Id("http").Op("*").Id("http"+r.contract.Name)).
+		Id("limit"+method.Name).
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context")).
+		Params(Id("release").Func().Params(), Id("rejected").Op("*").Id("errorRejected")).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Id("release").Op("=").Func().Params().Block()
+			if method.Annotations.IsSet(TagMaxInflight) {
+				inflight := Id("http").Dot(inflightFieldName(method))
+				bg.Select().Block(
+					Case(inflight.Clone().Op("<-").Struct().Values()).Block(
+						Id("release").Op("=").Func().Params().Block(Op("<-").Add(inflight.Clone())),
+					),
+					Default().Block(
+						Id("http").Dot("srv").Dot("rejectRequest").Call(Lit(serviceName), Lit(methodName), Id("rejectReasonMaxInflight")),
+						Return(Id("release"), Op("&").Id("errorRejected").Values(Dict{
+							Id("reason"):     Id("rejectReasonMaxInflight"),
+							Id("retryAfter"): Qual(PackageTime, "Second"),
+						})),
+					),
+				)
+			}
+			if method.Annotations.IsSet(TagRateLimit) {
+				key := Lit("")
+				if value := strings.TrimSpace(method.Annotations.Value(TagRateLimitKey, "")); value != "" {
+					key = Id("rateLimitKey").Call(Id(VarNameCtx), Lit(value))
+				}
+				bg.If(List(Id("retryAfter"), Id("allowed")).Op(":=").Id("http").Dot("srv").Dot("allowRequest").Call(Id(VarNameCtx), Lit(serviceName), Lit(methodName), key, Id(r.rateLimitVarName(method))).Op(";").Op("!").Id("allowed")).BlockFunc(func(ig *Group) {
+					if method.Annotations.IsSet(TagMaxInflight) {
+						ig.Id("release").Call()
+					}
+					ig.Return(Func().Params().Block(), Op("&").Id("errorRejected").Values(Dict{
+						Id("reason"):     Id("rejectReasonRateLimit"),
+						Id("retryAfter"): Id("retryAfter"),
+					}))
+				})
+			}
+			bg.Return()
+		})
+}
+
+// limitsCheckJsonRPC генерирует проверку ограничений JSON-RPC метода.
+func (r *contractRenderer) limitsCheckJsonRPC(method *parser.Method, ctx Code, withFiber bool) Code {
+
+	if !methodHasLimits(method) {
+		return Null()
+	}
+	return Line().List(Id("release"), Id("rejected")).Op(":=").Id("http").Dot("limit" + method.Name).Call(ctx).Line().
+		If(Id("rejected").Op("!=").Nil()).BlockFunc(func(ig *Group) {
+		if withFiber {
+			ig.Id(VarNameFtx).Dot("Set").Call(Qual(PackageFiber, "HeaderRetryAfter"), Id("rejected").Dot("RetryAfter").Call())
+		}
+		ig.Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("rateLimitedError"), Id("rejected").Dot("Error").Call(), Id("rejected").Dot("data").Call()))
+	}).Line().
+		Defer().Id("release").Call()
+}
+
+// limitsCheckHTTP генерирует проверку ограничений REST метода.
+func (r *contractRenderer) limitsCheckHTTP(method *parser.Method) Code {
+
+	if !methodHasLimits(method) {
+		return Null()
+	}
+	return List(Id("release"), Id("rejected")).Op(":=").Id("http").Dot("limit"+method.Name).Call(Id(VarNameFtx).Dot("UserContext").Call()).Line().
+		If(Id("rejected").Op("!=").Nil()).Block(
+		Id(VarNameFtx).Dot("Set").Call(Qual(PackageFiber, "HeaderRetryAfter"), Id("rejected").Dot("RetryAfter").Call()),
+		Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusTooManyRequests")),
+		Return().Id("sendResponse").Call(Id(VarNameFtx), Id("rejected").Dot("Error").Call()),
+	).Line().
+		Defer().Id("release").Call()
+}
+
+// durationCode возвращает выражение длительности в наиболее читаемых единицах.
+func durationCode(d time.Duration) Code {
+
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "Hour"},
+		{time.Minute, "Minute"},
+		{time.Second, "Second"},
+		{time.Millisecond, "Millisecond"},
+	}
+	for _, u := range units {
+		if d%u.unit != 0 {
+			continue
+		}
+		if d == u.unit {
+			return Qual(PackageTime, u.name)
+		}
+		return Lit(int(d/u.unit)).Op("*").Qual(PackageTime, u.name)
+	}
+	return Qual(PackageTime, "Duration").Call(Lit(int64(d)))
+}
@@ -12,6 +12,7 @@ import (
 
 	"tgp/internal/parser"
 	"tgp/plugins/server/renderer/types"
+	"tgp/plugins/server/utils"
 )
 
 // RenderLogger генерирует middleware для логирования.
@@ -26,6 +27,8 @@ func (r *contractRenderer) RenderLogger() error {
 
 	srcFile.ImportName(PackageSlog, "slog")
 	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageRand, "rand")
+	srcFile.ImportName(PackageSlices, "slices")
 	srcFile.ImportName(r.contract.PkgPath, filepath.Base(r.contract.PkgPath))
 	srcFile.ImportName(fmt.Sprintf("%s/viewer", r.pkgPath(r.outDir)), "viewer")
 
@@ -36,6 +39,17 @@ func (r *contractRenderer) RenderLogger() error {
 	for _, method := range r.contract.Methods {
 		srcFile.Const().Id("logMethod" + r.contract.Name + method.Name).Op("=").Lit(toLowerCamel(method.Name))
 	}
+	for _, method := range r.contract.Methods {
+		if value := r.logAnnotation(method, TagLogSample); value != "" {
+			rate, _ := utils.ParseLogSample(value)
+			srcFile.Const().Id(r.logSampleName(method)).Op("=").Lit(rate)
+		}
+	}
+	for _, method := range r.contract.Methods {
+		if r.hasLogView(method) {
+			srcFile.Line().Add(r.logViewFunc(method))
+		}
+	}
 
 	srcFile.Type().Id("logger" + r.contract.Name).Struct(
 		Id(VarNameNext).Qual(r.contract.PkgPath, r.contract.Name),
@@ -79,6 +93,12 @@ func (r *contractRenderer) loggerFuncBody(method *parser.Method) func(bg *Group)
 		)
 		bg.Id("_begin").Op(":=").Qual(PackageTime, "Now").Call()
 		bg.Defer().Func().Params().BlockFunc(func(bg *Group) {
+			if r.logAnnotation(method, TagLogSample) != "" {
+				// Успешные вызовы логируются выборочно, ошибки - всегда
+				bg.If(Id("err").Op("==").Nil().Op("&&").Qual(PackageRand, "Float64").Call().Op(">=").Id(r.logSampleName(method))).Block(
+					Return(),
+				)
+			}
 			// Ленивое форматирование: проверяем уровень логирования перед форматированием
 			bg.If(Op("!").Id("sLogger").Dot("Enabled").Call(Id(VarNameCtx), Qual(PackageSlog, "LevelInfo")).Op("&&").Id("err").Op("==").Nil()).Block(
 				Return(), // Логирование отключено, не форматируем
@@ -115,13 +135,13 @@ func (r *contractRenderer) loggerFuncBody(method *parser.Method) func(bg *Group)
 				if !skipRequest {
 					params := removeSkippedFields(r.ArgsFieldsWithoutContext(method), skipFields)
 					originParams := removeSkippedFields(argsWithoutContext(method), skipFields)
-					bg.Id("attrs").Op("=").Append(Id("attrs"), Qual(PackageSlog, "String").Call(Lit("request"), Qual(fmt.Sprintf("%s/viewer", r.pkgPath(r.outDir)), "Sprintf").Call(Lit("%+v"), Id(requestStructName(r.contract.Name, method.Name)).Values(r.dictByNormalVariables(params, originParams)))))
+					bg.Id("attrs").Op("=").Append(Id("attrs"), Qual(PackageSlog, "String").Call(Lit("request"), r.logSprintf(method).Call(Lit("%+v"), Id(requestStructName(r.contract.Name, method.Name)).Values(r.dictByNormalVariables(params, originParams)))))
 				}
 				// Ленивое форматирование response только если не пропущен
 				if !skipResponse {
 					returns := r.ResultFieldsWithoutError(method)
 					originReturns := resultsWithoutError(method)
-					bg.Id("attrs").Op("=").Append(Id("attrs"), Qual(PackageSlog, "String").Call(Lit("response"), Qual(fmt.Sprintf("%s/viewer", r.pkgPath(r.outDir)), "Sprintf").Call(Lit("%+v"), Id(responseStructName(r.contract.Name, method.Name)).Values(r.dictByNormalVariables(returns, originReturns)))))
+					bg.Id("attrs").Op("=").Append(Id("attrs"), Qual(PackageSlog, "String").Call(Lit("response"), r.logSprintf(method).Call(Lit("%+v"), Id(responseStructName(r.contract.Name, method.Name)).Values(r.dictByNormalVariables(returns, originReturns)))))
 				}
 				bg.Id("attrs").Op("=").Append(Id("attrs"), Qual(PackageSlog, "Any").Call(Lit("error"), Err()))
 				bg.Var().Id("args").Index().Any()
@@ -136,12 +156,12 @@ func (r *contractRenderer) loggerFuncBody(method *parser.Method) func(bg *Group)
 			if !skipRequest {
 				params := removeSkippedFields(r.ArgsFieldsWithoutContext(method), skipFields)
 				originParams := removeSkippedFields(argsWithoutContext(method), skipFields)
-				bg.Id("attrs").Op("=").Append(Id("attrs"), Qual(PackageSlog, "String").Call(Lit("request"), Qual(fmt.Sprintf("%s/viewer", r.pkgPath(r.outDir)), "Sprintf").Call(Lit("%+v"), Id(requestStructName(r.contract.Name, method.Name)).Values(r.dictByNormalVariables(params, originParams)))))
+				bg.Id("attrs").Op("=").Append(Id("attrs"), Qual(PackageSlog, "String").Call(Lit("request"), r.logSprintf(method).Call(Lit("%+v"), Id(requestStructName(r.contract.Name, method.Name)).Values(r.dictByNormalVariables(params, originParams)))))
 			}
 			if !skipResponse {
 				returns := r.ResultFieldsWithoutError(method)
 				originReturns := resultsWithoutError(method)
-				bg.Id("attrs").Op("=").Append(Id("attrs"), Qual(PackageSlog, "String").Call(Lit("response"), Qual(fmt.Sprintf("%s/viewer", r.pkgPath(r.outDir)), "Sprintf").Call(Lit("%+v"), Id(responseStructName(r.contract.Name, method.Name)).Values(r.dictByNormalVariables(returns, originReturns)))))
+				bg.Id("attrs").Op("=").Append(Id("attrs"), Qual(PackageSlog, "String").Call(Lit("response"), r.logSprintf(method).Call(Lit("%+v"), Id(responseStructName(r.contract.Name, method.Name)).Values(r.dictByNormalVariables(returns, originReturns)))))
 			}
 			bg.Var().Id("args").Index().Any()
 			bg.For(List(Id("_"), Id("attr")).Op(":=").Range().Id("attrs")).Block(
@@ -174,3 +194,77 @@ func (r *contractRenderer) dictByNormalVariables(fields []*parser.Variable, norm
 		}
 	})
 }
+
+// logAnnotation возвращает значение аннотации логирования метода, а если она не задана - контракта.
+func (r *contractRenderer) logAnnotation(method *parser.Method, tag string) string {
+
+	if method.Annotations.IsSet(tag) {
+		return strings.TrimSpace(method.Annotations.Value(tag))
+	}
+	return strings.TrimSpace(r.contract.Annotations.Value(tag, ""))
+}
+
+// logMaskPaths возвращает маскируемые в логах пути метода вместе с путями контракта.
+func (r *contractRenderer) logMaskPaths(method *parser.Method) (paths []string) {
+
+	contractPaths, _ := utils.ParseLogMask(r.contract.Annotations.Value(TagLogMask, ""))
+	methodPaths, _ := utils.ParseLogMask(method.Annotations.Value(TagLogMask, ""))
+	seen := make(map[string]bool)
+	for _, path := range append(contractPaths, methodPaths...) {
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return
+}
+
+// hasLogView проверяет, нужны ли методу собственные настройки форматирования логов.
+func (r *contractRenderer) hasLogView(method *parser.Method) bool {
+	return len(r.logMaskPaths(method)) > 0 || r.logAnnotation(method, TagLogTruncate) != "" || r.logAnnotation(method, TagLogMaxLen) != ""
+}
+
+// logViewName возвращает имя функции настроек форматирования логов метода.
+func (r *contractRenderer) logViewName(method *parser.Method) string {
+	return "logView" + r.contract.Name + method.Name
+}
+
+// logSampleName возвращает имя константы доли логируемых успешных вызовов метода.
+func (r *contractRenderer) logSampleName(method *parser.Method) string {
+	return "logSample" + r.contract.Name + method.Name
+}
+
+// logViewFunc генерирует функцию настроек форматирования логов метода поверх текущих глобальных настроек viewer.Config.
+func (r *contractRenderer) logViewFunc(method *parser.Method) Code {
+
+	viewerPkg := fmt.Sprintf("%s/viewer", r.pkgPath(r.outDir))
+	return Func().Id(r.logViewName(method)).
+		Params().
+		Params(Op("*").Qual(viewerPkg, "ConfigState")).
+		BlockFunc(func(bg *Group) {
+			bg.Id("view").Op(":=").Qual(viewerPkg, "Config")
+			if paths := r.logMaskPaths(method); len(paths) > 0 {
+				bg.Id("view").Dot("Mask").Op("=").Append(Qual(PackageSlices, "Clip").Call(Id("view").Dot("Mask")), ListFunc(func(g *Group) {
+					for _, path := range paths {
+						g.Lit(path)
+					}
+				}))
+			}
+			if r.logAnnotation(method, TagLogTruncate) != "" {
+				bg.Id("view").Dot("MaxStringLen").Op("=").Lit(method.Annotations.ValueInt(TagLogTruncate, r.contract.Annotations.ValueInt(TagLogTruncate)))
+			}
+			if r.logAnnotation(method, TagLogMaxLen) != "" {
+				bg.Id("view").Dot("MaxLen").Op("=").Lit(method.Annotations.ValueInt(TagLogMaxLen, r.contract.Annotations.ValueInt(TagLogMaxLen)))
+			}
+			bg.Return(Op("&").Id("view"))
+		})
+}
+
+// logSprintf возвращает функцию форматирования запроса и ответа метода для лога.
+func (r *contractRenderer) logSprintf(method *parser.Method) *Statement {
+
+	if r.hasLogView(method) {
+		return Id(r.logViewName(method)).Call().Dot("Sprintf")
+	}
+	return Qual(fmt.Sprintf("%s/viewer", r.pkgPath(r.outDir)), "Sprintf")
+}
@@ -24,7 +24,12 @@ func (r *contractRenderer) RenderMetrics() error {
 	srcFile.ImportName(PackageTime, "time")
 	srcFile.ImportName(r.contract.PkgPath, filepath.Base(r.contract.PkgPath))
 	srcFile.ImportName(PackageFiber, "fiber")
-	srcFile.ImportName(PackagePrometheus, "metrics")
+	if r.metricsOTel() {
+		srcFile.ImportName(PackageAttributeOTEL, "attribute")
+		srcFile.ImportName(PackageMetricOTEL, "metric")
+	} else {
+		srcFile.ImportName(PackagePrometheus, "metrics")
+	}
 
 	typeGen := types.NewGenerator(r.project, &srcFile)
 
@@ -44,11 +49,15 @@ func (r *contractRenderer) RenderMetrics() error {
 	srcFile.Line().Add(r.metricsMiddleware())
 
 	for _, method := range r.contract.Methods {
+		body := r.metricFuncBody(method)
+		if r.metricsOTel() {
+			body = r.metricFuncBodyOTel(method)
+		}
 		srcFile.Line().Func().Params(Id("m").Id("metrics" + r.contract.Name)).
 			Id(method.Name).
 			Params(typeGen.FuncDefinitionParams(method.Args)).
 			Params(typeGen.FuncDefinitionParams(method.Results)).
-			BlockFunc(r.metricFuncBody(method))
+			BlockFunc(body)
 	}
 
 	return srcFile.Save(path.Join(r.outDir, strings.ToLower(r.contract.Name)+"-metrics.go"))
@@ -88,6 +97,14 @@ func (r *contractRenderer) metricFuncBody(method *parser.Method) func(bg *Group)
 			If(Id("m").Dot("metrics").Op("==").Nil()).Block(
 				Return(),
 			),
+			Do(func(s *Statement) {
+				if methodDeprecated(r.contract, method) {
+					s.Id("m").Dot("metrics").Dot("RequestDeprecated").Dot("WithLabelValues").Call(
+						Id("metricService"+r.contract.Name),
+						Id("metricMethod"+r.contract.Name+method.Name)).
+						Dot("Add").Call(Lit(1))
+				}
+			}),
 			Var().Defs(
 				Id("success").Op("=").True(),
 				Id("errCode").Int(),
@@ -149,6 +166,55 @@ func (r *contractRenderer) metricFuncBody(method *parser.Method) func(bg *Group)
 	}
 }
 
+// metricFuncBodyOTel генерирует тело функции для метода с метриками OpenTelemetry.
+func (r *contractRenderer) metricFuncBodyOTel(method *parser.Method) func(bg *Group) {
+
+	return func(bg *Group) {
+
+		system := Id("metricSystemJsonRPC")
+		errCodeDefault := Id("internalError")
+		errCodeAttr := Id("metricAttrJsonRPCErrorCode")
+		if r.methodIsHTTP(method) {
+			system = Id("metricSystemHTTP")
+			errCodeDefault = Qual(PackageFiber, "StatusInternalServerError")
+			errCodeAttr = Id("metricAttrHTTPStatusCode")
+		}
+
+		bg.Line().Defer().Func().Params(Id("_begin").Qual(PackageTime, "Time")).Block(
+			// Проверка на nil для метрик
+			If(Id("m").Dot("metrics").Op("==").Nil()).Block(
+				Return(),
+			),
+			Id("attrs").Op(":=").Index().Qual(PackageAttributeOTEL, "KeyValue").Custom(Options{Open: "{", Close: "}", Separator: ",", Multi: true},
+				Qual(PackageAttributeOTEL, "String").Call(Id("metricAttrSystem"), system),
+				Qual(PackageAttributeOTEL, "String").Call(Id("metricAttrService"), Id("metricService"+r.contract.Name)),
+				Qual(PackageAttributeOTEL, "String").Call(Id("metricAttrMethod"), Id("metricMethod"+r.contract.Name+method.Name)),
+			),
+			Do(func(s *Statement) {
+				if methodDeprecated(r.contract, method) {
+					s.Id("m").Dot("metrics").Dot("RequestDeprecated").Dot("Add").Call(Id(VarNameCtx), Lit(1), Qual(PackageMetricOTEL, "WithAttributes").Call(Id("attrs").Op("...")))
+				}
+			}),
+			If(Err().Op("!=").Nil()).Block(
+				Id("errCode").Op(":=").Add(errCodeDefault),
+				If(List(Id("ec"), Id("ok")).Op(":=").Err().Assert(Id("withErrorCode")).Op(";").Id("ok")).Block(
+					Id("errCode").Op("=").Id("ec").Dot("Code").Call(),
+				),
+				Id("attrs").Op("=").Append(Id("attrs"),
+					Qual(PackageAttributeOTEL, "String").Call(Id("metricAttrErrorType"), Qual(PackageStrconv, "Itoa").Call(Id("errCode"))),
+					Qual(PackageAttributeOTEL, "Int").Call(errCodeAttr, Id("errCode")),
+				),
+				Id("m").Dot("metrics").Dot("RequestErrors").Dot("Add").Call(Id(VarNameCtx), Lit(1), Qual(PackageMetricOTEL, "WithAttributes").Call(Id("attrs").Op("..."))),
+			),
+			Id("attrSet").Op(":=").Qual(PackageMetricOTEL, "WithAttributes").Call(Id("attrs").Op("...")),
+			Id("m").Dot("metrics").Dot("RequestCount").Dot("Add").Call(Id(VarNameCtx), Lit(1), Id("attrSet")),
+			Id("m").Dot("metrics").Dot("RequestDuration").Dot("Record").Call(Id(VarNameCtx), Float64().Call(Qual(PackageTime, "Since").Call(Id("_begin")).Dot("Microseconds").Call()).Op("/").Lit(1000), Id("attrSet")),
+		).Call(Qual(PackageTime, "Now").Call())
+
+		bg.Line().Return().Id("m").Dot(VarNameNext).Dot(method.Name).Call(r.paramNames(method.Args))
+	}
+}
+
 // methodIsHTTP проверяет, является ли метод HTTP методом.
 func (r *contractRenderer) methodIsHTTP(method *parser.Method) bool {
 
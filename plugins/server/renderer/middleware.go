@@ -3,21 +3,24 @@
 package renderer
 
 import (
+	"fmt"
 	"path"
 	"path/filepath"
 	"strings"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
 
+	"tgp/internal/parser"
 	"tgp/plugins/server/renderer/types"
 )
 
-// RenderMiddleware генерирует типы middleware.
+// RenderMiddleware генерирует типы middleware, функции их объединения и адаптеры CallMiddleware.
 func (r *contractRenderer) RenderMiddleware() error {
 
 	srcFile := NewSrcFile(filepath.Base(r.outDir))
 	srcFile.PackageComment(DoNotEdit)
 
+	srcFile.ImportName(PackageContext, "context")
 	srcFile.ImportName(r.contract.PkgPath, filepath.Base(r.contract.PkgPath))
 
 	typeGen := types.NewGenerator(r.project, &srcFile)
@@ -42,5 +45,130 @@ func (r *contractRenderer) RenderMiddleware() error {
 			Params(Id(r.contract.Name + method.Name))
 	}
 
+	srcFile.Line().Commentf("Chain%s объединяет middleware контракта: первое в списке вызывается первым.", r.contract.Name)
+	srcFile.Add(r.chainFunc(r.contract.Name, Qual(r.contract.PkgPath, r.contract.Name)))
+	for _, method := range r.contract.Methods {
+		srcFile.Line().Add(r.chainFunc(r.contract.Name+method.Name, Id(r.contract.Name+method.Name)))
+	}
+
+	for _, method := range r.contract.Methods {
+		srcFile.Line().Add(r.callMiddlewareFunc(typeGen, method))
+	}
+
 	return srcFile.Save(path.Join(r.outDir, strings.ToLower(r.contract.Name)+"-middleware.go"))
 }
+
+// chainFunc генерирует функцию объединения middleware для обёртки с указанным именем.
+func (r *contractRenderer) chainFunc(name string, wrapped Code) Code {
+
+	return Func().Id("Chain" + name).
+		Params(Id("mws").Op("...").Id("Middleware" + name)).
+		Params(Id("Middleware" + name)).
+		Block(
+			Return(Func().Params(Id("next").Add(wrapped)).Params(wrapped).Block(
+				For(Id("i").Op(":=").Len(Id("mws")).Op("-").Lit(1), Id("i").Op(">=").Lit(0), Id("i").Op("--")).Block(
+					Id("next").Op("=").Id("mws").Index(Id("i")).Call(Id("next")),
+				),
+				Return(Id("next")),
+			)),
+		)
+}
+
+// callMiddlewareFunc генерирует адаптер универсального CallMiddleware к middleware метода.
+func (r *contractRenderer) callMiddlewareFunc(typeGen *types.Generator, method *parser.Method) Code {
+
+	methodType := r.contract.Name + method.Name
+	args := argsWithoutContext(method)
+	results := resultsWithoutError(method)
+	hasCtx := len(args) != len(method.Args)
+	hasErr := len(results) != len(method.Results)
+
+	handler := Func().
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("_").String(), Id("args").Index().Any()).
+		Params(Index().Any(), Error()).
+		BlockFunc(func(bg *Group) {
+			call := Id("next").CallFunc(func(cg *Group) {
+				if hasCtx {
+					cg.Id(VarNameCtx)
+				}
+				for i, arg := range args {
+					argCode := Id("callValue").Types(typeGen.FieldTypeFromVariable(arg, false)).Call(Id("args"), Lit(i))
+					if arg.IsEllipsis {
+						argCode.Op("...")
+					}
+					cg.Add(argCode)
+				}
+			})
+			values := Index().Any().ValuesFunc(func(vg *Group) {
+				for i := range results {
+					vg.Id(fmt.Sprintf("r%d", i))
+				}
+			})
+			vars := ListFunc(func(lg *Group) {
+				for i := range results {
+					lg.Id(fmt.Sprintf("r%d", i))
+				}
+				if hasErr {
+					lg.Err()
+				}
+			})
+			switch {
+			case len(method.Results) == 0:
+				bg.Add(call)
+				bg.Return(Nil(), Nil())
+			case hasErr:
+				bg.Add(vars).Op(":=").Add(call)
+				bg.Return(values, Err())
+			default:
+				bg.Add(vars).Op(":=").Add(call)
+				bg.Return(values, Nil())
+			}
+		})
+
+	ctx := Qual(PackageContext, "Background").Call()
+	if hasCtx {
+		ctx = Id(toLowerCamel(method.Args[0].Name))
+	}
+
+	typed := Func().
+		Params(typeGen.FuncDefinitionParams(method.Args)).
+		Params(typeGen.FuncDefinitionParams(method.Results)).
+		BlockFunc(func(bg *Group) {
+			callResults := "_"
+			if len(results) != 0 {
+				callResults = "callResults"
+			}
+			callErr := "_"
+			if hasErr {
+				callErr = "callErr"
+			}
+			call := Id("callHandler").Call(ctx, Lit(r.contract.Name+"."+method.Name), Index().Any().ValuesFunc(func(vg *Group) {
+				for _, arg := range args {
+					vg.Id(toLowerCamel(arg.Name))
+				}
+			}))
+			if len(method.Results) == 0 {
+				bg.Add(call)
+				return
+			}
+			bg.List(Id(callResults), Id(callErr)).Op(":=").Add(call)
+			bg.ReturnFunc(func(rg *Group) {
+				for i, result := range results {
+					rg.Id("callValue").Types(typeGen.FieldTypeFromVariable(result, false)).Call(Id("callResults"), Lit(i))
+				}
+				if hasErr {
+					rg.Id("callErr")
+				}
+			})
+		})
+
+	return Func().Id("callMiddleware" + methodType).
+		Params(Id("m").Id("CallMiddleware")).
+		Params(Id("Middleware" + methodType)).
+		Block(
+			Return(Func().Params(Id("next").Id(methodType)).Params(Id(methodType)).Block(
+				Id("callHandler").Op(":=").Id("m").Call(handler),
+				Return(typed),
+			)),
+		)
+}
@@ -28,9 +28,11 @@ var (
 	percentBytes       = []byte("%")
 	precisionBytes     = []byte(".")
 	// openAngleBytes     = []byte("<")
-	// closeAngleBytes    = []byte(">")
-	openMapBytes  = []byte("map[")
-	closeMapBytes = []byte("]")
+	closeAngleBytes = []byte(">")
+	openMapBytes    = []byte("map[")
+	closeMapBytes   = []byte("]")
+	maskedBytes     = []byte("***")
+	truncatedBytes  = []byte("...<len=")
 )
 
 var hexDigits = "0123456789abcdef"
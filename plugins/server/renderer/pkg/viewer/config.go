@@ -1,8 +1,42 @@
 package viewer
 
+import (
+	"fmt"
+)
+
 type ConfigState struct {
-	Indent   string
-	MaxDepth int
+	Indent       string
+	MaxDepth     int
+	MaxStringLen int
+	MaxLen       int
+	Mask         []string
 }
 
 var Config = ConfigState{Indent: " "}
+
+func (c *ConfigState) Sprintf(format string, a ...interface{}) string {
+	return c.truncate(fmt.Sprintf(format, c.convertArgs(a)...))
+}
+
+func (c *ConfigState) Sprint(a ...interface{}) string {
+	return c.truncate(fmt.Sprint(c.convertArgs(a)...))
+}
+
+func (c *ConfigState) NewFormatter(v interface{}) fmt.Formatter {
+	return newFormatter(c, v)
+}
+
+func (c *ConfigState) convertArgs(args []interface{}) (formatters []interface{}) {
+	formatters = make([]interface{}, len(args))
+	for index, arg := range args {
+		formatters[index] = newFormatter(c, arg)
+	}
+	return formatters
+}
+
+func (c *ConfigState) truncate(view string) string {
+	if c.MaxLen <= 0 || len(view) <= c.MaxLen {
+		return view
+	}
+	return string(truncateBytes([]byte(view), c.MaxLen))
+}
@@ -20,6 +20,7 @@ type formatState struct {
 	pointers       map[uintptr]int
 	ignoreNextType bool
 	cs             *ConfigState
+	masks          [][]string
 }
 
 func (f *formatState) buildDefaultFormat() (format string) {
@@ -132,7 +133,7 @@ func (f *formatState) format(v reflect.Value, opts ...option) {
 			return
 		}
 		if values := toString.Call(nil); len(values) == 1 {
-			_, _ = f.fs.Write(applyOptions([]byte(values[0].String()), opts...))
+			_, _ = f.fs.Write(truncateBytes(applyOptions([]byte(values[0].String()), opts...), f.cs.MaxStringLen))
 			return
 		}
 	}
@@ -230,7 +231,7 @@ func (f *formatState) format(v reflect.Value, opts ...option) {
 		_, _ = f.fs.Write(closeBracketBytes)
 
 	case reflect.String:
-		_, _ = f.fs.Write(applyOptions([]byte(v.String()), opts...))
+		_, _ = f.fs.Write(truncateBytes(applyOptions([]byte(v.String()), opts...), f.cs.MaxStringLen))
 
 	case reflect.Interface:
 
@@ -288,7 +289,15 @@ func (f *formatState) format(v reflect.Value, opts ...option) {
 					_, _ = f.fs.Write([]byte(vtf.Name))
 					_, _ = f.fs.Write(colonBytes)
 				}
+				masked, nested := matchMasks(f.masks, vtf)
+				if masked || isSensitive(vtf) {
+					_, _ = f.fs.Write(maskedBytes)
+					continue
+				}
+				masks := f.masks
+				f.masks = nested
 				f.format(f.unpackValue(v.Field(i)), tagToOption(vtf.Tag.Get(tagName)))
+				f.masks = masks
 			}
 		}
 		f.depth--
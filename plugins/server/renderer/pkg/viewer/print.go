@@ -9,11 +9,11 @@ func Sprintln(a ...interface{}) string {
 }
 
 func Sprintf(format string, a ...interface{}) string {
-	return fmt.Sprintf(format, convertArgs(a)...)
+	return Config.Sprintf(format, a...)
 }
 
 func Sprint(a ...interface{}) string {
-	return fmt.Sprint(convertArgs(a)...)
+	return Config.Sprint(a...)
 }
 
 func Printf(format string, a ...interface{}) (n int, err error) {
@@ -21,15 +21,11 @@ func Printf(format string, a ...interface{}) (n int, err error) {
 }
 
 func convertArgs(args []interface{}) (formatters []interface{}) {
-	formatters = make([]interface{}, len(args))
-	for index, arg := range args {
-		formatters[index] = NewFormatter(arg)
-	}
-	return formatters
+	return Config.convertArgs(args)
 }
 
 func newFormatter(cs *ConfigState, v interface{}) fmt.Formatter {
-	fs := &formatState{value: v, cs: cs}
+	fs := &formatState{value: v, cs: cs, masks: splitMasks(cs.Mask)}
 	fs.pointers = make(map[uintptr]int)
 	return fs
 }
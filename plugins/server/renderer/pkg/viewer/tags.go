@@ -1,10 +1,16 @@
 package viewer
 
 import (
+	"reflect"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
-const tagName = "dumper"
+const (
+	tagName      = "dumper"
+	tagSensitive = "sensitive"
+)
 
 func tagToOption(tag string) (opt option) {
 
@@ -16,3 +22,72 @@ func tagToOption(tag string) (opt option) {
 	}
 	return
 }
+
+func isSensitive(field reflect.StructField) bool {
+
+	if value, found := field.Tag.Lookup(tagSensitive); found {
+		if value == "" {
+			return true
+		}
+		sensitive, _ := strconv.ParseBool(value)
+		return sensitive
+	}
+	return field.Tag.Get(tagName) == tagSensitive
+}
+
+func splitMasks(paths []string) (masks [][]string) {
+
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path != "" {
+			masks = append(masks, strings.Split(path, "."))
+		}
+	}
+	return
+}
+
+func fieldNames(field reflect.StructField) (names []string) {
+
+	names = append(names, field.Name)
+	if jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ","); jsonName != "" && jsonName != "-" {
+		names = append(names, jsonName)
+	}
+	return
+}
+
+func matchMasks(masks [][]string, field reflect.StructField) (masked bool, nested [][]string) {
+
+	names := fieldNames(field)
+	for _, mask := range masks {
+		matched := false
+		for _, name := range names {
+			if strings.EqualFold(mask[0], name) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if len(mask) == 1 {
+			return true, nil
+		}
+		nested = append(nested, mask[1:])
+	}
+	return false, nested
+}
+
+func truncateBytes(view []byte, maxLen int) []byte {
+
+	if maxLen <= 0 || len(view) <= maxLen {
+		return view
+	}
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(view[cut]) {
+		cut--
+	}
+	truncated := make([]byte, 0, cut+len(truncatedBytes)+8)
+	truncated = append(truncated, view[:cut]...)
+	truncated = append(truncated, truncatedBytes...)
+	truncated = append(truncated, strconv.Itoa(len(view))...)
+	return append(truncated, closeAngleBytes...)
+}
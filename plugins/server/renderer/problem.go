@@ -0,0 +1,55 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+)
+
+// contractProblem проверяет, отдают ли REST методы контракта ошибки в формате application/problem+json.
+func contractProblem(contract *parser.Contract) bool {
+	return strings.EqualFold(strings.TrimSpace(contract.Annotations.Value(TagHttpErrors, "")), HttpErrorsProblem)
+}
+
+// hasProblem проверяет, есть ли контракты с ошибками в формате application/problem+json.
+func (r *baseRenderer) hasProblem() bool {
+
+	for _, contract := range r.project.Contracts {
+		if contractProblem(contract) {
+			return true
+		}
+	}
+	return false
+}
+
+// httpErrorResponse генерирует ответ REST метода на некорректный запрос.
+func (r *contractRenderer) httpErrorResponse(status Code, message Code) Code {
+
+	if contractProblem(r.contract) {
+		return Return().Id("sendProblem").Call(Id(VarNameFtx), status, Qual(PackageErrors, "New").Call(message))
+	}
+	return Id(VarNameFtx).Dot("Status").Call(status).Line().
+		Return().Id("sendResponse").Call(Id(VarNameFtx), message)
+}
+
+// httpMethodErrorResponse генерирует ответ REST метода с ошибкой, которую вернул сервис.
+func (r *contractRenderer) httpMethodErrorResponse() Code {
+
+	if contractProblem(r.contract) {
+		return Id("status").Op(":=").Qual(PackageFiber, "StatusInternalServerError").Line().
+			If(List(Id("errCoder"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withErrorCode")).Op(";").Id("ok")).Block(
+			Id("status").Op("=").Id("errCoder").Dot("Code").Call(),
+		).Line().
+			Return().Id("sendProblem").Call(Id(VarNameFtx), Id("status"), Err())
+	}
+	return If(List(Id("errCoder"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withErrorCode")).Op(";").Id("ok")).Block(
+		Id(VarNameFtx).Dot("Status").Call(Id("errCoder").Dot("Code").Call()),
+	).Else().Block(
+		Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusInternalServerError")),
+	).Line().
+		Return().Id("sendResponse").Call(Id(VarNameFtx), Err())
+}
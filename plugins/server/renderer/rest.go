@@ -84,13 +84,31 @@ func (r *contractRenderer) httpServeMethodFunc(srcFile *GoFile, typeGen *types.G
 		BlockFunc(func(bg *Group) {
 			bg.Line()
 			bg.Var().Id("request").Id(requestStructName(r.contract.Name, method.Name))
+			bg.Add(r.deprecationHeaders(method))
+			bg.Add(r.limitsCheckHTTP(method))
+			bg.Add(r.idempotencyCheckHTTP(method))
+			bg.Add(r.cacheCheckHTTP(method))
 			if successCodeStr := method.Annotations.Value(TagHttpSuccess, ""); successCodeStr != "" {
 				if successCode, err := strconv.Atoi(successCodeStr); err == nil && successCode != 0 {
 					bg.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Lit(successCode))
 				}
 			}
-			if len(r.arguments(method)) != 0 {
-				bg.If(Err().Op("=").Id(VarNameFtx).Dot("BodyParser").Call(Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+			switch {
+			case r.methodBodyMode(method) == bodyModeRaw:
+				bg.Add(r.httpRawBody(method))
+			case r.methodBodyMode(method) == bodyModeMultipart:
+				bg.Add(r.httpMultipartBody(srcFile, typeGen, method))
+			case len(r.arguments(method)) != 0:
+				bg.If(Id("codec").Op(":=").Id("requestCodec").Call(Id(VarNameFtx).Dot("UserContext").Call()).Op(";").Id("codec").Op("!=").Nil()).Block(
+					Err().Op("=").Id("codec").Dot("Unmarshal").Call(Id(VarNameFtx).Dot("Body").Call(), Op("&").Id("request")),
+				).Else().Block(
+					Err().Op("=").Id(VarNameFtx).Dot("BodyParser").Call(Op("&").Id("request")),
+				)
+				bg.If(Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+					if contractProblem(r.contract) {
+						ig.Add(r.httpErrorResponse(Qual(PackageFiber, "StatusBadRequest"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call()))
+						return
+					}
 					ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(PackageFiber, "StatusBadRequest"))
 					ig.List(Id("_"), Err()).Op("=").Id(VarNameFtx).Dot("WriteString").Call(Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call())
 					ig.Return()
@@ -98,26 +116,22 @@ func (r *contractRenderer) httpServeMethodFunc(srcFile *GoFile, typeGen *types.G
 			}
 			bg.Add(r.urlArgs(srcFile, typeGen, method, func(arg, header string) *Statement {
 				return Line().If(Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-					ig.Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest"))
-					ig.Return().Id("sendResponse").Call(Id(VarNameFtx), Lit("path arguments could not be decoded: ").Op("+").Err().Dot("Error").Call())
+					ig.Add(r.httpErrorResponse(Qual(PackageFiber, "StatusBadRequest"), Lit("path arguments could not be decoded: ").Op("+").Err().Dot("Error").Call()))
 				})
 			}))
 			bg.Add(r.urlParams(srcFile, typeGen, method, func(arg, header string) *Statement {
 				return Line().If(Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-					ig.Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest"))
-					ig.Return().Id("sendResponse").Call(Id(VarNameFtx), Lit("url arguments could not be decoded: ").Op("+").Err().Dot("Error").Call())
+					ig.Add(r.httpErrorResponse(Qual(PackageFiber, "StatusBadRequest"), Lit("url arguments could not be decoded: ").Op("+").Err().Dot("Error").Call()))
 				})
 			}))
 			bg.Add(r.httpArgHeaders(srcFile, typeGen, method, func(arg, header string) *Statement {
 				return Line().If(Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-					ig.Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest"))
-					ig.Return().Id("sendResponse").Call(Id(VarNameFtx), Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call())
+					ig.Add(r.httpErrorResponse(Qual(PackageFiber, "StatusBadRequest"), Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call()))
 				})
 			}))
 			bg.Add(r.httpCookies(srcFile, typeGen, method, func(arg, header string) *Statement {
 				return Line().If(Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-					ig.Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusBadRequest"))
-					ig.Return().Id("sendResponse").Call(Id(VarNameFtx), Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call())
+					ig.Add(r.httpErrorResponse(Qual(PackageFiber, "StatusBadRequest"), Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call()))
 				})
 			}))
 			if responseMethod := method.Annotations.Value(TagHttpResponse, ""); responseMethod != "" {
@@ -153,18 +167,17 @@ func (r *contractRenderer) httpServeMethodFunc(srcFile *GoFile, typeGen *types.G
 					if len(ex) > 0 {
 						bf.Add(&ex)
 					}
+					if ret := r.streamResult(method); ret != nil {
+						bf.Add(r.httpStreamResponse(method, ret))
+						return
+					}
 					if len(resultsWithoutError(method)) == 1 && method.Annotations.Contains(TagHttpEnableInlineSingle) {
 						bf.Return().Id("sendResponse").Call(Id(VarNameFtx), Id("response").Dot(toCamel(resultsWithoutError(method)[0].Name)))
 					} else {
 						bf.Return().Id("sendResponse").Call(Id(VarNameFtx), Id("response"))
 					}
 				})
-				bg.If(List(Id("errCoder"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withErrorCode")).Op(";").Id("ok")).Block(
-					Id(VarNameFtx).Dot("Status").Call(Id("errCoder").Dot("Code").Call()),
-				).Else().Block(
-					Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusInternalServerError")),
-				)
-				bg.Return().Id("sendResponse").Call(Id(VarNameFtx), Err())
+				bg.Add(r.httpMethodErrorResponse())
 			}
 		})
 }
@@ -55,6 +55,8 @@ func (r *contractRenderer) RenderServer() error {
 			)
 	}
 
+	srcFile.Line().Add(r.withCallMiddlewareFunc())
+
 	if r.contract.Annotations.Contains(TagTrace) {
 		srcFile.Line().Func().Params(Id("srv").Op("*").Id("server" + r.contract.Name)).Id("WithTrace").Params().Block(
 			Id("srv").Dot("Wrap").Call(Id("traceMiddleware" + r.contract.Name)),
@@ -136,9 +138,26 @@ func (r *contractRenderer) wrapFunc(typeGen *types.Generator) Code {
 		Id("Wrap").
 		Params(Id("m").Id("Middleware" + r.contract.Name)).
 		BlockFunc(func(bg *Group) {
-			bg.Id("srv").Dot("svc").Op("=").Id("m").Call(Id("srv").Dot("svc"))
+			bg.Comment("Оборачиваем текущее состояние, чтобы сохранить middleware, добавленные через Wrap<Method>")
+			bg.Id("current").Op(":=").Op("*").Id("srv")
+			bg.Id("srv").Dot("svc").Op("=").Id("m").Call(Op("&").Id("current"))
 			for _, method := range r.contract.Methods {
 				bg.Id("srv").Dot(toLowerCamel(method.Name)).Op("=").Id("srv").Dot("svc").Dot(method.Name)
 			}
 		})
 }
+
+// withCallMiddlewareFunc генерирует применение универсальных middleware транспорта к методам контракта.
+func (r *contractRenderer) withCallMiddlewareFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("server" + r.contract.Name)).
+		Id("withCallMiddleware").
+		Params(Id("lookup").Func().Params(Id("method").String()).Id("CallMiddleware")).
+		BlockFunc(func(bg *Group) {
+			for _, method := range r.contract.Methods {
+				bg.If(Id("m").Op(":=").Id("lookup").Call(Lit(r.contract.Name+"."+method.Name)), Id("m").Op("!=").Nil()).Block(
+					Id("srv").Dot("Wrap" + method.Name).Call(Id("callMiddleware" + r.contract.Name + method.Name).Call(Id("m"))),
+				)
+			}
+		})
+}
@@ -9,17 +9,26 @@ package renderer
 
 // Заглушки для contractRenderer методов, которые не требуют контракта
 
-func (r *contractRenderer) RenderTransportHTTP() error    { return nil }
-func (r *contractRenderer) RenderTransportContext() error { return nil }
-func (r *contractRenderer) RenderTransportLogger() error  { return nil }
-func (r *contractRenderer) RenderTransportFiber() error   { return nil }
-func (r *contractRenderer) RenderTransportHeader() error  { return nil }
-func (r *contractRenderer) RenderTransportErrors() error  { return nil }
-func (r *contractRenderer) RenderTransportServer() error  { return nil }
-func (r *contractRenderer) RenderTransportOptions() error { return nil }
-func (r *contractRenderer) RenderTransportMetrics() error { return nil }
-func (r *contractRenderer) RenderTransportVersion() error { return nil }
-func (r *contractRenderer) RenderTransportJsonRPC() error { return nil }
+func (r *contractRenderer) RenderTransportHTTP() error        { return nil }
+func (r *contractRenderer) RenderTransportContext() error     { return nil }
+func (r *contractRenderer) RenderTransportLogger() error      { return nil }
+func (r *contractRenderer) RenderTransportFiber() error       { return nil }
+func (r *contractRenderer) RenderTransportHeader() error      { return nil }
+func (r *contractRenderer) RenderTransportErrors() error      { return nil }
+func (r *contractRenderer) RenderTransportServer() error      { return nil }
+func (r *contractRenderer) RenderTransportOptions() error     { return nil }
+func (r *contractRenderer) RenderTransportMetrics() error     { return nil }
+func (r *contractRenderer) RenderTransportVersion() error     { return nil }
+func (r *contractRenderer) RenderTransportJsonRPC() error     { return nil }
+func (r *contractRenderer) RenderTransportLimits() error      { return nil }
+func (r *contractRenderer) RenderTransportIdempotency() error { return nil }
+func (r *contractRenderer) RenderTransportCache() error       { return nil }
+func (r *contractRenderer) RenderTransportProblem() error     { return nil }
+func (r *contractRenderer) RenderTransportHealth() error      { return nil }
+func (r *contractRenderer) RenderTransportCodec() error       { return nil }
+func (r *contractRenderer) RenderTransportMiddleware() error  { return nil }
+func (r *contractRenderer) RenderTransportHooks() error       { return nil }
+func (r *contractRenderer) RenderTransportTesting() error     { return nil }
 
 // Заглушки для transportRenderer методов, которые требуют контракта
 
@@ -27,8 +36,11 @@ func (r *transportRenderer) RenderHTTP() error       { return nil }
 func (r *transportRenderer) RenderServer() error     { return nil }
 func (r *transportRenderer) RenderExchange() error   { return nil }
 func (r *transportRenderer) RenderMiddleware() error { return nil }
+func (r *transportRenderer) RenderHooks() error      { return nil }
 func (r *transportRenderer) RenderTrace() error      { return nil }
 func (r *transportRenderer) RenderMetrics() error    { return nil }
 func (r *transportRenderer) RenderLogger() error     { return nil }
 func (r *transportRenderer) RenderJsonRPC() error    { return nil }
 func (r *transportRenderer) RenderREST() error       { return nil }
+func (r *transportRenderer) RenderLimits() error     { return nil }
+func (r *transportRenderer) RenderTesting() error    { return nil }
@@ -0,0 +1,384 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+	"tgp/plugins/server/renderer/types"
+)
+
+// RenderTesting генерирует типизированные помощники вызова методов контракта для пакета testing.
+func (r *contractRenderer) RenderTesting() error {
+
+	srcFile := NewSrcFile(testingPkgName)
+	srcFile.PackageComment(DoNotEdit)
+
+	jsonPkg := r.project.Annotations.Value(TagPackageJSON, PackageStdJSON)
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageNetHTTP, "http")
+	srcFile.ImportName(PackageNetURL, "url")
+	srcFile.ImportName(PackageBytes, "bytes")
+	srcFile.ImportName(PackageFmt, "fmt")
+	srcFile.ImportName(PackageIO, "io")
+	srcFile.ImportName(PackageMultipart, "multipart")
+	srcFile.ImportName(PackageTextproto, "textproto")
+	srcFile.ImportName(jsonPkg, "json")
+
+	typeGen := types.NewGenerator(r.project, &srcFile)
+
+	srcFile.Line().Commentf("%s - типизированные вызовы методов контракта %s через транспорт.", r.contract.Name, r.contract.Name)
+	srcFile.Type().Id(r.contract.Name).Struct(
+		Id("srv").Op("*").Id("Server"),
+	)
+
+	srcFile.Line().Commentf("%s возвращает помощник вызова методов контракта %s.", r.contract.Name, r.contract.Name)
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id(r.contract.Name).Params().Op("*").Id(r.contract.Name).Block(
+		Return(Op("&").Id(r.contract.Name).Values(Dict{Id("srv"): Id("srv")})),
+	)
+
+	for _, method := range r.contract.Methods {
+		if !r.methodIsJsonRPC(method) && !r.methodIsHTTP(method) {
+			continue
+		}
+		testable := r.methodIsTestable(method)
+		if r.testingRequestUsed(method) {
+			srcFile.Line().Add(r.exchangeStruct(typeGen, requestStructName(r.contract.Name, method.Name), r.fieldsArgument(method)))
+		}
+		if !testable {
+			srcFile.Line().Add(r.testingRawMethod(typeGen, method, jsonPkg))
+			continue
+		}
+		if !r.testingInlineSingle(method) {
+			srcFile.Line().Add(r.exchangeStruct(typeGen, responseStructName(r.contract.Name, method.Name), r.fieldsResult(method)))
+		}
+		if r.methodIsJsonRPC(method) {
+			srcFile.Line().Add(r.testingJsonRPCMethod(typeGen, method))
+			continue
+		}
+		srcFile.Line().Add(r.testingHTTPMethod(typeGen, method, jsonPkg))
+	}
+
+	return srcFile.Save(path.Join(r.outDir, testingPkgName, strings.ToLower(r.contract.Name)+".go"))
+}
+
+// methodIsTestable проверяет, можно ли сгенерировать типизированный вызов метода (для multipart, потоков и http-response генерируется вызов с телом без разбора).
+func (r *contractRenderer) methodIsTestable(method *parser.Method) bool {
+
+	if method.Annotations.Value(TagHttpResponse, "") != "" || r.methodBodyMode(method) == bodyModeMultipart || r.streamResult(method) != nil {
+		return false
+	}
+	return !r.testingHasInline(method)
+}
+
+// testingHasInline проверяет, есть ли у аргументов или результатов метода встраиваемые (inline) поля, которые нельзя передать через структуру запроса.
+func (r *contractRenderer) testingHasInline(method *parser.Method) bool {
+
+	for _, field := range append(r.fieldsArgument(method), r.fieldsResult(method)...) {
+		if strings.Contains(field.tags["json"], "inline") {
+			return true
+		}
+	}
+	return false
+}
+
+// testingInlineSingle проверяет, возвращается ли единственный результат метода без обёртки.
+func (r *contractRenderer) testingInlineSingle(method *parser.Method) bool {
+	return len(resultsWithoutError(method)) == 1 && method.Annotations.Contains(TagHttpEnableInlineSingle)
+}
+
+// testingBodyMode возвращает способ передачи тела запроса REST метода из помощника.
+func (r *contractRenderer) testingBodyMode(method *parser.Method) string {
+
+	switch {
+	case r.methodBodyMode(method) == bodyModeMultipart:
+		return bodyModeMultipart
+	case r.methodBodyMode(method) == bodyModeRaw && len(r.binaryArgs(method)) != 0:
+		return bodyModeRaw
+	case len(r.arguments(method)) != 0:
+		return bodyModeJSON
+	}
+	return bodyModeNone
+}
+
+// testingRequestUsed проверяет, передаются ли аргументы метода структурой запроса.
+func (r *contractRenderer) testingRequestUsed(method *parser.Method) bool {
+
+	if r.methodIsJsonRPC(method) {
+		return r.methodIsTestable(method)
+	}
+	return r.testingBodyMode(method) == bodyModeJSON && !r.testingHasInline(method)
+}
+
+// testingRequest генерирует заполнение структуры запроса из аргументов метода.
+func (r *contractRenderer) testingRequest(method *parser.Method) *Statement {
+
+	return Id("request").Op(":=").Id(requestStructName(r.contract.Name, method.Name)).ValuesFunc(func(vg *Group) {
+		for _, arg := range argsWithoutContext(method) {
+			if isStreamVar(arg) {
+				continue
+			}
+			vg.Id(toCamel(arg.Name)).Op(":").Id(arg.Name)
+		}
+	})
+}
+
+// testingResults генерирует разбор ответа в результаты метода.
+func (r *contractRenderer) testingResults(method *parser.Method, call func(result Code) Code) []Code {
+
+	results := resultsWithoutError(method)
+	if r.testingInlineSingle(method) {
+		return []Code{Err().Op("=").Add(call(Op("&").Id(results[0].Name))), Return()}
+	}
+	codes := []Code{
+		Var().Id("response").Id(responseStructName(r.contract.Name, method.Name)),
+		If(Err().Op("=").Add(call(Op("&").Id("response"))).Op(";").Err().Op("!=").Nil()).Block(Return()),
+	}
+	for _, ret := range results {
+		codes = append(codes, Id(ret.Name).Op("=").Id("response").Dot(toCamel(ret.Name)))
+	}
+	return append(codes, Return())
+}
+
+// testingJsonRPCMethod генерирует типизированный вызов JSON-RPC метода.
+func (r *contractRenderer) testingJsonRPCMethod(typeGen *types.Generator, method *parser.Method) Code {
+
+	return Func().Params(Id("client").Op("*").Id(r.contract.Name)).
+		Id(method.Name).
+		Params(typeGen.FuncDefinitionParams(method.Args)).
+		Params(typeGen.FuncDefinitionParams(method.Results)).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Add(r.testingRequest(method))
+			for _, code := range r.testingResults(method, func(result Code) Code {
+				return Id("client").Dot("srv").Dot("callJsonRPC").Call(Id(VarNameCtx), Lit(jsonRPCMethodName(r.contract, method)), Id("request"), result)
+			}) {
+				bg.Add(code)
+			}
+		})
+}
+
+// testingSuccessCode возвращает HTTP статус успешного ответа REST метода.
+func (r *contractRenderer) testingSuccessCode(method *parser.Method) int {
+
+	if code, err := strconv.Atoi(method.Annotations.Value(TagHttpSuccess, "")); err == nil && code != 0 {
+		return code
+	}
+	return 200
+}
+
+// testingRawMethod генерирует вызов метода без типизированного разбора ответа: REST метод принимает аргументы метода
+// и возвращает тело ответа, JSON-RPC метод принимает параметры и результат для сериализации.
+func (r *contractRenderer) testingRawMethod(typeGen *types.Generator, method *parser.Method, jsonPkg string) Code {
+
+	var ctxArgs []*parser.Variable
+	if len(method.Args) != 0 && method.Args[0].TypeID == "context:Context" {
+		ctxArgs = append(ctxArgs, method.Args[0])
+	}
+	if r.methodIsJsonRPC(method) {
+		return Commentf("%s вызывает JSON-RPC метод %s с параметрами params и разбирает результат в result.", method.Name, method.Name).Line().
+			Func().Params(Id("client").Op("*").Id(r.contract.Name)).
+			Id(method.Name).
+			Params(typeGen.FuncDefinitionParams(ctxArgs), List(Id("params"), Id("result")).Any()).
+			Params(Err().Error()).
+			Block(
+				Return(Id("client").Dot("srv").Dot("callJsonRPC").Call(Id(VarNameCtx), Lit(jsonRPCMethodName(r.contract, method)), Id("params"), Id("result"))),
+			)
+	}
+	if r.testingBodyMode(method) == bodyModeJSON && r.testingHasInline(method) {
+		// встраиваемые поля не передаются структурой запроса: тело JSON формирует вызывающий
+		params := ctxArgs
+		bodyArgs := r.arguments(method)
+		for _, arg := range argsWithoutContext(method) {
+			if !slices.Contains(bodyArgs, arg) {
+				params = append(params, arg)
+			}
+		}
+		return Commentf("%s вызывает метод %s с JSON телом запроса body и возвращает тело ответа без разбора.", method.Name, method.Name).Line().
+			Func().Params(Id("client").Op("*").Id(r.contract.Name)).
+			Id(method.Name).
+			Params(typeGen.FuncDefinitionParams(params), Id("body").Qual(PackageIO, "Reader")).
+			Params(Id("respBody").Index().Byte(), Err().Error()).
+			BlockFunc(func(bg *Group) {
+				bg.Line()
+				r.testingHTTPRequest(bg, method, Id("httpRequest").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Lit("application/json")))
+				bg.Return(Id("client").Dot("srv").Dot("roundTrip").Call(Id("httpRequest"), Lit(r.testingSuccessCode(method))))
+			})
+	}
+	return Commentf("%s вызывает метод %s и возвращает тело ответа без разбора.", method.Name, method.Name).Line().
+		Func().Params(Id("client").Op("*").Id(r.contract.Name)).
+		Id(method.Name).
+		Params(typeGen.FuncDefinitionParams(method.Args)).
+		Params(Id("respBody").Index().Byte(), Err().Error()).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			r.testingHTTPRequest(bg, method, r.testingHTTPBody(bg, method, jsonPkg))
+			bg.Return(Id("client").Dot("srv").Dot("roundTrip").Call(Id("httpRequest"), Lit(r.testingSuccessCode(method))))
+		})
+}
+
+// testingHTTPBody генерирует тело запроса REST метода из аргументов и возвращает установку его Content-Type.
+func (r *contractRenderer) testingHTTPBody(bg *Group, method *parser.Method, jsonPkg string) (setContentType Code) {
+
+	bg.Var().Id("body").Qual(PackageIO, "Reader")
+	contentType := Null()
+	switch r.testingBodyMode(method) {
+	case bodyModeRaw:
+		arg := r.binaryArgs(method)[0]
+		value := "application/octet-stream"
+		if allowed := r.allowedContentTypes(method); len(allowed) != 0 {
+			value = allowed[0]
+		}
+		contentType = Lit(value)
+		if isStreamVar(arg) {
+			bg.Id("body").Op("=").Id(arg.Name)
+		} else {
+			bg.Id("body").Op("=").Qual(PackageBytes, "NewReader").Call(Id(arg.Name))
+		}
+	case bodyModeMultipart:
+		contentType = Id("form").Dot("FormDataContentType").Call()
+		r.testingMultipartBody(bg, method)
+	case bodyModeJSON:
+		contentType = Lit("application/json")
+		bg.Add(r.testingRequest(method))
+		bg.Var().Id("data").Index().Byte()
+		bg.If(List(Id("data"), Err()).Op("=").Qual(jsonPkg, "Marshal").Call(Id("request")).Op(";").Err().Op("!=").Nil()).Block(Return())
+		bg.Id("body").Op("=").Qual(PackageBytes, "NewReader").Call(Id("data"))
+	default:
+		return nil
+	}
+	return Id("httpRequest").Dot("Header").Dot("Set").Call(Lit("Content-Type"), contentType)
+}
+
+// testingMultipartBody генерирует multipart тело запроса: значения полей формы и бинарные аргументы частями-файлами.
+func (r *contractRenderer) testingMultipartBody(bg *Group, method *parser.Method) {
+
+	partType := "application/octet-stream"
+	if allowed := r.allowedContentTypes(method); len(allowed) != 0 {
+		partType = allowed[0]
+	}
+	bg.Var().Id("formBody").Qual(PackageBytes, "Buffer")
+	bg.Id("form").Op(":=").Qual(PackageMultipart, "NewWriter").Call(Op("&").Id("formBody"))
+	for _, arg := range r.argsWithoutSpecialArgs(method) {
+		if isBinaryVar(arg) {
+			continue
+		}
+		bg.If(Err().Op("=").Id("form").Dot("WriteField").Call(Lit(arg.Name), Qual(PackageFmt, "Sprint").Call(Id(arg.Name))).Op(";").Err().Op("!=").Nil()).Block(Return())
+	}
+	for _, arg := range r.binaryArgs(method) {
+		write := Id("part").Dot("Write").Call(Id(arg.Name))
+		if isStreamVar(arg) {
+			write = Qual(PackageIO, "Copy").Call(Id("part"), Id(arg.Name))
+		}
+		bg.BlockFunc(func(ig *Group) {
+			ig.Var().Id("part").Qual(PackageIO, "Writer")
+			ig.If(List(Id("part"), Err()).Op("=").Id("form").Dot("CreatePart").Call(Qual(PackageTextproto, "MIMEHeader").Values(Dict{
+				Lit("Content-Disposition"): Index().String().Values(Lit(fmt.Sprintf(`form-data; name="%s"; filename="%s"`, arg.Name, arg.Name))),
+				Lit("Content-Type"):        Index().String().Values(Lit(partType)),
+			})).Op(";").Err().Op("!=").Nil()).Block(Return())
+			ig.If(List(Id("_"), Err()).Op("=").Add(write).Op(";").Err().Op("!=").Nil()).Block(Return())
+		})
+	}
+	bg.If(Err().Op("=").Id("form").Dot("Close").Call().Op(";").Err().Op("!=").Nil()).Block(Return())
+	bg.Id("body").Op("=").Op("&").Id("formBody")
+}
+
+// testingHTTPMethod генерирует типизированный вызов REST метода с path, query, заголовками и cookies.
+func (r *contractRenderer) testingHTTPMethod(typeGen *types.Generator, method *parser.Method, jsonPkg string) Code {
+
+	return Func().Params(Id("client").Op("*").Id(r.contract.Name)).
+		Id(method.Name).
+		Params(typeGen.FuncDefinitionParams(method.Args)).
+		Params(typeGen.FuncDefinitionParams(method.Results)).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			setContentType := r.testingHTTPBody(bg, method, jsonPkg)
+			r.testingHTTPRequest(bg, method, setContentType)
+			for _, code := range r.testingResults(method, func(result Code) Code {
+				return Id("client").Dot("srv").Dot("callHTTP").Call(Id("httpRequest"), Lit(r.testingSuccessCode(method)), result)
+			}) {
+				bg.Add(code)
+			}
+		})
+}
+
+// testingHTTPRequest генерирует создание HTTP запроса метода с телом body, path, query, заголовками и cookies.
+func (r *contractRenderer) testingHTTPRequest(bg *Group, method *parser.Method, setContentType Code) {
+
+	queryMap, queryArgs := r.argQueryMap(method)
+	url := Id("baseURL").Op("+").Add(r.testingHTTPPath(method))
+	if len(queryArgs) != 0 {
+		bg.Id("query").Op(":=").Qual(PackageNetURL, "Values").Values()
+		for _, argName := range queryArgs {
+			if arg := r.argByName(method, argName); arg != nil {
+				bg.Id("query").Dot("Set").Call(Lit(queryMap[argName]), Qual(PackageFmt, "Sprint").Call(Id(arg.Name)))
+			}
+		}
+		url.Op("+").Lit("?").Op("+").Id("query").Dot("Encode").Call()
+	}
+	bg.Var().Id("httpRequest").Op("*").Qual(PackageNetHTTP, "Request")
+	bg.If(List(Id("httpRequest"), Err()).Op("=").Qual(PackageNetHTTP, "NewRequestWithContext").Call(
+		Id(VarNameCtx),
+		Lit(strings.ToUpper(r.methodHTTPMethod(method))),
+		url,
+		Id("body"),
+	).Op(";").Err().Op("!=").Nil()).Block(Return())
+	if setContentType != nil {
+		bg.Add(setContentType)
+	}
+	for _, argName := range sortedKeys(r.varHeaderMap(method)) {
+		if arg := r.argByName(method, argName); arg != nil {
+			bg.Id("httpRequest").Dot("Header").Dot("Set").Call(Lit(r.varHeaderMap(method)[argName]), Qual(PackageFmt, "Sprint").Call(Id(arg.Name)))
+		}
+	}
+	for _, argName := range sortedKeys(r.varCookieMap(method)) {
+		if arg := r.argByName(method, argName); arg != nil {
+			bg.Id("httpRequest").Dot("AddCookie").Call(Op("&").Qual(PackageNetHTTP, "Cookie").Values(Dict{
+				Id("Name"):  Lit(r.varCookieMap(method)[argName]),
+				Id("Value"): Qual(PackageFmt, "Sprint").Call(Id(arg.Name)),
+			}))
+		}
+	}
+}
+
+// testingHTTPPath генерирует путь REST метода с подстановкой path аргументов.
+func (r *contractRenderer) testingHTTPPath(method *parser.Method) *Statement {
+
+	pathMap := r.argPathMap(method)
+	tokens := strings.Split(r.methodHTTPPath(method), "/")
+	var parts []Code
+	literal := ""
+	for i, token := range tokens {
+		if i > 0 {
+			literal += "/"
+		}
+		argName := strings.TrimSpace(strings.TrimPrefix(token, ":"))
+		if _, isArg := pathMap[argName]; strings.HasPrefix(token, ":") && isArg && r.argByName(method, argName) != nil {
+			if literal != "" {
+				parts = append(parts, Lit(literal))
+				literal = ""
+			}
+			parts = append(parts, Qual(PackageNetURL, "PathEscape").Call(Qual(PackageFmt, "Sprint").Call(Id(r.argByName(method, argName).Name))))
+			continue
+		}
+		literal += token
+	}
+	if literal != "" {
+		parts = append(parts, Lit(literal))
+	}
+	stmt := Null()
+	for i, part := range parts {
+		if i > 0 {
+			stmt.Op("+")
+		}
+		stmt.Add(part)
+	}
+	return stmt
+}
@@ -0,0 +1,367 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// RenderTransportCache генерирует транспортный cache файл.
+func (r *transportRenderer) RenderTransportCache() error {
+
+	if !r.hasCache() {
+		return nil
+	}
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageBytes, "bytes")
+	srcFile.ImportName(PackageList, "list")
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageSha256, "sha256")
+	srcFile.ImportName(PackageHex, "hex")
+	srcFile.ImportName(PackageNetHTTP, "http")
+	srcFile.ImportName(PackageSlices, "slices")
+	srcFile.ImportName(PackageSlog, "slog")
+	srcFile.ImportName(PackageStrings, "strings")
+	srcFile.ImportName(PackageSync, "sync")
+	srcFile.ImportName(PackageTime, "time")
+	srcFile.ImportName(PackageFiber, "fiber")
+
+	srcFile.Line().Const().Id("defaultResponseCacheSize").Op("=").Lit(1024)
+
+	srcFile.Line().Comment("cachedContentHeaders заголовки содержимого ответа, сохраняемые в кэш вместе с телом.")
+	srcFile.Var().Id("cachedContentHeaders").Op("=").Index().String().Values(
+		Qual(PackageFiber, "HeaderContentDisposition"),
+		Qual(PackageFiber, "HeaderContentEncoding"),
+		Qual(PackageFiber, "HeaderContentLanguage"),
+	)
+
+	srcFile.Line().Comment("CachedResponse закэшированный ответ REST метода.")
+	srcFile.Type().Id("CachedResponse").Struct(
+		Id("StatusCode").Int(),
+		Id("ContentType").String(),
+		Id("ETag").String(),
+		Id("Header").Map(String()).String().Comment("заголовки содержимого и результатов метода; заголовки запроса (request ID и т.п.) выставляются заново"),
+		Id("Body").Index().Byte(),
+		Id("Modified").Qual(PackageTime, "Time"),
+	)
+
+	srcFile.Line().Comment("ResponseCache серверный кэш ответов REST методов (например, Redis).")
+	srcFile.Type().Id("ResponseCache").Interface(
+		Comment("Get возвращает закэшированный ответ или nil, если ответа нет или он устарел."),
+		Id("Get").Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("key").String()).
+			Params(Id("response").Op("*").Id("CachedResponse"), Err().Error()),
+		Comment("Set сохраняет ответ на время ttl."),
+		Id("Set").Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("key").String(), Id("response").Id("CachedResponse"), Id("ttl").Qual(PackageTime, "Duration")).Error(),
+	)
+
+	srcFile.Line().Add(r.memoryResponseCacheType())
+	srcFile.Line().Add(r.newMemoryResponseCacheFunc())
+	srcFile.Line().Add(r.memoryResponseCacheFuncs())
+	srcFile.Line().Add(r.cachePolicyType())
+	srcFile.Line().Add(r.cachedHTTPFunc())
+	srcFile.Line().Add(r.cachedCallCompleteFunc())
+	srcFile.Line().Add(r.cachedCallWriteHeadersFunc())
+	srcFile.Line().Add(r.responseCacheKeyFunc())
+	srcFile.Line().Add(r.cachedHeadersFunc())
+	srcFile.Line().Add(r.etagFuncs())
+
+	return srcFile.Save(path.Join(r.outDir, "cache.go"))
+}
+
+// memoryResponseCacheType генерирует тип кэша ответов в памяти.
+func (r *transportRenderer) memoryResponseCacheType() Code {
+
+	return Type().Id("memoryCacheItem").Struct(
+		Id("key").String(),
+		Id("response").Id("CachedResponse"),
+		Id("expires").Qual(PackageTime, "Time"),
+	).Line().Line().Type().Id("memoryResponseCache").Struct(
+		Id("mu").Qual(PackageSync, "Mutex"),
+		Id("size").Int(),
+		Id("items").Map(String()).Op("*").Qual(PackageList, "Element"),
+		Id("order").Op("*").Qual(PackageList, "List"),
+	)
+}
+
+// newMemoryResponseCacheFunc генерирует конструктор кэша ответов в памяти.
+func (r *transportRenderer) newMemoryResponseCacheFunc() Code {
+
+	return Comment("NewMemoryResponseCache создаёт кэш ответов в памяти, вытесняющий давно не использованные записи (LRU).").Line().
+		Func().Id("NewMemoryResponseCache").
+		Params(Id("size").Int()).
+		Params(Id("ResponseCache")).
+		Block(
+			Line().If(Id("size").Op("<=").Lit(0)).Block(
+				Id("size").Op("=").Id("defaultResponseCacheSize"),
+			),
+			Return(Op("&").Id("memoryResponseCache").Values(Dict{
+				Id("size"):  Id("size"),
+				Id("items"): Make(Map(String()).Op("*").Qual(PackageList, "Element")),
+				Id("order"): Qual(PackageList, "New").Call(),
+			})),
+		)
+}
+
+// memoryResponseCacheFuncs генерирует методы кэша ответов в памяти.
+func (r *transportRenderer) memoryResponseCacheFuncs() Code {
+
+	return Func().Params(Id("c").Op("*").Id("memoryResponseCache")).
+		Id("Get").
+		Params(Id("_").Qual(PackageContext, "Context"), Id("key").String()).
+		Params(Op("*").Id("CachedResponse"), Error()).
+		Block(
+			Line().Id("c").Dot("mu").Dot("Lock").Call(),
+			Defer().Id("c").Dot("mu").Dot("Unlock").Call(),
+			List(Id("element"), Id("found")).Op(":=").Id("c").Dot("items").Index(Id("key")),
+			If(Op("!").Id("found")).Block(
+				Return(Nil(), Nil()),
+			),
+			Id("item").Op(":=").Id("element").Dot("Value").Assert(Op("*").Id("memoryCacheItem")),
+			If(Qual(PackageTime, "Now").Call().Dot("After").Call(Id("item").Dot("expires"))).Block(
+				Id("c").Dot("order").Dot("Remove").Call(Id("element")),
+				Delete(Id("c").Dot("items"), Id("key")),
+				Return(Nil(), Nil()),
+			),
+			Id("c").Dot("order").Dot("MoveToFront").Call(Id("element")),
+			Id("response").Op(":=").Id("item").Dot("response"),
+			Return(Op("&").Id("response"), Nil()),
+		).
+		Line().Line().
+		Func().Params(Id("c").Op("*").Id("memoryResponseCache")).
+		Id("Set").
+		Params(Id("_").Qual(PackageContext, "Context"), Id("key").String(), Id("response").Id("CachedResponse"), Id("ttl").Qual(PackageTime, "Duration")).
+		Error().
+		Block(
+			Line().Id("c").Dot("mu").Dot("Lock").Call(),
+			Defer().Id("c").Dot("mu").Dot("Unlock").Call(),
+			Id("expires").Op(":=").Qual(PackageTime, "Now").Call().Dot("Add").Call(Id("ttl")),
+			If(List(Id("element"), Id("found")).Op(":=").Id("c").Dot("items").Index(Id("key")).Op(";").Id("found")).Block(
+				Id("item").Op(":=").Id("element").Dot("Value").Assert(Op("*").Id("memoryCacheItem")),
+				Id("item").Dot("response").Op("=").Id("response"),
+				Id("item").Dot("expires").Op("=").Id("expires"),
+				Id("c").Dot("order").Dot("MoveToFront").Call(Id("element")),
+				Return(Nil()),
+			),
+			Id("c").Dot("items").Index(Id("key")).Op("=").Id("c").Dot("order").Dot("PushFront").Call(Op("&").Id("memoryCacheItem").Values(Dict{
+				Id("key"):      Id("key"),
+				Id("response"): Id("response"),
+				Id("expires"):  Id("expires"),
+			})),
+			For(Id("c").Dot("order").Dot("Len").Call().Op(">").Id("c").Dot("size")).Block(
+				Id("oldest").Op(":=").Id("c").Dot("order").Dot("Back").Call(),
+				Id("c").Dot("order").Dot("Remove").Call(Id("oldest")),
+				Delete(Id("c").Dot("items"), Id("oldest").Dot("Value").Assert(Op("*").Id("memoryCacheItem")).Dot("key")),
+			),
+			Return(Nil()),
+		)
+}
+
+// cachePolicyType генерирует типы параметров и выполняемого вызова кэшируемого метода.
+func (r *transportRenderer) cachePolicyType() Code {
+
+	return Type().Id("cachePolicy").Struct(
+		Id("control").String(),
+		Id("ttl").Qual(PackageTime, "Duration"),
+		Id("etag").Bool(),
+		Id("headers").Index().String(),
+		Id("cookies").Index().String(),
+		Id("results").Index().String(),
+	).Line().Line().Type().Id("cachedCall").Struct(
+		Id("srv").Op("*").Id("Server"),
+		Id("key").String(),
+		Id("policy").Id("cachePolicy"),
+	)
+}
+
+// cachedHTTPFunc генерирует выдачу ответа REST метода из серверного кэша.
+func (r *transportRenderer) cachedHTTPFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("cachedHTTP").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("scope").String(), Id("policy").Id("cachePolicy")).
+		Params(Id("call").Op("*").Id("cachedCall"), Id("handled").Bool()).
+		Block(
+			Line().Id("call").Op("=").Op("&").Id("cachedCall").Values(Dict{
+				Id("srv"):    Id("srv"),
+				Id("policy"): Id("policy"),
+			}),
+			For(List(Id("_"), Id("header")).Op(":=").Range().Id("policy").Dot("headers")).Block(
+				Id(VarNameFtx).Dot("Append").Call(Qual(PackageFiber, "HeaderVary"), Id("header")),
+			),
+			If(Len(Id("srv").Dot("codecs")).Op("!=").Lit(0)).Block(
+				Id(VarNameFtx).Dot("Append").Call(Qual(PackageFiber, "HeaderVary"), Qual(PackageFiber, "HeaderAccept")),
+			),
+			If(Id("srv").Dot("responseCache").Op("==").Nil().Op("||").Id("policy").Dot("ttl").Op("<=").Lit(0)).Block(
+				Return(Id("call"), False()),
+			),
+			Id("call").Dot("key").Op("=").Id("responseCacheKey").Call(Id(VarNameFtx), Id("scope"), Id("policy")),
+			List(Id("response"), Err()).Op(":=").Id("srv").Dot("responseCache").Dot("Get").Call(Id(VarNameFtx).Dot("UserContext").Call(), Id("call").Dot("key")),
+			If(Err().Op("!=").Nil()).Block(
+				Id("srv").Dot("log").Dot("Error").Call(Lit("response cache failed"), Qual(PackageSlog, "String").Call(Lit("method"), Id("scope")), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
+				Return(Id("call"), False()),
+			),
+			If(Id("response").Op("==").Nil()).Block(
+				Return(Id("call"), False()),
+			),
+			For(List(Id("name"), Id("value")).Op(":=").Range().Id("response").Dot("Header")).Block(
+				Id(VarNameFtx).Dot("Set").Call(Id("name"), Id("value")),
+			),
+			If(Id("response").Dot("ContentType").Op("!=").Lit("")).Block(
+				Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("SetContentType").Call(Id("response").Dot("ContentType")),
+			),
+			Id(VarNameFtx).Dot("Status").Call(Id("response").Dot("StatusCode")),
+			Id(VarNameFtx).Dot("Response").Call().Dot("SetBody").Call(Id("response").Dot("Body")),
+			Id("call").Dot("writeHeaders").Call(Id(VarNameFtx), Id("response").Dot("ETag"), Id("response").Dot("Modified")),
+			Return(Id("call"), True()),
+		)
+}
+
+// cachedCallCompleteFunc генерирует сохранение ответа REST метода в кэш и установку заголовков кэширования.
+func (r *transportRenderer) cachedCallCompleteFunc() Code {
+
+	return Func().Params(Id("call").Op("*").Id("cachedCall")).
+		Id("completeHTTP").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Err().Error()).
+		Block(
+			Line().If(Err().Op("!=").Nil().Op("||").Id(VarNameFtx).Dot("Response").Call().Dot("StatusCode").Call().Op("!=").Qual(PackageFiber, "StatusOK")).Block(
+				Return(),
+			),
+			Id("body").Op(":=").Id(VarNameFtx).Dot("Response").Call().Dot("Body").Call(),
+			Var().Id("etag").String(),
+			If(Id("call").Dot("policy").Dot("etag")).Block(
+				Id("etag").Op("=").Id("strongETag").Call(Id("body")),
+			),
+			Var().Id("modified").Qual(PackageTime, "Time"),
+			If(Id("call").Dot("key").Op("!=").Lit("")).Block(
+				If(List(Id("header"), Id("cacheable")).Op(":=").Id("cachedHeaders").Call(Id(VarNameFtx), Id("call").Dot("policy")).Op(";").Id("cacheable")).Block(
+					Id("modified").Op("=").Qual(PackageTime, "Now").Call().Dot("UTC").Call().Dot("Truncate").Call(Qual(PackageTime, "Second")),
+					Id("response").Op(":=").Id("CachedResponse").Values(Dict{
+						Id("StatusCode"):  Qual(PackageFiber, "StatusOK"),
+						Id("ContentType"): String().Call(Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("ContentType").Call()),
+						Id("ETag"):        Id("etag"),
+						Id("Header"):      Id("header"),
+						Id("Body"):        Qual(PackageBytes, "Clone").Call(Id("body")),
+						Id("Modified"):    Id("modified"),
+					}),
+					If(Err().Op("=").Id("call").Dot("srv").Dot("responseCache").Dot("Set").Call(Qual(PackageContext, "WithoutCancel").Call(Id(VarNameFtx).Dot("UserContext").Call()), Id("call").Dot("key"), Id("response"), Id("call").Dot("policy").Dot("ttl")).Op(";").Err().Op("!=").Nil()).Block(
+						Id("call").Dot("srv").Dot("log").Dot("Error").Call(Lit("response cache failed"), Qual(PackageSlog, "String").Call(Lit("key"), Id("call").Dot("key")), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
+					),
+				),
+			),
+			Id("call").Dot("writeHeaders").Call(Id(VarNameFtx), Id("etag"), Id("modified")),
+		)
+}
+
+// cachedCallWriteHeadersFunc генерирует установку заголовков кэширования и ответ 304 Not Modified.
+func (r *transportRenderer) cachedCallWriteHeadersFunc() Code {
+
+	return Func().Params(Id("call").Op("*").Id("cachedCall")).
+		Id("writeHeaders").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("etag").String(), Id("modified").Qual(PackageTime, "Time")).
+		Block(
+			Line().If(Id("call").Dot("policy").Dot("control").Op("!=").Lit("")).Block(
+				Id(VarNameFtx).Dot("Set").Call(Qual(PackageFiber, "HeaderCacheControl"), Id("call").Dot("policy").Dot("control")),
+			),
+			If(Op("!").Id("modified").Dot("IsZero").Call()).Block(
+				Id(VarNameFtx).Dot("Set").Call(Qual(PackageFiber, "HeaderLastModified"), Id("modified").Dot("Format").Call(Qual(PackageNetHTTP, "TimeFormat"))),
+			),
+			If(Id("etag").Op("!=").Lit("")).Block(
+				Id(VarNameFtx).Dot("Set").Call(Qual(PackageFiber, "HeaderETag"), Id("etag")),
+			),
+			Var().Id("notModified").Bool(),
+			If(Id("match").Op(":=").Id(VarNameFtx).Dot("Get").Call(Qual(PackageFiber, "HeaderIfNoneMatch")).Op(";").Id("match").Op("!=").Lit("")).Block(
+				Id("notModified").Op("=").Id("etag").Op("!=").Lit("").Op("&&").Id("etagMatch").Call(Id("match"), Id("etag")),
+			).Else().If(Id("since").Op(":=").Id(VarNameFtx).Dot("Get").Call(Qual(PackageFiber, "HeaderIfModifiedSince")).Op(";").Id("since").Op("!=").Lit("").Op("&&").Op("!").Id("modified").Dot("IsZero").Call()).Block(
+				List(Id("sinceTime"), Err()).Op(":=").Qual(PackageNetHTTP, "ParseTime").Call(Id("since")),
+				Id("notModified").Op("=").Err().Op("==").Nil().Op("&&").Op("!").Id("modified").Dot("After").Call(Id("sinceTime")),
+			),
+			If(Id("notModified")).Block(
+				Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusNotModified")),
+				Id(VarNameFtx).Dot("Response").Call().Dot("ResetBody").Call(),
+			),
+		)
+}
+
+// responseCacheKeyFunc генерирует построение ключа серверного кэша ответа.
+func (r *transportRenderer) responseCacheKeyFunc() Code {
+
+	return Func().Id("responseCacheKey").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("scope").String(), Id("policy").Id("cachePolicy")).
+		String().
+		Block(
+			Line().Var().Id("key").Qual(PackageStrings, "Builder"),
+			Id("key").Dot("WriteString").Call(Id("scope")),
+			Id("key").Dot("WriteByte").Call(LitRune(' ')),
+			Id("key").Dot("WriteString").Call(Id(VarNameFtx).Dot("OriginalURL").Call()),
+			For(List(Id("_"), Id("header")).Op(":=").Range().Id("policy").Dot("headers")).Block(
+				Id("key").Dot("WriteString").Call(Lit("\nheader ").Op("+").Id("header").Op("+").Lit("=").Op("+").Id(VarNameFtx).Dot("Get").Call(Id("header"))),
+			),
+			For(List(Id("_"), Id("cookie")).Op(":=").Range().Id("policy").Dot("cookies")).Block(
+				Id("key").Dot("WriteString").Call(Lit("\ncookie ").Op("+").Id("cookie").Op("+").Lit("=").Op("+").Id(VarNameFtx).Dot("Cookies").Call(Id("cookie"))),
+			),
+			If(Id("codec").Op(":=").Id("responseCodec").Call(Id(VarNameFtx).Dot("UserContext").Call()).Op(";").Id("codec").Op("!=").Nil()).Block(
+				Id("key").Dot("WriteString").Call(Lit("\ncodec ").Op("+").Id("codec").Dot("ContentType").Call()),
+			),
+			Return(Id("key").Dot("String").Call()),
+		)
+}
+
+// cachedHeadersFunc генерирует выборку заголовков ответа для сохранения в кэш: только заголовки содержимого и результатов метода.
+func (r *transportRenderer) cachedHeadersFunc() Code {
+
+	return Func().Id("cachedHeaders").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("policy").Id("cachePolicy")).
+		Params(Id("header").Map(String()).String(), Id("cacheable").Bool()).
+		Block(
+			Line().Id("cacheable").Op("=").True(),
+			Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("VisitAll").Call(Func().Params(List(Id("key"), Id("value")).Index().Byte()).Block(
+				Id("name").Op(":=").String().Call(Id("key")),
+				Id("named").Op(":=").Func().Params(Id("candidate").String()).Bool().Block(
+					Return(Qual(PackageStrings, "EqualFold").Call(Id("candidate"), Id("name"))),
+				),
+				If(Id("named").Call(Qual(PackageFiber, "HeaderSetCookie"))).Block(
+					Id("cacheable").Op("=").False(),
+					Return(),
+				),
+				If(Op("!").Qual(PackageSlices, "ContainsFunc").Call(Id("cachedContentHeaders"), Id("named")).Op("&&").Op("!").Qual(PackageSlices, "ContainsFunc").Call(Id("policy").Dot("results"), Id("named"))).Block(
+					Return(),
+				),
+				If(Id("header").Op("==").Nil()).Block(
+					Id("header").Op("=").Make(Map(String()).String()),
+				),
+				Id("header").Index(Id("name")).Op("=").String().Call(Id("value")),
+			)),
+			Return(),
+		)
+}
+
+// etagFuncs генерирует вычисление и сравнение ETag.
+func (r *transportRenderer) etagFuncs() Code {
+
+	return Func().Id("strongETag").
+		Params(Id("body").Index().Byte()).
+		String().
+		Block(
+			Line().Id("sum").Op(":=").Qual(PackageSha256, "Sum256").Call(Id("body")),
+			Return(Lit(`"`).Op("+").Qual(PackageHex, "EncodeToString").Call(Id("sum").Index(Op(":").Lit(16))).Op("+").Lit(`"`)),
+		).
+		Line().Line().
+		Func().Id("etagMatch").
+		Params(List(Id("header"), Id("etag")).String()).
+		Bool().
+		Block(
+			Line().For(List(Id("_"), Id("candidate")).Op(":=").Range().Qual(PackageStrings, "Split").Call(Id("header"), Lit(","))).Block(
+				Id("candidate").Op("=").Qual(PackageStrings, "TrimPrefix").Call(Qual(PackageStrings, "TrimSpace").Call(Id("candidate")), Lit("W/")),
+				If(Id("candidate").Op("==").Lit("*").Op("||").Id("candidate").Op("==").Id("etag")).Block(
+					Return(True()),
+				),
+			),
+			Return(False()),
+		)
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// RenderTransportCodec генерирует транспортный codec файл с согласованием формата тел запросов и ответов.
+func (r *transportRenderer) RenderTransportCodec() error {
+
+	codecPath := path.Join(r.outDir, "codec.go")
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(PackageSlog, "slog")
+	srcFile.ImportName(PackageStrings, "strings")
+
+	srcFile.Line().Comment("Типы содержимого распространённых бинарных кодеков.")
+	srcFile.Const().Defs(
+		Id("ContentTypeMsgpack").Op("=").Lit("application/msgpack"),
+		Id("ContentTypeCBOR").Op("=").Lit("application/cbor"),
+	)
+
+	srcFile.Line().Comment("Codec кодирует тела запросов и ответов в формате, отличном от JSON (например, MessagePack или CBOR).")
+	srcFile.Comment("Реализация должна учитывать json-теги структур, чтобы имена полей совпадали с JSON представлением.")
+	srcFile.Type().Id("Codec").Interface(
+		Id("ContentType").Params().String(),
+		Id("Marshal").Params(Id("v").Any()).Params(Index().Byte(), Error()),
+		Id("Unmarshal").Params(Id("data").Index().Byte(), Id("v").Any()).Error(),
+	)
+
+	srcFile.Line().Type().Id("codecCtx").Struct()
+
+	srcFile.Line().Type().Id("negotiatedCodecs").Struct(
+		Id("request").Id("Codec"),
+		Id("response").Id("Codec"),
+	)
+
+	srcFile.Line().Add(r.codecHandlerFunc())
+	srcFile.Line().Add(r.acceptCodecFunc())
+	srcFile.Line().Add(r.mediaTypeFunc())
+	srcFile.Line().Add(r.requestCodecFunc())
+	srcFile.Line().Add(r.responseCodecFunc())
+	srcFile.Line().Add(r.sendCodecResponseFunc())
+	if r.hasJsonRPC() {
+		r.renderCodecJsonRPC(&srcFile)
+	}
+
+	return srcFile.Save(codecPath)
+}
+
+// codecHandlerFunc генерирует middleware согласования кодеков по заголовкам Content-Type и Accept.
+func (r *transportRenderer) codecHandlerFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("codecHandler").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Error().
+		Block(
+			Line(),
+			If(Len(Id("srv").Dot("codecs")).Op("==").Lit(0)).Block(
+				Return(Id(VarNameFtx).Dot("Next").Call()),
+			),
+			Id("codecs").Op(":=").Id("negotiatedCodecs").Values(Dict{
+				Id("request"): Id("srv").Dot("codecs").Index(Id("mediaType").Call(Id(VarNameFtx).Dot("Get").Call(Qual(PackageFiber, "HeaderContentType")))),
+			}),
+			Id("codecs").Dot("response").Op("=").Id("srv").Dot("acceptCodec").Call(Id(VarNameFtx).Dot("Get").Call(Qual(PackageFiber, "HeaderAccept")), Id("codecs").Dot("request")),
+			If(Id("codecs").Dot("request").Op("!=").Nil().Op("||").Id("codecs").Dot("response").Op("!=").Nil()).Block(
+				Id(VarNameFtx).Dot("SetUserContext").Call(Qual(PackageContext, "WithValue").Call(Id(VarNameFtx).Dot("UserContext").Call(), Id("codecCtx").Values(), Id("codecs"))),
+			),
+			Return(Id(VarNameFtx).Dot("Next").Call()),
+		)
+}
+
+// acceptCodecFunc генерирует выбор кодека ответа по заголовку Accept.
+func (r *transportRenderer) acceptCodecFunc() Code {
+
+	return Comment("acceptCodec выбирает кодек ответа: первый зарегистрированный тип из Accept, JSON или кодек запроса для */*.").Line().
+		Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("acceptCodec").
+		Params(Id("accept").String(), Id("fallback").Id("Codec")).
+		Params(Id("Codec")).
+		Block(
+			Line(),
+			If(Id("accept").Op("==").Lit("")).Block(
+				Return(Id("fallback")),
+			),
+			For(List(Id("_"), Id("part")).Op(":=").Range().Qual(PackageStrings, "Split").Call(Id("accept"), Lit(","))).Block(
+				Id("accepted").Op(":=").Id("mediaType").Call(Id("part")),
+				If(List(Id("codec"), Id("found")).Op(":=").Id("srv").Dot("codecs").Index(Id("accepted")).Op(";").Id("found")).Block(
+					Return(Id("codec")),
+				),
+				Switch(Id("accepted")).Block(
+					Case(Qual(PackageFiber, "MIMEApplicationJSON")).Block(
+						Return(Nil()),
+					),
+					Case(Lit("*/*"), Lit("application/*")).Block(
+						Return(Id("fallback")),
+					),
+				),
+			),
+			Return(Id("fallback")),
+		)
+}
+
+// mediaTypeFunc генерирует функцию выделения типа содержимого без параметров.
+func (r *transportRenderer) mediaTypeFunc() Code {
+
+	return Func().Id("mediaType").
+		Params(Id("value").String()).
+		String().
+		Block(
+			If(Id("i").Op(":=").Qual(PackageStrings, "IndexByte").Call(Id("value"), LitRune(';')).Op(";").Id("i").Op(">=").Lit(0)).Block(
+				Id("value").Op("=").Id("value").Index(Op(":").Id("i")),
+			),
+			Return(Qual(PackageStrings, "ToLower").Call(Qual(PackageStrings, "TrimSpace").Call(Id("value")))),
+		)
+}
+
+// requestCodecFunc генерирует функцию получения кодека тела запроса.
+func (r *transportRenderer) requestCodecFunc() Code {
+
+	return Comment("requestCodec возвращает кодек тела запроса или nil для JSON.").Line().
+		Func().Id("requestCodec").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context")).
+		Params(Id("Codec")).
+		Block(
+			List(Id("codecs"), Id("_")).Op(":=").Id(VarNameCtx).Dot("Value").Call(Id("codecCtx").Values()).Op(".").Call(Id("negotiatedCodecs")),
+			Return(Id("codecs").Dot("request")),
+		)
+}
+
+// responseCodecFunc генерирует функцию получения кодека тела ответа.
+func (r *transportRenderer) responseCodecFunc() Code {
+
+	return Comment("responseCodec возвращает кодек тела ответа или nil для JSON.").Line().
+		Func().Id("responseCodec").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context")).
+		Params(Id("Codec")).
+		Block(
+			List(Id("codecs"), Id("_")).Op(":=").Id(VarNameCtx).Dot("Value").Call(Id("codecCtx").Values()).Op(".").Call(Id("negotiatedCodecs")),
+			Return(Id("codecs").Dot("response")),
+		)
+}
+
+// sendCodecResponseFunc генерирует функцию отправки ответа через кодек.
+func (r *transportRenderer) sendCodecResponseFunc() Code {
+
+	return Func().Id("sendCodecResponse").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("codec").Id("Codec"), Id("resp").Any()).
+		Params(Err().Error()).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			if r.hasJsonRPC() {
+				bg.Switch(Id("response").Op(":=").Id("resp").Op(".").Call(Type())).Block(
+					Case(Op("*").Id("baseJsonRPC")).Block(
+						If(Id("response").Op("!=").Nil()).Block(
+							Id("resp").Op("=").Id("newCodecJsonRPC").Call(Id("response")),
+						),
+					),
+					Case(Index().Op("*").Id("baseJsonRPC")).Block(
+						Id("envelopes").Op(":=").Make(Index().Id("codecJsonRPC"), Lit(0), Len(Id("response"))),
+						For(List(Id("_"), Id("item")).Op(":=").Range().Id("response")).Block(
+							Id("envelopes").Op("=").Append(Id("envelopes"), Id("newCodecJsonRPC").Call(Id("item"))),
+						),
+						Id("resp").Op("=").Id("envelopes"),
+					),
+				)
+			}
+			bg.Var().Id("body").Index().Byte()
+			bg.If(List(Id("body"), Err()).Op("=").Id("codec").Dot("Marshal").Call(Id("resp")).Op(";").Err().Op("!=").Nil()).Block(
+				If(Id("logger").Op(":=").Id("FromContext").Call(Id(VarNameFtx).Dot("UserContext").Call()).Op(";").Id("logger").Op("!=").Nil()).Block(
+					Id("logger").Dot("Error").Call(Lit("response marshal error"), Qual(PackageSlog, "String").Call(Lit("codec"), Id("codec").Dot("ContentType").Call()), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
+				),
+				Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusInternalServerError")),
+				Return(Err()),
+			)
+			bg.Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("SetContentType").Call(Id("codec").Dot("ContentType").Call())
+			bg.List(Id("_"), Err()).Op("=").Id(VarNameFtx).Dot("Write").Call(Id("body"))
+			bg.Return(Err())
+		})
+}
+
+// renderCodecJsonRPC генерирует конверт JSON-RPC для бинарных кодеков.
+func (r *transportRenderer) renderCodecJsonRPC(srcFile *GoFile) {
+
+	jsonPkg := r.project.Annotations.Value(TagPackageJSON, PackageStdJSON)
+	srcFile.ImportName(jsonPkg, "json")
+	srcFile.ImportName(PackageBytes, "bytes")
+
+	srcFile.Line().Comment("codecJsonRPC конверт JSON-RPC в бинарном кодеке: params и result передаются значениями, а не вложенным JSON.")
+	srcFile.Type().Id("codecJsonRPC").Struct(
+		Id("ID").Any().Tag(map[string]string{"json": "id,omitempty"}),
+		Id("Version").String().Tag(map[string]string{"json": "jsonrpc"}),
+		Id("Method").String().Tag(map[string]string{"json": "method,omitempty"}),
+		Id("Error").Op("*").Id("errorJsonRPC").Tag(map[string]string{"json": "error,omitempty"}),
+		Id("Params").Any().Tag(map[string]string{"json": "params,omitempty"}),
+		Id("Result").Any().Tag(map[string]string{"json": "result,omitempty"}),
+	)
+
+	srcFile.Line().Comment("decodeCodecJsonRPC декодирует одиночный или batch запрос JSON-RPC; params перекодируются кодеком для типизированного разбора в методе.")
+	srcFile.Func().Id("decodeCodecJsonRPC").
+		Params(Id("codec").Id("Codec"), Id("body").Index().Byte()).
+		Params(Id("requests").Index().Id("baseJsonRPC"), Id("single").Bool(), Err().Error()).
+		Block(
+			Line(),
+			Var().Id("envelope").Id("codecJsonRPC"),
+			If(Err().Op("=").Id("codec").Dot("Unmarshal").Call(Id("body"), Op("&").Id("envelope")).Op(";").Err().Op("==").Nil()).Block(
+				Var().Id("request").Id("baseJsonRPC"),
+				If(List(Id("request"), Err()).Op("=").Id("envelope").Dot("request").Call(Id("codec")).Op(";").Err().Op("!=").Nil()).Block(
+					Return(),
+				),
+				Return(Index().Id("baseJsonRPC").Values(Id("request")), True(), Nil()),
+			),
+			Var().Id("envelopes").Index().Id("codecJsonRPC"),
+			If(Err().Op("=").Id("codec").Dot("Unmarshal").Call(Id("body"), Op("&").Id("envelopes")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			Id("requests").Op("=").Make(Index().Id("baseJsonRPC"), Lit(0), Len(Id("envelopes"))),
+			For(List(Id("_"), Id("item")).Op(":=").Range().Id("envelopes")).Block(
+				Var().Id("request").Id("baseJsonRPC"),
+				If(List(Id("request"), Err()).Op("=").Id("item").Dot("request").Call(Id("codec")).Op(";").Err().Op("!=").Nil()).Block(
+					Return(),
+				),
+				Id("requests").Op("=").Append(Id("requests"), Id("request")),
+			),
+			Return(),
+		)
+
+	srcFile.Line().Func().Params(Id("envelope").Id("codecJsonRPC")).
+		Id("request").
+		Params(Id("codec").Id("Codec")).
+		Params(Id("request").Id("baseJsonRPC"), Err().Error()).
+		Block(
+			Line(),
+			Id("request").Op("=").Id("baseJsonRPC").Values(Dict{
+				Id("Version"): Id("envelope").Dot("Version"),
+				Id("Method"):  Id("envelope").Dot("Method"),
+				Id("codec"):   Id("codec"),
+			}),
+			If(Id("envelope").Dot("ID").Op("!=").Nil()).Block(
+				If(List(Id("request").Dot("ID"), Err()).Op("=").Qual(jsonPkg, "Marshal").Call(Id("envelope").Dot("ID")).Op(";").Err().Op("!=").Nil()).Block(
+					Return(),
+				),
+			),
+			If(Id("envelope").Dot("Params").Op("!=").Nil()).Block(
+				List(Id("request").Dot("Params"), Err()).Op("=").Id("codec").Dot("Marshal").Call(Id("envelope").Dot("Params")),
+			),
+			Return(),
+		)
+
+	srcFile.Line().Func().Id("newCodecJsonRPC").
+		Params(Id("response").Op("*").Id("baseJsonRPC")).
+		Params(Id("envelope").Id("codecJsonRPC")).
+		Block(
+			Line(),
+			Id("envelope").Op("=").Id("codecJsonRPC").Values(Dict{
+				Id("Version"): Id("response").Dot("Version"),
+				Id("Error"):   Id("response").Dot("Error"),
+				Id("Result"):  Id("response").Dot("resultValue"),
+			}),
+			If(Len(Id("response").Dot("ID")).Op("!=").Lit(0)).Block(
+				Id("envelope").Dot("ID").Op("=").Id("codecID").Call(Id("response").Dot("ID")),
+			),
+			If(Id("envelope").Dot("Result").Op("==").Nil().Op("&&").Len(Id("response").Dot("Result")).Op("!=").Lit(0)).Block(
+				Id("_").Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("response").Dot("Result"), Op("&").Id("envelope").Dot("Result")),
+			),
+			Return(),
+		)
+
+	srcFile.Line().Comment("codecID возвращает идентификатор запроса в исходном виде: целые числа не превращаются в float64.")
+	srcFile.Func().Id("codecID").
+		Params(Id("id").Id("idJsonRPC")).
+		Params(Id("value").Any()).
+		Block(
+			Line(),
+			Id("decoder").Op(":=").Qual(jsonPkg, "NewDecoder").Call(Qual(PackageBytes, "NewReader").Call(Id("id"))),
+			Id("decoder").Dot("UseNumber").Call(),
+			If(Id("decoder").Dot("Decode").Call(Op("&").Id("value")).Op("!=").Nil()).Block(
+				Return(Nil()),
+			),
+			If(List(Id("number"), Id("ok")).Op(":=").Id("value").Op(".").Call(Qual(jsonPkg, "Number")).Op(";").Id("ok")).Block(
+				If(List(Id("i"), Err()).Op(":=").Id("number").Dot("Int64").Call().Op(";").Err().Op("==").Nil()).Block(
+					Return(Id("i")),
+				),
+				List(Id("f"), Id("_")).Op(":=").Id("number").Dot("Float64").Call(),
+				Return(Id("f")),
+			),
+			Return(),
+		)
+}
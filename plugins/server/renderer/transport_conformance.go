@@ -0,0 +1,208 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// conformanceCase - проверка соответствия спецификации JSON-RPC 2.0: тело запроса и ожидаемые ответы (id:code или id:ok для вызванного метода).
+type conformanceCase struct {
+	name      string
+	contract  string
+	body      string
+	batch     bool
+	responses []string
+}
+
+// conformanceCases - проверки спецификации JSON-RPC 2.0, не зависящие от методов контрактов.
+var conformanceCases = []conformanceCase{
+	{name: "parse error", body: `{"jsonrpc": "2.0", "method": "foobar, "params": "bar", "baz]`, responses: []string{"null:-32700"}},
+	{name: "empty body", body: ``, responses: []string{"null:-32700"}},
+	{name: "invalid request object", body: `{"jsonrpc": "2.0", "method": 1, "params": "bar"}`, responses: []string{"null:-32600"}},
+	{name: "non-object request", body: `1`, responses: []string{"null:-32600"}},
+	{name: "missing version", body: `{"method": "conformance.missing", "id": 1}`, responses: []string{"1:-32600"}},
+	{name: "missing method", body: `{"jsonrpc": "2.0", "id": 2}`, responses: []string{"2:-32600"}},
+	{name: "invalid id", body: `{"jsonrpc": "2.0", "method": "conformance.missing", "id": {}}`, responses: []string{"null:-32600"}},
+	{name: "method not found", body: `{"jsonrpc": "2.0", "method": "conformance.missing", "id": "abc"}`, responses: []string{`"abc":-32601`}},
+	{name: "notification", body: `{"jsonrpc": "2.0", "method": "conformance.missing"}`},
+	{name: "empty batch", body: `[]`, responses: []string{"null:-32600"}},
+	{name: "invalid batch", body: `[1, 2]`, batch: true, responses: []string{"null:-32600", "null:-32600"}},
+	{name: "invalid JSON batch", body: `[{"jsonrpc": "2.0", "method": "sum", "id": "1"}, {"jsonrpc": "2.0", "method"]`, responses: []string{"null:-32700"}},
+	{name: "notification batch", body: `[{"jsonrpc": "2.0", "method": "conformance.missing"}, {"jsonrpc": "2.0", "method": "conformance.other"}]`},
+	{name: "mixed batch", body: `[{"jsonrpc": "2.0", "method": "conformance.missing", "id": 1}, {"jsonrpc": "2.0", "method": "conformance.missing"}, {"foo": "boo"}]`, batch: true, responses: []string{"1:-32601", "null:-32600"}},
+}
+
+// conformanceMethodCases возвращает проверки параметров по позиции и notification для первого подходящего JSON-RPC метода каждого контракта.
+func (r *transportRenderer) conformanceMethodCases() (cases []conformanceCase) {
+
+	for _, contract := range r.project.Contracts {
+		if !contract.Annotations.Contains(TagServerJsonRPC) {
+			continue
+		}
+		for _, method := range contract.Methods {
+			// ограничения частоты и вывод из эксплуатации меняют ответ независимо от параметров
+			if method.Annotations.Contains(TagMethodHTTP) || methodHasLimits(method) || methodDeprecated(contract, method) {
+				continue
+			}
+			var params []string
+			for _, arg := range argsWithoutContext(method) {
+				if !isStreamVar(arg) {
+					params = append(params, "null")
+				}
+			}
+			name := jsonRPCMethodName(contract, method)
+			request := func(params []string, id string) string {
+				body := fmt.Sprintf(`{"jsonrpc": "2.0", "method": %q, "params": [%s]`, name, strings.Join(params, ", "))
+				if id != "" {
+					body += `, "id": ` + id
+				}
+				return body + "}"
+			}
+			cases = append(cases,
+				conformanceCase{name: contract.Name + " by-position params", contract: contract.Name, body: request(params, "1"), responses: []string{"1:ok"}},
+				conformanceCase{name: contract.Name + " too many by-position params", contract: contract.Name, body: request(append(params, "null"), "2"), responses: []string{"2:-32602"}},
+				conformanceCase{name: contract.Name + " notification", contract: contract.Name, body: request(params, "")},
+			)
+			break
+		}
+	}
+	return
+}
+
+// renderTestingConformance генерирует набор проверок соответствия транспорта спецификации JSON-RPC 2.0.
+func (r *transportRenderer) renderTestingConformance(testingDir string) error {
+
+	srcFile := NewSrcFile(testingPkgName)
+	srcFile.PackageComment(DoNotEdit)
+
+	jsonPkg := r.project.Annotations.Value(TagPackageJSON, PackageStdJSON)
+	transportPkg := r.pkgPath(r.outDir)
+	srcFile.ImportName(transportPkg, "transport")
+	srcFile.ImportAlias(PackageTesting, "gotesting")
+	srcFile.ImportName(PackageNetHTTP, "http")
+	srcFile.ImportName(PackageIO, "io")
+	srcFile.ImportName(PackageBytes, "bytes")
+	srcFile.ImportName(PackageFmt, "fmt")
+	srcFile.ImportName(PackageSort, "sort")
+	srcFile.ImportName(PackageSlices, "slices")
+	srcFile.ImportName(PackageStrings, "strings")
+	srcFile.ImportName(jsonPkg, "json")
+
+	srcFile.Line().Comment("conformanceCase - запрос и ожидаемые ответы в виде id:code (порядок ответов batch не важен).")
+	srcFile.Comment("Ответ id:ok означает, что метод контракта contract вызван: результат или ошибка реализации.")
+	srcFile.Type().Id("conformanceCase").Struct(
+		Id("name").String(),
+		Id("contract").String(),
+		Id("body").String(),
+		Id("batch").Bool(),
+		Id("responses").Index().String(),
+	)
+
+	srcFile.Line().Type().Id("conformanceResponse").Struct(
+		Id("Version").String().Tag(map[string]string{"json": "jsonrpc"}),
+		Id("ID").Qual(jsonPkg, "RawMessage").Tag(map[string]string{"json": "id"}),
+		Id("Error").Op("*").Struct(
+			Id("Code").Int().Tag(map[string]string{"json": "code"}),
+		).Tag(map[string]string{"json": "error"}),
+	)
+
+	srcFile.Line().Var().Id("conformanceCases").Op("=").Index().Id("conformanceCase").ValuesFunc(func(vg *Group) {
+		for _, tc := range append(conformanceCases, r.conformanceMethodCases()...) {
+			vg.Line().Values(Dict{
+				Id("name"):     Lit(tc.name),
+				Id("contract"): Lit(tc.contract),
+				Id("body"):     Lit(tc.body),
+				Id("batch"):    Lit(tc.batch),
+				Id("responses"): Index().String().ValuesFunc(func(rg *Group) {
+					for _, response := range tc.responses {
+						rg.Lit(response)
+					}
+				}),
+			})
+		}
+		vg.Line()
+	})
+
+	srcFile.Line().Comment("JsonRPCConformance проверяет соответствие транспорта спецификации JSON-RPC 2.0: коды -32700/-32600/-32601/-32602,")
+	srcFile.Comment("параметры по позиции, notifications и batch. Проверки методов контрактов, не переданных в options, пропускаются.")
+	srcFile.Func().Id("JsonRPCConformance").
+		Params(Id("t").Op("*").Qual(PackageTesting, "T"), Id("options").Op("...").Qual(transportPkg, "Option")).
+		Block(
+			Line().Id("t").Dot("Helper").Call(),
+			Id("srv").Op(":=").Id("New").Call(Id("t"), Id("options").Op("...")),
+			Id("unregistered").Op(":=").Make(Map(String()).Bool()),
+			For(List(Id("_"), Id("tc")).Op(":=").Range().Id("conformanceCases")).Block(
+				Id("t").Dot("Run").Call(Id("tc").Dot("name"), Func().Params(Id("t").Op("*").Qual(PackageTesting, "T")).Block(
+					If(Id("unregistered").Index(Id("tc").Dot("contract"))).Block(
+						Id("t").Dot("Skipf").Call(Lit("contract %s is not registered"), Id("tc").Dot("contract")),
+					),
+					Id("responses").Op(":=").Id("srv").Dot("postConformance").Call(Id("t"), Id("tc")),
+					Comment("первая проверка контракта определяет, зарегистрирован ли он в транспорте"),
+					If(Id("tc").Dot("contract").Op("!=").Lit("").Op("&&").Len(Id("responses")).Op("==").Lit(1).Op("&&").Qual(PackageStrings, "HasSuffix").Call(Id("responses").Index(Lit(0)), Lit(":-32601"))).Block(
+						Id("unregistered").Index(Id("tc").Dot("contract")).Op("=").True(),
+						Id("t").Dot("Skipf").Call(Lit("contract %s is not registered"), Id("tc").Dot("contract")),
+					),
+					Qual(PackageSort, "Strings").Call(Id("responses")),
+					Id("expected").Op(":=").Append(Index().String().Values(), Id("tc").Dot("responses").Op("...")),
+					Qual(PackageSort, "Strings").Call(Id("expected")),
+					If(Qual(PackageStrings, "Join").Call(Id("responses"), Lit(",")).Op("!=").Qual(PackageStrings, "Join").Call(Id("expected"), Lit(","))).Block(
+						Id("t").Dot("Fatalf").Call(Lit("expected responses %v, got %v"), Id("expected"), Id("responses")),
+					),
+				)),
+			),
+		)
+
+	srcFile.Line().Comment("postConformance отправляет запрос проверки и возвращает ответы в виде id:code.")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("postConformance").
+		Params(Id("t").Op("*").Qual(PackageTesting, "T"), Id("tc").Id("conformanceCase")).
+		Params(Id("result").Index().String()).
+		Block(
+			Line().Id("t").Dot("Helper").Call(),
+			List(Id("response"), Err()).Op(":=").Id("srv").Dot("client").Dot("Post").Call(Id("baseURL").Op("+").Lit("/"), Lit("application/json"), Qual(PackageStrings, "NewReader").Call(Id("tc").Dot("body"))),
+			If(Err().Op("!=").Nil()).Block(
+				Id("t").Dot("Fatalf").Call(Lit("request failed: %v"), Err()),
+			),
+			Defer().Id("response").Dot("Body").Dot("Close").Call(),
+			List(Id("body"), Err()).Op(":=").Qual(PackageIO, "ReadAll").Call(Id("response").Dot("Body")),
+			If(Err().Op("!=").Nil()).Block(
+				Id("t").Dot("Fatalf").Call(Lit("read response: %v"), Err()),
+			),
+			If(Id("body").Op("=").Qual(PackageBytes, "TrimSpace").Call(Id("body")), Len(Id("body")).Op("==").Lit(0)).Block(
+				If(Id("response").Dot("StatusCode").Op("!=").Qual(PackageNetHTTP, "StatusNoContent").Op("&&").Id("response").Dot("StatusCode").Op("!=").Qual(PackageNetHTTP, "StatusOK")).Block(
+					Id("t").Dot("Fatalf").Call(Lit("unexpected HTTP status %d without body"), Id("response").Dot("StatusCode")),
+				),
+				Return(Index().String().Values()),
+			),
+			Var().Id("responses").Index().Id("conformanceResponse"),
+			If(Id("tc").Dot("batch")).Block(
+				If(Err().Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("body"), Op("&").Id("responses")).Op(";").Err().Op("!=").Nil()).Block(
+					Id("t").Dot("Fatalf").Call(Lit("expected batch response: %v: %s"), Err(), Id("body")),
+				),
+			).Else().Block(
+				Var().Id("single").Id("conformanceResponse"),
+				If(Err().Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("body"), Op("&").Id("single")).Op(";").Err().Op("!=").Nil()).Block(
+					Id("t").Dot("Fatalf").Call(Lit("expected single response: %v: %s"), Err(), Id("body")),
+				),
+				Id("responses").Op("=").Append(Id("responses"), Id("single")),
+			),
+			Id("result").Op("=").Make(Index().String(), Lit(0), Len(Id("responses"))),
+			For(List(Id("_"), Id("item")).Op(":=").Range().Id("responses")).Block(
+				If(Id("item").Dot("Version").Op("!=").Lit("2.0")).Block(
+					Id("t").Dot("Fatalf").Call(Lit("unexpected protocol version %q: %s"), Id("item").Dot("Version"), Id("body")),
+				),
+				If(Id("item").Dot("Error").Op("==").Nil().Op("||").Op("!").Qual(PackageSlices, "Contains").Call(Index().Int().Values(Lit(-32700), Lit(-32600), Lit(-32601), Lit(-32602)), Id("item").Dot("Error").Dot("Code"))).Block(
+					Id("result").Op("=").Append(Id("result"), Qual(PackageFmt, "Sprintf").Call(Lit("%s:ok"), Id("item").Dot("ID"))),
+					Continue(),
+				),
+				Id("result").Op("=").Append(Id("result"), Qual(PackageFmt, "Sprintf").Call(Lit("%s:%d"), Id("item").Dot("ID"), Id("item").Dot("Error").Dot("Code"))),
+			),
+			Return(),
+		)
+
+	return srcFile.Save(path.Join(testingDir, "conformance.go"))
+}
@@ -22,6 +22,7 @@ func (r *transportRenderer) RenderTransportFiber() error {
 	srcFile.ImportName(PackageSlog, "slog")
 	srcFile.ImportName(PackageContext, "context")
 	srcFile.ImportName(PackageFmt, "fmt")
+	srcFile.ImportName(PackageDebug, "debug")
 
 	srcFile.Line().Const().Id("logLevelHeader").Op("=").Lit("X-Log-Level")
 
@@ -115,7 +116,7 @@ func (r *transportRenderer) renderFiberRecover(srcFile *GoFile) {
 
 	srcFile.Line().Func().Id("recoverHandler").
 		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
-		Error().
+		Params(Id("errRecover").Error()).
 		Block(
 			Defer().Func().Params().Block(
 				If(Id("r").Op(":=").Recover().Op(";").Id("r").Op("!=").Nil().Block(
@@ -128,9 +129,16 @@ func (r *transportRenderer) renderFiberRecover(srcFile *GoFile) {
 							Qual(PackageSlog, "Any").Call(Lit("error"), Qual(PackageErrors, "Wrap").Call(Err(), Lit("recover"))),
 							Qual(PackageSlog, "String").Call(Lit("method"), Id(VarNameFtx).Dot("Method").Call()),
 							Qual(PackageSlog, "String").Call(Lit("path"), Id(VarNameFtx).Dot("OriginalURL").Call()),
+							Qual(PackageSlog, "String").Call(Lit("stack"), String().Call(Qual(PackageDebug, "Stack").Call())),
 						),
 					),
-					Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusInternalServerError")),
+					Do(func(s *Statement) {
+						if r.hasProblem() {
+							s.Id("errRecover").Op("=").Id("sendProblem").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusInternalServerError"), Nil())
+							return
+						}
+						s.Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusInternalServerError"))
+					}),
 				)),
 			).Call(),
 			Return(Id(VarNameFtx).Dot("Next").Call()),
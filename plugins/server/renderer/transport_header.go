@@ -29,6 +29,7 @@ func (r *transportRenderer) RenderTransportHeader() error {
 	r.renderHeaderHandler(&srcFile)
 	r.renderHeaderValue(&srcFile, jsonPkg)
 	r.renderHeaderValueInterface(&srcFile)
+	r.renderRequestID(&srcFile)
 
 	return srcFile.Save(headerPath)
 }
@@ -85,6 +86,11 @@ func (r *transportRenderer) renderHeaderHandler(srcFile *GoFile) {
 					),
 				),
 			)
+			bg.If(Id("srv").Dot("requestIDHeader").Op("!=").Lit("")).Block(
+				If(Id("requestID").Op(":=").String().Call(Id("resp").Dot("Header").Dot("Peek").Call(Id("srv").Dot("requestIDHeader"))).Op(";").Id("requestID").Op("!=").Lit("")).Block(
+					Id("updatedCtx").Op("=").Qual(PackageContext, "WithValue").Call(Id("updatedCtx"), Id("requestIDKey").Values(), Id("requestID")),
+				),
+			)
 			bg.If(Len(Id("logAttrs")).Op(">").Lit(0)).Block(
 				If(Id("logger").Op("!=").Nil()).Block(
 					Id("args").Op(":=").Make(Index().Any(), Lit(0), Len(Id("logAttrs"))),
@@ -137,3 +143,18 @@ func (r *transportRenderer) renderHeaderValueInterface(srcFile *GoFile) {
 		Id("Cookie").Params().Params(Qual(PackageFiber, "Cookie")),
 	)
 }
+
+// renderRequestID генерирует хранение идентификатора запроса в контексте.
+func (r *transportRenderer) renderRequestID(srcFile *GoFile) {
+
+	srcFile.Line().Type().Id("requestIDKey").Struct()
+
+	srcFile.Line().Comment("RequestIDFromContext возвращает идентификатор запроса, заданный опцией WithRequestID.")
+	srcFile.Func().Id("RequestIDFromContext").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context")).
+		Params(Id("requestID").String()).
+		Block(
+			List(Id("requestID"), Id("_")).Op("=").Id(VarNameCtx).Dot("Value").Call(Id("requestIDKey").Values()).Op(".").Call(String()),
+			Return(),
+		)
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// RenderTransportHealth генерирует транспортный health файл с пробами /healthz и /readyz.
+func (r *transportRenderer) RenderTransportHealth() error {
+
+	healthPath := path.Join(r.outDir, "health.go")
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(PackageSync, "sync")
+	srcFile.ImportName(PackageTime, "time")
+
+	srcFile.Line().Const().Defs(
+		Id("healthzPath").Op("=").Lit("/healthz"),
+		Id("readyzPath").Op("=").Lit("/readyz"),
+		Id("defaultHealthCheckTimeout").Op("=").Lit(5).Op("*").Qual(PackageTime, "Second"),
+		Line(),
+		Id("healthStatusOK").Op("=").Lit("ok"),
+		Id("healthStatusFail").Op("=").Lit("fail"),
+		Id("healthStatusDraining").Op("=").Lit("draining"),
+	)
+
+	srcFile.Line().Comment("HealthChecker проверяет готовность зависимости сервиса к обработке запросов.")
+	srcFile.Type().Id("HealthChecker").Interface(
+		Id("Check").Params(Id(VarNameCtx).Qual(PackageContext, "Context")).Error(),
+	)
+
+	srcFile.Line().Comment("HealthCheckerFunc позволяет использовать функцию как HealthChecker.")
+	srcFile.Type().Id("HealthCheckerFunc").Func().Params(Id(VarNameCtx).Qual(PackageContext, "Context")).Error()
+
+	srcFile.Line().Func().Params(Id("f").Id("HealthCheckerFunc")).
+		Id("Check").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context")).
+		Error().
+		Block(
+			Return(Id("f").Call(Id(VarNameCtx))),
+		)
+
+	srcFile.Line().Type().Id("healthCheck").Struct(
+		Id("name").String(),
+		Id("checker").Id("HealthChecker"),
+	)
+
+	srcFile.Line().Type().Id("healthResponse").Struct(
+		Id("Status").String().Tag(map[string]string{"json": "status"}),
+		Id("Checks").Map(String()).String().Tag(map[string]string{"json": "checks,omitempty"}),
+	)
+
+	srcFile.Line().Add(r.serveHealthzFunc())
+	srcFile.Line().Add(r.serveReadyzFunc())
+	srcFile.Line().Add(r.drainFunc())
+	if r.hasMetrics() {
+		srcFile.Line().Add(r.inFlightHandlerFunc())
+	}
+
+	return srcFile.Save(healthPath)
+}
+
+// serveHealthzFunc генерирует обработчик пробы живости /healthz.
+func (r *transportRenderer) serveHealthzFunc() Code {
+
+	return Comment("serveHealthz отвечает на пробу живости, пока процесс способен обрабатывать запросы.").Line().
+		Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("serveHealthz").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Error().
+		Block(
+			Return(Id("sendResponse").Call(Id(VarNameFtx), Id("healthResponse").Values(Dict{Id("Status"): Id("healthStatusOK")}))),
+		)
+}
+
+// serveReadyzFunc генерирует обработчик пробы готовности /readyz.
+func (r *transportRenderer) serveReadyzFunc() Code {
+
+	return Comment("serveReadyz опрашивает зарегистрированные HealthChecker и отвечает 503 при ошибке или выводе из балансировки.").Line().
+		Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("serveReadyz").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Error().
+		Block(
+			Line(),
+			If(Id("srv").Dot("draining").Dot("Load").Call()).Block(
+				Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusServiceUnavailable")),
+				Return(Id("sendResponse").Call(Id(VarNameFtx), Id("healthResponse").Values(Dict{Id("Status"): Id("healthStatusDraining")}))),
+			),
+			List(Id(VarNameCtx), Id("cancel")).Op(":=").Qual(PackageContext, "WithTimeout").Call(Id(VarNameFtx).Dot("UserContext").Call(), Id("defaultHealthCheckTimeout")),
+			Defer().Id("cancel").Call(),
+			Line(),
+			Id("response").Op(":=").Id("healthResponse").Values(Dict{Id("Status"): Id("healthStatusOK")}),
+			If(Len(Id("srv").Dot("healthChecks")).Op("!=").Lit(0)).Block(
+				Id("response").Dot("Checks").Op("=").Make(Map(String()).String(), Len(Id("srv").Dot("healthChecks"))),
+			),
+			Var().Id("mu").Qual(PackageSync, "Mutex"),
+			Var().Id("wg").Qual(PackageSync, "WaitGroup"),
+			For(List(Id("_"), Id("check")).Op(":=").Range().Id("srv").Dot("healthChecks")).Block(
+				Id("wg").Dot("Add").Call(Lit(1)),
+				Go().Func().Params(Id("check").Id("healthCheck")).Block(
+					Defer().Id("wg").Dot("Done").Call(),
+					Err().Op(":=").Id("check").Dot("checker").Dot("Check").Call(Id(VarNameCtx)),
+					Id("mu").Dot("Lock").Call(),
+					Defer().Id("mu").Dot("Unlock").Call(),
+					If(Err().Op("!=").Nil()).Block(
+						Id("response").Dot("Status").Op("=").Id("healthStatusFail"),
+						Id("response").Dot("Checks").Index(Id("check").Dot("name")).Op("=").Err().Dot("Error").Call(),
+						Return(),
+					),
+					Id("response").Dot("Checks").Index(Id("check").Dot("name")).Op("=").Id("healthStatusOK"),
+				).Call(Id("check")),
+			),
+			Id("wg").Dot("Wait").Call(),
+			If(Id("response").Dot("Status").Op("!=").Id("healthStatusOK")).Block(
+				Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusServiceUnavailable")),
+			),
+			Return(Id("sendResponse").Call(Id(VarNameFtx), Id("response"))),
+		)
+}
+
+// drainFunc генерирует функцию Drain.
+func (r *transportRenderer) drainFunc() Code {
+
+	return Comment("Drain выводит сервер из балансировки: /readyz начинает отвечать 503, запросы продолжают обслуживаться.").Line().
+		Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("Drain").
+		Params().
+		Block(
+			Id("srv").Dot("draining").Dot("Store").Call(True()),
+		)
+}
+
+// inFlightHandlerFunc генерирует middleware подсчёта обрабатываемых запросов.
+func (r *transportRenderer) inFlightHandlerFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("inFlightHandler").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Error().
+		BlockFunc(func(bg *Group) {
+			bg.If(Id("srv").Dot("metrics").Op("==").Nil()).Block(
+				Return(Id(VarNameFtx).Dot("Next").Call()),
+			)
+			if r.metricsOTel() {
+				bg.Id(VarNameCtx).Op(":=").Id(VarNameFtx).Dot("UserContext").Call()
+				bg.Id("srv").Dot("metrics").Dot("RequestsInFlight").Dot("Add").Call(Id(VarNameCtx), Lit(1))
+				bg.Defer().Id("srv").Dot("metrics").Dot("RequestsInFlight").Dot("Add").Call(Id(VarNameCtx), Lit(-1))
+			} else {
+				bg.Id("srv").Dot("metrics").Dot("RequestsInFlight").Dot("Inc").Call()
+				bg.Defer().Id("srv").Dot("metrics").Dot("RequestsInFlight").Dot("Dec").Call()
+			}
+			bg.Return(Id(VarNameFtx).Dot("Next").Call())
+		})
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// RenderTransportHooks генерирует универсальный хук OnCall для аудита и пользовательских метрик.
+func (r *transportRenderer) RenderTransportHooks() error {
+
+	hooksPath := path.Join(r.outDir, "hooks.go")
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageStrings, "strings")
+
+	srcFile.Line().Comment("CallHook - хук, вызываемый после каждого метода. request и response - указатели на <Contract><Method>Request и <Contract><Method>Response.")
+	srcFile.Type().Id("CallHook").Func().Params(
+		Id(VarNameCtx).Qual(PackageContext, "Context"),
+		Id("contract").String(),
+		Id("method").String(),
+		Id("request").Any(),
+		Id("response").Any(),
+		Err().Error(),
+	)
+
+	srcFile.Line().Comment("callExchanges - сборка структур обмена по имени метода (Contract.Method).")
+	srcFile.Var().Id("callExchanges").Op("=").Map(String()).Func().Params(Id("args").Index().Any(), Id("results").Index().Any()).Params(Any(), Any()).Values(DictFunc(func(dict Dict) {
+		for _, contract := range r.project.Contracts {
+			for _, method := range contract.Methods {
+				requestName := contract.Name + method.Name + "Request"
+				responseName := contract.Name + method.Name + "Response"
+				dict[Lit(contract.Name+"."+method.Name)] = Func().Params(Id("args").Index().Any(), Id("results").Index().Any()).Params(Any(), Any()).Block(
+					Id("request").Op(":=").Id("new"+requestName).Call(Id("args")),
+					Id("response").Op(":=").Id("new"+responseName).Call(Id("results")),
+					Return(Op("&").Id("request"), Op("&").Id("response")),
+				)
+			}
+		}
+	}))
+
+	srcFile.Line().Comment("OnCall регистрирует хук, вызываемый после каждого метода всех контрактов (как middleware из WithMiddleware).")
+	srcFile.Func().Id("OnCall").
+		Params(Id("hook").Id("CallHook")).
+		Id("Option").
+		Block(
+			Return(Id("WithMiddleware").Call(Func().Params(Id(VarNameNext).Id("CallHandler")).Id("CallHandler").Block(
+				Return(Func().Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("method").String(), Id("args").Index().Any()).Params(Index().Any(), Error()).Block(
+					List(Id("results"), Err()).Op(":=").Id(VarNameNext).Call(Id(VarNameCtx), Id("method"), Id("args")),
+					Var().List(Id("request"), Id("response")).Any(),
+					If(List(Id("exchange"), Id("ok")).Op(":=").Id("callExchanges").Index(Id("method")), Id("ok")).Block(
+						List(Id("request"), Id("response")).Op("=").Id("exchange").Call(Id("args"), Id("results")),
+					),
+					List(Id("contractName"), Id("methodName"), Id("_")).Op(":=").Qual(PackageStrings, "Cut").Call(Id("method"), Lit(".")),
+					Id("hook").Call(Id(VarNameCtx), Id("contractName"), Id("methodName"), Id("request"), Id("response"), Err()),
+					Return(Id("results"), Err()),
+				)),
+			))),
+		)
+
+	return srcFile.Save(hooksPath)
+}
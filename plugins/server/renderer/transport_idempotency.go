@@ -0,0 +1,347 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// RenderTransportIdempotency генерирует транспортный idempotency файл.
+func (r *transportRenderer) RenderTransportIdempotency() error {
+
+	if !r.hasIdempotent() {
+		return nil
+	}
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageBytes, "bytes")
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageSha256, "sha256")
+	srcFile.ImportName(PackageHex, "hex")
+	srcFile.ImportName(PackageStdJSON, "json")
+	srcFile.ImportName(PackageSlog, "slog")
+	srcFile.ImportName(PackageStrings, "strings")
+	srcFile.ImportName(PackageSync, "sync")
+	srcFile.ImportName(PackageTime, "time")
+	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(PackageErrors, "errors")
+
+	srcFile.Line().Const().Defs(
+		Id("idempotencyKeyHeader").Op("=").Lit("Idempotency-Key"),
+		Id("idempotencyReplayedHeader").Op("=").Lit("Idempotent-Replayed"),
+		Id("idempotencyConflictError").Op("=").Lit(-32009),
+		Id("defaultIdempotencyTTL").Op("=").Lit(24).Op("*").Qual(PackageTime, "Hour"),
+		Id("maxMemoryIdempotencyRecords").Op("=").Lit(65536),
+	)
+
+	srcFile.Line().Var().Defs(
+		Comment("ErrIdempotencyInFlight запрос с тем же ключом идемпотентности ещё выполняется."),
+		Id("ErrIdempotencyInFlight").Op("=").Qual(PackageErrors, "New").Call(Lit("request with the same idempotency key is in progress")),
+		Comment("ErrIdempotencyMismatch ключ идемпотентности использован с другим запросом."),
+		Id("ErrIdempotencyMismatch").Op("=").Qual(PackageErrors, "New").Call(Lit("idempotency key was used with a different request")),
+	)
+
+	srcFile.Line().Comment("IdempotencyRecord сохранённый ответ идемпотентного запроса.")
+	srcFile.Type().Id("IdempotencyRecord").Struct(
+		Id("StatusCode").Int(),
+		Id("ContentType").String(),
+		Id("Body").Index().Byte(),
+	)
+
+	srcFile.Line().Comment("IdempotencyStore хранилище ответов идемпотентных запросов (например, Redis).")
+	srcFile.Type().Id("IdempotencyStore").Interface(
+		Comment("Begin резервирует ключ или возвращает сохранённый ответ. Для выполняющегося запроса возвращает ErrIdempotencyInFlight,").Line().
+			Comment("для ключа, использованного с другим запросом, ErrIdempotencyMismatch.").Line().
+			Id("Begin").Params(Id(VarNameCtx).Qual(PackageContext, "Context"), List(Id("key"), Id("fingerprint")).String(), Id("ttl").Qual(PackageTime, "Duration")).
+			Params(Id("record").Op("*").Id("IdempotencyRecord"), Err().Error()),
+		Comment("Complete сохраняет ответ выполненного запроса."),
+		Id("Complete").Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("key").String(), Id("record").Id("IdempotencyRecord"), Id("ttl").Qual(PackageTime, "Duration")).Error(),
+		Comment("Release снимает резерв ключа, позволяя повторить запрос."),
+		Id("Release").Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("key").String()).Error(),
+	)
+
+	srcFile.Line().Add(r.memoryIdempotencyStoreType())
+	srcFile.Line().Add(r.newMemoryIdempotencyStoreFunc())
+	srcFile.Line().Add(r.memoryIdempotencyStoreFuncs())
+	srcFile.Line().Type().Id("idempotencyKeyCtx").Struct()
+	srcFile.Line().Add(r.idempotencyKeyHandlerFunc())
+	srcFile.Line().Add(r.idempotentCallType())
+	srcFile.Line().Add(r.beginIdempotentFunc())
+	srcFile.Line().Add(r.idempotentCallCompleteFunc())
+	if r.hasJsonRPC() {
+		srcFile.Line().Add(r.idempotentJsonRPCFuncs())
+	}
+	srcFile.Line().Add(r.idempotentHTTPFuncs())
+
+	return srcFile.Save(path.Join(r.outDir, "idempotency.go"))
+}
+
+// memoryIdempotencyStoreType генерирует тип хранилища идемпотентных ответов в памяти.
+func (r *transportRenderer) memoryIdempotencyStoreType() Code {
+
+	return Type().Id("idempotencyEntry").Struct(
+		Id("fingerprint").String(),
+		Id("record").Op("*").Id("IdempotencyRecord"),
+		Id("expires").Qual(PackageTime, "Time"),
+	).Line().Line().Type().Id("memoryIdempotencyStore").Struct(
+		Id("mu").Qual(PackageSync, "Mutex"),
+		Id("entries").Map(String()).Op("*").Id("idempotencyEntry"),
+	)
+}
+
+// newMemoryIdempotencyStoreFunc генерирует конструктор хранилища идемпотентных ответов в памяти.
+func (r *transportRenderer) newMemoryIdempotencyStoreFunc() Code {
+
+	return Func().Id("NewMemoryIdempotencyStore").
+		Params().
+		Params(Id("IdempotencyStore")).
+		Block(
+			Return(Op("&").Id("memoryIdempotencyStore").Values(Dict{
+				Id("entries"): Make(Map(String()).Op("*").Id("idempotencyEntry")),
+			})),
+		)
+}
+
+// memoryIdempotencyStoreFuncs генерирует методы хранилища идемпотентных ответов в памяти.
+func (r *transportRenderer) memoryIdempotencyStoreFuncs() Code {
+
+	return Func().Params(Id("s").Op("*").Id("memoryIdempotencyStore")).
+		Id("Begin").
+		Params(Id("_").Qual(PackageContext, "Context"), List(Id("key"), Id("fingerprint")).String(), Id("ttl").Qual(PackageTime, "Duration")).
+		Params(Id("record").Op("*").Id("IdempotencyRecord"), Err().Error()).
+		Block(
+			Line().Id("now").Op(":=").Qual(PackageTime, "Now").Call(),
+			Id("s").Dot("mu").Dot("Lock").Call(),
+			Defer().Id("s").Dot("mu").Dot("Unlock").Call(),
+			Line().If(Len(Id("s").Dot("entries")).Op(">=").Id("maxMemoryIdempotencyRecords")).Block(
+				For(List(Id("entryKey"), Id("entry")).Op(":=").Range().Id("s").Dot("entries")).Block(
+					If(Id("now").Dot("After").Call(Id("entry").Dot("expires"))).Block(
+						Delete(Id("s").Dot("entries"), Id("entryKey")),
+					),
+				),
+			),
+			List(Id("entry"), Id("found")).Op(":=").Id("s").Dot("entries").Index(Id("key")),
+			If(Op("!").Id("found").Op("||").Id("now").Dot("After").Call(Id("entry").Dot("expires"))).Block(
+				Id("s").Dot("entries").Index(Id("key")).Op("=").Op("&").Id("idempotencyEntry").Values(Dict{
+					Id("fingerprint"): Id("fingerprint"),
+					Id("expires"):     Id("now").Dot("Add").Call(Id("ttl")),
+				}),
+				Return(Nil(), Nil()),
+			),
+			If(Id("entry").Dot("fingerprint").Op("!=").Id("fingerprint")).Block(
+				Return(Nil(), Id("ErrIdempotencyMismatch")),
+			),
+			If(Id("entry").Dot("record").Op("==").Nil()).Block(
+				Return(Nil(), Id("ErrIdempotencyInFlight")),
+			),
+			Return(Id("entry").Dot("record"), Nil()),
+		).
+		Line().Line().
+		Func().Params(Id("s").Op("*").Id("memoryIdempotencyStore")).
+		Id("Complete").
+		Params(Id("_").Qual(PackageContext, "Context"), Id("key").String(), Id("record").Id("IdempotencyRecord"), Id("ttl").Qual(PackageTime, "Duration")).
+		Error().
+		Block(
+			Line().Id("s").Dot("mu").Dot("Lock").Call(),
+			Defer().Id("s").Dot("mu").Dot("Unlock").Call(),
+			If(List(Id("entry"), Id("found")).Op(":=").Id("s").Dot("entries").Index(Id("key")).Op(";").Id("found")).Block(
+				Id("entry").Dot("record").Op("=").Op("&").Id("record"),
+				Id("entry").Dot("expires").Op("=").Qual(PackageTime, "Now").Call().Dot("Add").Call(Id("ttl")),
+			),
+			Return(Nil()),
+		).
+		Line().Line().
+		Func().Params(Id("s").Op("*").Id("memoryIdempotencyStore")).
+		Id("Release").
+		Params(Id("_").Qual(PackageContext, "Context"), Id("key").String()).
+		Error().
+		Block(
+			Line().Id("s").Dot("mu").Dot("Lock").Call(),
+			Defer().Id("s").Dot("mu").Dot("Unlock").Call(),
+			Delete(Id("s").Dot("entries"), Id("key")),
+			Return(Nil()),
+		)
+}
+
+// idempotencyKeyHandlerFunc генерирует middleware, сохраняющий ключ идемпотентности в контексте.
+func (r *transportRenderer) idempotencyKeyHandlerFunc() Code {
+
+	return Func().Id("idempotencyKeyHandler").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Error()).
+		Block(
+			Line().If(Id("key").Op(":=").Id(VarNameFtx).Dot("Get").Call(Id("idempotencyKeyHeader")).Op(";").Id("key").Op("!=").Lit("")).Block(
+				Id(VarNameFtx).Dot("SetUserContext").Call(Qual(PackageContext, "WithValue").Call(Id(VarNameFtx).Dot("UserContext").Call(), Id("idempotencyKeyCtx").Values(), Qual(PackageStrings, "Clone").Call(Id("key")))),
+			),
+			Return(Id(VarNameFtx).Dot("Next").Call()),
+		)
+}
+
+// idempotentCallType генерирует тип выполняемого идемпотентного вызова.
+func (r *transportRenderer) idempotentCallType() Code {
+
+	return Type().Id("idempotentCall").Struct(
+		Id("srv").Op("*").Id("Server"),
+		Id("key").String(),
+	)
+}
+
+// beginIdempotentFunc генерирует резервирование ключа идемпотентности.
+func (r *transportRenderer) beginIdempotentFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("beginIdempotent").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), List(Id("scope"), Id("key")).String(), Id("payload").Index().Byte()).
+		Params(Id("call").Op("*").Id("idempotentCall"), Id("record").Op("*").Id("IdempotencyRecord"), Err().Error()).
+		Block(
+			Line().If(Id("key").Op("==").Lit("").Op("||").Id("srv").Op("==").Nil().Op("||").Id("srv").Dot("idempotencyStore").Op("==").Nil()).Block(
+				Return(Nil(), Nil(), Nil()),
+			),
+			Id("sum").Op(":=").Qual(PackageSha256, "Sum256").Call(Id("payload")),
+			Id("call").Op("=").Op("&").Id("idempotentCall").Values(Dict{
+				Id("srv"): Id("srv"),
+				Id("key"): Id("scope").Op("+").Lit(":").Op("+").Id("key"),
+			}),
+			If(List(Id("record"), Err()).Op("=").Id("srv").Dot("idempotencyStore").Dot("Begin").Call(Id(VarNameCtx), Id("call").Dot("key"), Qual(PackageHex, "EncodeToString").Call(Id("sum").Index(Op(":"))), Id("srv").Dot("idempotencyTTL")).Op(";").Err().Op("!=").Nil()).Block(
+				If(Qual(PackageErrors, "Is").Call(Err(), Id("ErrIdempotencyInFlight")).Op("||").Qual(PackageErrors, "Is").Call(Err(), Id("ErrIdempotencyMismatch"))).Block(
+					Return(Nil(), Nil(), Err()),
+				),
+				Id("srv").Dot("log").Dot("Error").Call(Lit("idempotency store failed"), Qual(PackageSlog, "String").Call(Lit("method"), Id("scope")), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
+				Return(Nil(), Nil(), Nil()),
+			),
+			If(Id("record").Op("!=").Nil()).Block(
+				Return(Nil(), Id("record"), Nil()),
+			),
+			Return(),
+		)
+}
+
+// idempotentCallCompleteFunc генерирует сохранение или освобождение результата идемпотентного вызова.
+func (r *transportRenderer) idempotentCallCompleteFunc() Code {
+
+	return Func().Params(Id("call").Op("*").Id("idempotentCall")).
+		Id("complete").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("record").Op("*").Id("IdempotencyRecord")).
+		Block(
+			Line().Id(VarNameCtx).Op("=").Qual(PackageContext, "WithoutCancel").Call(Id(VarNameCtx)),
+			Var().Err().Error(),
+			If(Id("record").Op("==").Nil()).Block(
+				Err().Op("=").Id("call").Dot("srv").Dot("idempotencyStore").Dot("Release").Call(Id(VarNameCtx), Id("call").Dot("key")),
+			).Else().Block(
+				Err().Op("=").Id("call").Dot("srv").Dot("idempotencyStore").Dot("Complete").Call(Id(VarNameCtx), Id("call").Dot("key"), Op("*").Id("record"), Id("call").Dot("srv").Dot("idempotencyTTL")),
+			),
+			If(Err().Op("!=").Nil()).Block(
+				Id("call").Dot("srv").Dot("log").Dot("Error").Call(Lit("idempotency store failed"), Qual(PackageSlog, "String").Call(Lit("key"), Id("call").Dot("key")), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
+			),
+		)
+}
+
+// idempotentJsonRPCFuncs генерирует обработку ключа идемпотентности для JSON-RPC методов.
+func (r *transportRenderer) idempotentJsonRPCFuncs() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("idempotentJsonRPC").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("scope").String(), Id("requestBase").Id("baseJsonRPC")).
+		Params(Id("call").Op("*").Id("idempotentCall"), Id("replay").Op("*").Id("baseJsonRPC")).
+		Block(
+			Line().List(Id("key"), Id("_")).Op(":=").Id(VarNameCtx).Dot("Value").Call(Id("idempotencyKeyCtx").Values()).Assert(String()),
+			List(Id("call"), Id("record"), Err()).Op(":=").Id("srv").Dot("beginIdempotent").Call(Id(VarNameCtx), Id("scope"), Id("key"), Id("requestBase").Dot("Params")),
+			If(Err().Op("!=").Nil()).Block(
+				Return(Nil(), Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("idempotencyConflictError"), Err().Dot("Error").Call(), Nil())),
+			),
+			If(Id("record").Op("!=").Nil()).Block(
+				Id("replay").Op("=").Op("&").Id("baseJsonRPC").Values(),
+				If(Err().Op("=").Qual(PackageStdJSON, "Unmarshal").Call(Id("record").Dot("Body"), Id("replay")).Op(";").Err().Op("!=").Nil()).Block(
+					Return(Nil(), Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("internalError"), Lit("stored response could not be decoded"), Nil())),
+				),
+				Id("replay").Dot("ID").Op("=").Id("requestBase").Dot("ID"),
+			),
+			Return(),
+		).
+		Line().Line().
+		Func().Params(Id("call").Op("*").Id("idempotentCall")).
+		Id("completeJsonRPC").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("responseBase").Op("*").Id("baseJsonRPC")).
+		Block(
+			Line().If(Id("call").Op("==").Nil()).Block(
+				Return(),
+			),
+			If(Id("responseBase").Op("==").Nil().Op("||").Id("responseBase").Dot("Error").Op("!=").Nil().Op("&&").Id("responseBase").Dot("Error").Dot("Code").Op("==").Id("internalError")).Block(
+				Id("call").Dot("complete").Call(Id(VarNameCtx), Nil()),
+				Return(),
+			),
+			Id("stored").Op(":=").Op("*").Id("responseBase"),
+			Var().Err().Error(),
+			If(Id("stored").Dot("resultValue").Op("!=").Nil()).Block(
+				If(List(Id("stored").Dot("Result"), Err()).Op("=").Qual(PackageStdJSON, "Marshal").Call(Id("stored").Dot("resultValue")).Op(";").Err().Op("!=").Nil()).Block(
+					Id("call").Dot("complete").Call(Id(VarNameCtx), Nil()),
+					Return(),
+				),
+			),
+			List(Id("body"), Err()).Op(":=").Qual(PackageStdJSON, "Marshal").Call(Id("stored")),
+			If(Err().Op("!=").Nil()).Block(
+				Id("call").Dot("complete").Call(Id(VarNameCtx), Nil()),
+				Return(),
+			),
+			Id("call").Dot("complete").Call(Id(VarNameCtx), Op("&").Id("IdempotencyRecord").Values(Dict{
+				Id("StatusCode"):  Qual(PackageFiber, "StatusOK"),
+				Id("ContentType"): Qual(PackageFiber, "MIMEApplicationJSON"),
+				Id("Body"):        Id("body"),
+			})),
+		)
+}
+
+// idempotentHTTPFuncs генерирует обработку ключа идемпотентности для REST методов.
+func (r *transportRenderer) idempotentHTTPFuncs() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("idempotentHTTP").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("scope").String()).
+		Params(Id("call").Op("*").Id("idempotentCall"), Id("handled").Bool(), Err().Error()).
+		Block(
+			Line().Id("payload").Op(":=").Append(Index().Byte().Call(Id(VarNameFtx).Dot("Method").Call().Op("+").Lit(" ").Op("+").Id(VarNameFtx).Dot("OriginalURL").Call().Op("+").Lit("\n")), Id(VarNameFtx).Dot("Body").Call().Op("...")),
+			Var().Id("record").Op("*").Id("IdempotencyRecord"),
+			List(Id("call"), Id("record"), Err()).Op("=").Id("srv").Dot("beginIdempotent").Call(Id(VarNameFtx).Dot("UserContext").Call(), Id("scope"), Id(VarNameFtx).Dot("Get").Call(Id("idempotencyKeyHeader")), Id("payload")),
+			Switch().Block(
+				Case(Qual(PackageErrors, "Is").Call(Err(), Id("ErrIdempotencyInFlight"))).Block(
+					Return(Nil(), True(), Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusConflict"), Err().Dot("Error").Call())),
+				),
+				Case(Err().Op("!=").Nil()).Block(
+					Return(Nil(), True(), Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusUnprocessableEntity"), Err().Dot("Error").Call())),
+				),
+				Case(Id("record").Op("!=").Nil()).Block(
+					Id(VarNameFtx).Dot("Set").Call(Id("idempotencyReplayedHeader"), Lit("true")),
+					If(Id("record").Dot("ContentType").Op("!=").Lit("")).Block(
+						Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("SetContentType").Call(Id("record").Dot("ContentType")),
+					),
+					Id(VarNameFtx).Dot("Status").Call(Id("record").Dot("StatusCode")),
+					Return(Nil(), True(), Id(VarNameFtx).Dot("Send").Call(Id("record").Dot("Body"))),
+				),
+			),
+			Return(),
+		).
+		Line().Line().
+		Func().Params(Id("call").Op("*").Id("idempotentCall")).
+		Id("completeHTTP").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Err().Error()).
+		Block(
+			Line().If(Id("call").Op("==").Nil()).Block(
+				Return(),
+			),
+			Id("statusCode").Op(":=").Id(VarNameFtx).Dot("Response").Call().Dot("StatusCode").Call(),
+			If(Err().Op("!=").Nil().Op("||").Id("statusCode").Op(">=").Qual(PackageFiber, "StatusInternalServerError")).Block(
+				Id("call").Dot("complete").Call(Id(VarNameFtx).Dot("UserContext").Call(), Nil()),
+				Return(),
+			),
+			Id("call").Dot("complete").Call(Id(VarNameFtx).Dot("UserContext").Call(), Op("&").Id("IdempotencyRecord").Values(Dict{
+				Id("StatusCode"):  Id("statusCode"),
+				Id("ContentType"): String().Call(Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("ContentType").Call()),
+				Id("Body"):        Qual(PackageBytes, "Clone").Call(Id(VarNameFtx).Dot("Response").Call().Dot("Body").Call()),
+			})),
+		)
+}
@@ -46,6 +46,8 @@ func (r *transportRenderer) renderJsonRPCTypes(srcFile *GoFile) {
 
 	srcFile.Line().Add(r.jsonrpcConstants())
 	srcFile.Add(r.idJsonRPC()).Line()
+	srcFile.Comment("nullIDJsonRPC - идентификатор ответа на запрос, id которого не удалось определить.")
+	srcFile.Var().Id("nullIDJsonRPC").Op("=").Id("idJsonRPC").Call(Lit("null")).Line()
 	srcFile.Add(r.baseJsonRPC()).Line()
 	srcFile.Add(r.errorJsonRPC()).Line()
 }
@@ -73,6 +75,11 @@ func (r *transportRenderer) renderJsonRPCFunctions(srcFile *GoFile) {
 	srcFile.Line()
 	srcFile.Line().Add(r.jsonRPCMethodMap())
 	srcFile.Line()
+	if r.hasDeprecated() {
+		srcFile.Add(r.jsonRPCDeprecatedMap())
+		srcFile.Line().Add(r.setDeprecationHeadersFunc())
+		srcFile.Line()
+	}
 	srcFile.Add(r.serveBatchFunc())
 	srcFile.Add(r.batchFunc())
 	srcFile.Add(r.singleBatchFunc())
@@ -81,6 +88,12 @@ func (r *transportRenderer) renderJsonRPCFunctions(srcFile *GoFile) {
 	srcFile.Add(r.toLowercaseMethodFunc())
 	srcFile.Add(r.sanitizeErrorMessageFunc())
 	srcFile.Add(r.validateJsonRPCRequestFunc())
+	srcFile.Line().Add(r.validIDJsonRPCFunc())
+	srcFile.Line().Add(r.responseIDJsonRPCFunc())
+	srcFile.Line().Add(r.decodeRequestsJsonRPCFunc())
+	srcFile.Line().Add(r.decodeRequestJsonRPCFunc())
+	srcFile.Line().Add(r.positionalParamsJsonRPCFunc())
+	srcFile.Line().Add(r.decodePositionalJsonRPCFunc())
 	srcFile.Line()
 	srcFile.Line().Add(r.makeErrorResponseJsonRPCFunc())
 }
@@ -120,6 +133,9 @@ func (r *transportRenderer) baseJsonRPC() Code {
 		tg.Id("Error").Op("*").Id("errorJsonRPC").Tag(map[string]string{"json": "error,omitempty"})
 		tg.Id("Params").Qual(jsonPkg, "RawMessage").Tag(map[string]string{"json": "params,omitempty"})
 		tg.Id("Result").Qual(jsonPkg, "RawMessage").Tag(map[string]string{"json": "result,omitempty"})
+		tg.Line().Id("codec").Id("Codec")
+		tg.Id("resultValue").Any()
+		tg.Id("decodeErr").Error()
 	})
 }
 
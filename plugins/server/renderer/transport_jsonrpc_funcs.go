@@ -4,7 +4,6 @@ package renderer
 
 import (
 	"fmt"
-	"strings"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
 
@@ -23,35 +22,37 @@ func (r *transportRenderer) jsonBufferPools() Code {
 	})
 }
 
-// jsonRPCMethodMap генерирует карту методов JSON-RPC.
+// jsonRPCMethodMap генерирует карту методов JSON-RPC зарегистрированных контрактов (методы остальных контрактов не найдены).
 func (r *transportRenderer) jsonRPCMethodMap() Code {
 
 	return Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("jsonRPCMethodMap").
 		Params().
-		Params(Map(String()).Id("methodJsonRPC")).
+		Params(Id("methods").Map(String()).Id("methodJsonRPC")).
 		BlockFunc(func(bg *Group) {
-			bg.Return(Map(String()).Id("methodJsonRPC").Values(DictFunc(func(dict Dict) {
-				for _, contract := range r.project.Contracts {
-					if !contract.Annotations.Contains(TagServerJsonRPC) {
-						continue
+			bg.Line().Id("methods").Op("=").Make(Map(String()).Id("methodJsonRPC"))
+			for _, contract := range r.project.Contracts {
+				var methods []*parser.Method
+				for _, method := range contract.Methods {
+					if contract.Annotations.Contains(TagServerJsonRPC) && r.methodIsJsonRPCForContract(contract, method) {
+						methods = append(methods, method)
 					}
-					for _, method := range contract.Methods {
-						if !r.methodIsJsonRPCForContract(contract, method) {
-							continue
-						}
-						contractLCName := strings.ToLower(contract.Name)
-						methodLCName := strings.ToLower(method.Name)
-						methodKey := contractLCName + "." + methodLCName
-						dict[Lit(methodKey)] = Func().
+				}
+				if len(methods) == 0 {
+					continue
+				}
+				bg.If(Id("srv").Dot("http" + contract.Name).Op("!=").Nil()).BlockFunc(func(ig *Group) {
+					for _, method := range methods {
+						ig.Id("methods").Index(Lit(jsonRPCMethodName(contract, method))).Op("=").Func().
 							Params(Id(VarNameCtx).Qual(fmt.Sprintf("%s/context", r.pkgPath(r.outDir)), "Context"), Id("requestBase").Id("baseJsonRPC")).
 							Params(Id("responseBase").Op("*").Id("baseJsonRPC")).
 							Block(
 								Return(Id("srv").Dot("http"+contract.Name).Dot(toLowerCamel(method.Name)+"WithContext").Call(Id(VarNameCtx), Id("requestBase"))),
 							)
 					}
-				}
-			})))
+				})
+			}
+			bg.Return()
 		})
 }
 
@@ -66,7 +67,10 @@ func (r *transportRenderer) singleBatchFunc() Code {
 			bg.Line()
 			bg.Var().Err().Error()
 			bg.If(Err().Op("=").Id("validateJsonRPCRequest").Call(Id("request")).Op(";").Err().Op("!=").Nil()).Block(
-				Return(Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("invalidRequestError"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call(), Nil())),
+				Return(Id("makeErrorResponseJsonRPC").Call(Id("responseIDJsonRPC").Call(Id("request").Dot("ID")), Id("invalidRequestError"), Lit("invalid JSON-RPC request: ").Op("+").Err().Dot("Error").Call(), Nil())),
+			)
+			bg.If(Id("request").Dot("Method").Op("==").Lit("")).Block(
+				Return(Id("makeErrorResponseJsonRPC").Call(Id("responseIDJsonRPC").Call(Id("request").Dot("ID")), Id("invalidRequestError"), Lit("invalid JSON-RPC request: missing method"), Nil())),
 			)
 			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
 			bg.Id("method").Op(":=").Id("toLowercaseMethod").Call(Id("methodNameOrigin"))
@@ -75,7 +79,12 @@ func (r *transportRenderer) singleBatchFunc() Code {
 			bg.If(Op("!").Id("ok")).Block(
 				Return(Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("methodNotFoundError"), Lit("invalid method '").Op("+").Id("methodNameOrigin").Op("+").Lit("'"), Nil())),
 			)
-			bg.Return(Id("handler").Call(Id(VarNameCtx), Id("request")))
+			bg.Id("response").Op("=").Id("handler").Call(Id(VarNameCtx), Id("request"))
+			// Notification выполняется, но не получает ответа
+			bg.If(Id("request").Dot("ID").Op("==").Nil()).Block(
+				Return(Nil()),
+			)
+			bg.Return()
 		})
 }
 
@@ -103,7 +112,7 @@ func (r *transportRenderer) batchFunc() Code {
 				Id("syncResponses").Op(":=").Make(Index().Op("*").Id("baseJsonRPC"), Lit(0), Len(Id("requests"))),
 				For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
 					Id("response").Op(":=").Id("srv").Dot("doSingleBatch").Call(Id("batchCtx"), Id("request")),
-					If(Id("request").Dot("ID").Op("!=").Nil()).Block(
+					If(Id("response").Op("!=").Nil()).Block(
 						Id("syncResponses").Op("=").Append(Id("syncResponses"), Id("response")),
 					),
 				),
@@ -116,13 +125,8 @@ func (r *transportRenderer) batchFunc() Code {
 			)
 			bg.Id("callCh").Op(":=").Make(Chan().Id("baseJsonRPC"), Id("batchSize"))
 			bg.Line()
-			// Подсчитываем ожидаемое количество ответов для оптимального размера буфера канала
-			bg.Id("expectedCount").Op(":=").Lit(0)
-			bg.For(List(Id("_"), Id("req")).Op(":=").Range().Id("requests")).Block(
-				If(Id("req").Dot("ID").Op("!=").Nil()).Block(
-					Id("expectedCount").Op("++"),
-				),
-			)
+			// Каждый запрос даёт результат: nil для notification, ответ с ошибкой для некорректного запроса
+			bg.Id("expectedCount").Op(":=").Len(Id("requests"))
 			bg.Id("resultCh").Op(":=").Make(Chan().Op("*").Id("baseJsonRPC"), Id("expectedCount"))
 			bg.Line()
 			bg.For(Id("i").Op(":=").Lit(0).Op(";").Id("i").Op("<").Id("batchSize").Op(";").Id("i").Op("++")).Block(
@@ -136,12 +140,10 @@ func (r *transportRenderer) batchFunc() Code {
 							),
 							Default().BlockFunc(func(dg *Group) {
 								dg.Id("response").Op(":=").Id("srv").Dot("doSingleBatch").Call(Id("batchCtx"), Id("request"))
-								dg.If(Id("request").Dot("ID").Op("!=").Nil()).Block(
-									Select().Block(
-										Case(Id("resultCh").Op("<-").Id("response")).Block(),
-										Case(Op("<-").Id("batchCtx").Dot("Done").Call()).Block(
-											Return(),
-										),
+								dg.Select().Block(
+									Case(Id("resultCh").Op("<-").Id("response")).Block(),
+									Case(Op("<-").Id("batchCtx").Dot("Done").Call()).Block(
+										Return(),
 									),
 								)
 							}),
@@ -162,36 +164,20 @@ func (r *transportRenderer) batchFunc() Code {
 			bg.Close(Id("callCh"))
 			bg.Line()
 			bg.Id("responses").Op("=").Make(Index().Op("*").Id("baseJsonRPC"), Lit(0), Id("expectedCount"))
-			bg.Id("received").Op(":=").Lit(0)
-			bg.If(Id("batchTimeout").Op(">").Lit(0)).Block(
-				For(Id("received").Op("<").Id("expectedCount")).Block(
-					Select().Block(
-						Case(List(Id("resp"), Id("ok")).Op(":=").Op("<-").Id("resultCh")).Block(
-							If(Op("!").Id("ok")).Block(
-								Return(),
-							),
-							Id("responses").Op("=").Append(Id("responses"), Id("resp")),
-							Id("received").Op("++"),
-						),
-						Case(Op("<-").Id("batchCtx").Dot("Done").Call()).Block(
-							If(Id("cancel").Op("!=").Nil()).Block(
-								Id("cancel").Call(),
-							),
-							Id("wg").Dot("Wait").Call(),
-							Close(Id("resultCh")),
-							Return(),
+			bg.For(Id("received").Op(":=").Lit(0), Id("received").Op("<").Id("expectedCount"), Id("received").Op("++")).Block(
+				Select().Block(
+					Case(Id("response").Op(":=").Op("<-").Id("resultCh")).Block(
+						If(Id("response").Op("!=").Nil()).Block(
+							Id("responses").Op("=").Append(Id("responses"), Id("response")),
 						),
 					),
+					Case(Op("<-").Id("batchCtx").Dot("Done").Call()).Block(
+						Id("wg").Dot("Wait").Call(),
+						Return(),
+					),
 				),
-				Id("wg").Dot("Wait").Call(),
-				Close(Id("resultCh")),
-			).Else().Block(
-				For(Id("response").Op(":=").Range().Id("resultCh")).Block(
-					Id("responses").Op("=").Append(Id("responses"), Id("response")),
-				),
-				Id("wg").Dot("Wait").Call(),
-				Close(Id("resultCh")),
 			)
+			bg.Id("wg").Dot("Wait").Call()
 			bg.Return()
 		})
 }
@@ -199,15 +185,12 @@ func (r *transportRenderer) batchFunc() Code {
 // serveBatchFunc генерирует функцию serveBatch.
 func (r *transportRenderer) serveBatchFunc() Code {
 
-	jsonPkg := r.project.Annotations.Value(TagPackageJSON, PackageStdJSON)
 	return Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("serveBatch").
 		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
 		Params(Id("err").Error()).
 		BlockFunc(func(bg *Group) {
 			bg.Line()
-			bg.Var().Id("single").Bool()
-			bg.Var().Id("requests").Op("[]").Id("baseJsonRPC")
 			bg.Id("methodHTTP").Op(":=").Id(VarNameFtx).Dot("Method").Call()
 			bg.If(Id("methodHTTP").Op("!=").Qual(PackageFiber, "MethodPost")).BlockFunc(func(ig *Group) {
 				ig.Id(VarNameFtx).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(PackageFiber, "StatusMethodNotAllowed"))
@@ -216,40 +199,28 @@ func (r *transportRenderer) serveBatchFunc() Code {
 				)
 				ig.Return()
 			})
-			bg.Id("body").Op(":=").Qual(PackageBytes, "TrimSpace").Call(Id(VarNameFtx).Dot("Body").Call())
-			bg.If(Len(Id("body")).Op("==").Lit(0)).Block(
-				Return(Id("sendResponse").Call(Id(VarNameFtx), Id("makeErrorResponseJsonRPC").Call(Nil(), Id("parseError"), Lit("request body could not be decoded: empty body"), Nil()))),
-			)
-			bg.Id("decoder").Op(":=").Qual(jsonPkg, "NewDecoder").Call(Qual(PackageBytes, "NewReader").Call(Id("body")))
-			bg.Id("decoder").Dot("DisallowUnknownFields").Call()
-			bg.Var().Id("token").Interface()
-			bg.List(Id("token"), Err()).Op("=").Id("decoder").Dot("Token").Call()
-			bg.If(Err().Op("!=").Nil()).Block(
-				Return(Id("sendResponse").Call(Id(VarNameFtx), Id("makeErrorResponseJsonRPC").Call(Nil(), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Nil()))),
+			bg.List(Id("requests"), Id("single"), Id("errResponse")).Op(":=").Id("decodeRequestsJsonRPC").Call(Id(VarNameFtx))
+			bg.If(Id("errResponse").Op("!=").Nil()).Block(
+				Return(Id("sendResponse").Call(Id(VarNameFtx), Id("errResponse"))),
 			)
-			bg.If(Id("token").Op("==").Qual(jsonPkg, "Delim").Call(Lit('['))).BlockFunc(func(ig *Group) {
-				// Проверка на пустой массив
-				ig.If(Op("!").Id("decoder").Dot("More").Call()).Block(
-					Return(Id("sendResponse").Call(Id(VarNameFtx), Id("makeErrorResponseJsonRPC").Call(Nil(), Id("invalidRequestError"), Lit("empty batch request"), Nil()))),
-				)
-				ig.For(Id("decoder").Dot("More").Call()).BlockFunc(func(fg *Group) {
-					fg.Var().Id("request").Id("baseJsonRPC")
-					fg.If(Err().Op("=").Id("decoder").Dot("Decode").Call(Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(dg *Group) {
-						dg.Return(Id("sendResponse").Call(Id(VarNameFtx), Id("makeErrorResponseJsonRPC").Call(Nil(), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Nil())))
-					})
-					fg.Id("requests").Op("=").Append(Id("requests"), Id("request"))
-				})
-			}).Else().BlockFunc(func(ig *Group) {
-				ig.Var().Id("request").Id("baseJsonRPC")
-				ig.If(Err().Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("body"), Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ug *Group) {
-					ug.Return(Id("sendResponse").Call(Id(VarNameFtx), Id("makeErrorResponseJsonRPC").Call(Nil(), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Nil())))
-				})
-				ig.Id("single").Op("=").True()
-				ig.Id("requests").Op("=").Append(Id("requests"), Id("request"))
-			})
 			bg.If(Len(Id("requests")).Op(">").Id("srv").Dot("maxBatchSize")).Block(
 				Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusBadRequest"), Lit("batch size exceeded"))),
 			)
+			if r.hasDeprecated() {
+				bg.Id("setDeprecationHeaders").Call(Id(VarNameFtx), Id("requests"))
+			}
+			if r.hasLimits() {
+				// ограничители вызываются без Fiber контекста: Retry-After выставляется по отклонённым ответам
+				bg.If(Id("single")).Block(
+					Id("response").Op(":=").Id("srv").Dot("doSingleBatch").Call(Id(VarNameFtx).Dot("UserContext").Call(), Id("requests").Op("[").Lit(0).Op("]")),
+					Id("setRetryAfterJsonRPC").Call(Id(VarNameFtx), Id("response")),
+					Return(Id("sendResponse").Call(Id(VarNameFtx), Id("response"))),
+				)
+				bg.Id("responses").Op(":=").Id("srv").Dot("doBatch").Call(Id(VarNameFtx), Id("requests"))
+				bg.Id("setRetryAfterJsonRPC").Call(Id(VarNameFtx), Id("responses").Op("..."))
+				bg.Return(Id("sendResponse").Call(Id(VarNameFtx), Id("responses")))
+				return
+			}
 			bg.If(Id("single")).Block(
 				Return(Id("sendResponse").Call(Id(VarNameFtx), Id("srv").Dot("doSingleBatch").Call(Id(VarNameFtx).Dot("UserContext").Call(), Id("requests").Op("[").Lit(0).Op("]")))),
 			)
@@ -293,16 +264,148 @@ func (r *transportRenderer) validateJsonRPCRequestFunc() Code {
 		Params(Id("requestBase").Id("baseJsonRPC")).
 		Params(Err().Error()).
 		BlockFunc(func(bg *Group) {
+			bg.If(Id("requestBase").Dot("decodeErr").Op("!=").Nil()).Block(
+				Return(Id("requestBase").Dot("decodeErr")),
+			)
 			bg.If(Id("requestBase").Dot("Version").Op("==").Lit("")).Block(
 				Return(Qual(PackageErrors, "New").Call(Lit("missing protocol version"))),
 			)
 			bg.If(Id("requestBase").Dot("Version").Op("!=").Id("Version")).Block(
 				Return(Qual(PackageFmt, "Errorf").Call(Lit("incorrect protocol version: %s"), Id("requestBase").Dot("Version"))),
 			)
+			bg.If(Op("!").Id("validIDJsonRPC").Call(Id("requestBase").Dot("ID"))).Block(
+				Return(Qual(PackageErrors, "New").Call(Lit("id must be a string, number or null"))),
+			)
 			bg.Return(Nil())
 		})
 }
 
+// validIDJsonRPCFunc генерирует функцию validIDJsonRPC: id запроса может быть строкой, числом или null.
+func (r *transportRenderer) validIDJsonRPCFunc() Code {
+
+	return Func().Id("validIDJsonRPC").
+		Params(Id("id").Id("idJsonRPC")).
+		Bool().
+		Block(
+			If(Len(Id("id")).Op("==").Lit(0)).Block(
+				Return(True()),
+			),
+			Switch(Id("id").Index(Lit(0))).Block(
+				Case(Id("'{'"), Id("'['"), Id("'t'"), Id("'f'")).Block(
+					Return(False()),
+				),
+			),
+			Return(True()),
+		)
+}
+
+// responseIDJsonRPCFunc генерирует функцию responseIDJsonRPC: id ответа на некорректный запрос или null, если id не определён.
+func (r *transportRenderer) responseIDJsonRPCFunc() Code {
+
+	return Func().Id("responseIDJsonRPC").
+		Params(Id("id").Id("idJsonRPC")).
+		Params(Id("idJsonRPC")).
+		Block(
+			If(Len(Id("id")).Op("==").Lit(0).Op("||").Op("!").Id("validIDJsonRPC").Call(Id("id"))).Block(
+				Return(Id("nullIDJsonRPC")),
+			),
+			Return(Id("id")),
+		)
+}
+
+// decodeRequestsJsonRPCFunc генерирует функцию decodeRequestsJsonRPC: разбор одиночного или batch запроса с ошибками по спецификации JSON-RPC 2.0.
+func (r *transportRenderer) decodeRequestsJsonRPCFunc() Code {
+
+	jsonPkg := r.project.Annotations.Value(TagPackageJSON, PackageStdJSON)
+	return Func().Id("decodeRequestsJsonRPC").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Id("requests").Index().Id("baseJsonRPC"), Id("single").Bool(), Id("errResponse").Op("*").Id("baseJsonRPC")).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Var().Err().Error()
+			bg.Id("body").Op(":=").Qual(PackageBytes, "TrimSpace").Call(Id(VarNameFtx).Dot("Body").Call())
+			bg.If(Len(Id("body")).Op("==").Lit(0)).Block(
+				Return(Nil(), False(), Id("makeErrorResponseJsonRPC").Call(Id("nullIDJsonRPC"), Id("parseError"), Lit("request body could not be decoded: empty body"), Nil())),
+			)
+			bg.If(Id("codec").Op(":=").Id("requestCodec").Call(Id(VarNameFtx).Dot("UserContext").Call()).Op(";").Id("codec").Op("!=").Nil()).Block(
+				If(List(Id("requests"), Id("single"), Err()).Op("=").Id("decodeCodecJsonRPC").Call(Id("codec"), Id("body")).Op(";").Err().Op("!=").Nil()).Block(
+					Return(Nil(), False(), Id("makeErrorResponseJsonRPC").Call(Id("nullIDJsonRPC"), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Nil())),
+				),
+			).Else().If(Op("!").Qual(jsonPkg, "Valid").Call(Id("body"))).Block(
+				Return(Nil(), False(), Id("makeErrorResponseJsonRPC").Call(Id("nullIDJsonRPC"), Id("parseError"), Lit("request body could not be decoded: invalid JSON"), Nil())),
+			).Else().If(Id("body").Index(Lit(0)).Op("!=").Id("'['")).Block(
+				Return(Index().Id("baseJsonRPC").Values(Id("decodeRequestJsonRPC").Call(Id("body"))), True(), Nil()),
+			).Else().Block(
+				Var().Id("items").Index().Qual(jsonPkg, "RawMessage"),
+				If(Err().Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("body"), Op("&").Id("items")).Op(";").Err().Op("!=").Nil()).Block(
+					Return(Nil(), False(), Id("makeErrorResponseJsonRPC").Call(Id("nullIDJsonRPC"), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Nil())),
+				),
+				Id("requests").Op("=").Make(Index().Id("baseJsonRPC"), Lit(0), Len(Id("items"))),
+				For(List(Id("_"), Id("item")).Op(":=").Range().Id("items")).Block(
+					Id("requests").Op("=").Append(Id("requests"), Id("decodeRequestJsonRPC").Call(Id("item"))),
+				),
+			)
+			bg.If(Len(Id("requests")).Op("==").Lit(0)).Block(
+				Return(Nil(), False(), Id("makeErrorResponseJsonRPC").Call(Id("nullIDJsonRPC"), Id("invalidRequestError"), Lit("empty batch request"), Nil())),
+			)
+			bg.Return()
+		})
+}
+
+// decodeRequestJsonRPCFunc генерирует функцию decodeRequestJsonRPC: ошибка разбора сохраняется в запросе и возвращается как invalid request.
+func (r *transportRenderer) decodeRequestJsonRPCFunc() Code {
+
+	jsonPkg := r.project.Annotations.Value(TagPackageJSON, PackageStdJSON)
+	return Func().Id("decodeRequestJsonRPC").
+		Params(Id("data").Index().Byte()).
+		Params(Id("request").Id("baseJsonRPC")).
+		Block(
+			Line().Id("decoder").Op(":=").Qual(jsonPkg, "NewDecoder").Call(Qual(PackageBytes, "NewReader").Call(Id("data"))),
+			Id("decoder").Dot("DisallowUnknownFields").Call(),
+			If(Err().Op(":=").Id("decoder").Dot("Decode").Call(Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+				Id("request").Dot("decodeErr").Op("=").Err(),
+			),
+			Return(),
+		)
+}
+
+// positionalParamsJsonRPCFunc генерирует функцию positionalParamsJsonRPC.
+func (r *transportRenderer) positionalParamsJsonRPCFunc() Code {
+
+	jsonPkg := r.project.Annotations.Value(TagPackageJSON, PackageStdJSON)
+	return Func().Id("positionalParamsJsonRPC").
+		Params(Id("params").Qual(jsonPkg, "RawMessage")).
+		Bool().
+		Block(
+			Id("params").Op("=").Qual(PackageBytes, "TrimSpace").Call(Id("params")),
+			Return(Len(Id("params")).Op("!=").Lit(0).Op("&&").Id("params").Index(Lit(0)).Op("==").Id("'['")),
+		)
+}
+
+// decodePositionalJsonRPCFunc генерирует функцию decodePositionalJsonRPC: разбор параметров, переданных массивом по позициям аргументов.
+func (r *transportRenderer) decodePositionalJsonRPCFunc() Code {
+
+	jsonPkg := r.project.Annotations.Value(TagPackageJSON, PackageStdJSON)
+	return Func().Id("decodePositionalJsonRPC").
+		Params(Id("params").Qual(jsonPkg, "RawMessage"), Id("fields").Op("...").Any()).
+		Params(Err().Error()).
+		Block(
+			Line().Var().Id("values").Index().Qual(jsonPkg, "RawMessage"),
+			If(Err().Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("params"), Op("&").Id("values")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			If(Len(Id("values")).Op(">").Len(Id("fields"))).Block(
+				Return(Qual(PackageFmt, "Errorf").Call(Lit("too many params: %d, expected at most %d"), Len(Id("values")), Len(Id("fields")))),
+			),
+			For(List(Id("i"), Id("value")).Op(":=").Range().Id("values")).Block(
+				If(Err().Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("value"), Id("fields").Index(Id("i"))).Op(";").Err().Op("!=").Nil()).Block(
+					Return(Qual(PackageFmt, "Errorf").Call(Lit("param %d: %w"), Id("i"), Err())),
+				),
+			),
+			Return(Nil()),
+		)
+}
+
 // makeErrorResponseJsonRPCFunc генерирует функцию makeErrorResponseJsonRPC.
 func (r *transportRenderer) makeErrorResponseJsonRPCFunc() Code {
 
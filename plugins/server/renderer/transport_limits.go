@@ -0,0 +1,299 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// RenderTransportLimits генерирует транспортный limits файл.
+func (r *transportRenderer) RenderTransportLimits() error {
+
+	if !r.hasLimits() {
+		return nil
+	}
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageMath, "math")
+	srcFile.ImportName(PackageSlog, "slog")
+	srcFile.ImportName(PackageStrconv, "strconv")
+	srcFile.ImportName(PackageStrings, "strings")
+	srcFile.ImportName(PackageSync, "sync")
+	srcFile.ImportName(PackageTime, "time")
+	srcFile.ImportName(PackageFiber, "fiber")
+
+	srcFile.Line().Const().Defs(
+		Id("rateLimitedError").Op("=").Lit(-32029),
+		Id("rejectReasonRateLimit").Op("=").Lit("rate_limit"),
+		Id("rejectReasonMaxInflight").Op("=").Lit("max_inflight"),
+		Id("maxMemoryRateLimitBuckets").Op("=").Lit(65536),
+	)
+
+	srcFile.Line().Comment("RateLimit описывает ограничение частоты вызовов метода (алгоритм token bucket).")
+	srcFile.Type().Id("RateLimit").Struct(
+		Id("Limit").Int().Comment("количество запросов за период"),
+		Id("Period").Qual(PackageTime, "Duration").Comment("период восполнения лимита"),
+		Id("Burst").Int().Comment("максимальный размер всплеска"),
+	)
+
+	srcFile.Line().Comment("RateLimitStore хранилище состояния ограничителей частоты вызовов (например, Redis).")
+	srcFile.Type().Id("RateLimitStore").Interface(
+		Id("Allow").Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("key").String(), Id("limit").Id("RateLimit")).
+			Params(Id("allowed").Bool(), Id("retryAfter").Qual(PackageTime, "Duration"), Err().Error()),
+	)
+
+	srcFile.Line().Add(r.memoryRateLimitStoreType())
+	srcFile.Line().Add(r.newMemoryRateLimitStoreFunc())
+	srcFile.Line().Add(r.memoryRateLimitStoreAllowFunc())
+	srcFile.Line().Add(r.errorRejectedType())
+	srcFile.Line().Add(r.errorRejectedFuncs())
+	srcFile.Line().Add(r.allowRequestFunc())
+	srcFile.Line().Add(r.rejectRequestFunc())
+	if r.hasJsonRPC() {
+		srcFile.Line().Add(r.setRetryAfterJsonRPCFunc())
+	}
+	if len(r.rateLimitKeySources()) != 0 {
+		srcFile.Line().Type().Id("rateLimitKeysCtx").Struct()
+		srcFile.Line().Add(r.rateLimitKeysHandlerFunc())
+		srcFile.Line().Add(r.rateLimitKeyFunc())
+	}
+
+	return srcFile.Save(path.Join(r.outDir, "limits.go"))
+}
+
+// memoryRateLimitStoreType генерирует тип хранилища ограничителей в памяти.
+func (r *transportRenderer) memoryRateLimitStoreType() Code {
+
+	return Type().Id("tokenBucket").Struct(
+		Id("tokens").Float64(),
+		Id("updated").Qual(PackageTime, "Time"),
+		Id("period").Qual(PackageTime, "Duration"),
+	).Line().Line().Type().Id("memoryRateLimitStore").Struct(
+		Id("mu").Qual(PackageSync, "Mutex"),
+		Id("buckets").Map(String()).Op("*").Id("tokenBucket"),
+	)
+}
+
+// newMemoryRateLimitStoreFunc генерирует конструктор хранилища ограничителей в памяти.
+func (r *transportRenderer) newMemoryRateLimitStoreFunc() Code {
+
+	return Func().Id("NewMemoryRateLimitStore").
+		Params().
+		Params(Id("RateLimitStore")).
+		Block(
+			Return(Op("&").Id("memoryRateLimitStore").Values(Dict{
+				Id("buckets"): Make(Map(String()).Op("*").Id("tokenBucket")),
+			})),
+		)
+}
+
+// memoryRateLimitStoreAllowFunc генерирует метод Allow хранилища ограничителей в памяти.
+func (r *transportRenderer) memoryRateLimitStoreAllowFunc() Code {
+
+	return Func().Params(Id("s").Op("*").Id("memoryRateLimitStore")).
+		Id("Allow").
+		Params(Id("_").Qual(PackageContext, "Context"), Id("key").String(), Id("limit").Id("RateLimit")).
+		Params(Id("allowed").Bool(), Id("retryAfter").Qual(PackageTime, "Duration"), Err().Error()).
+		Block(
+			Line().If(Id("limit").Dot("Limit").Op("<=").Lit(0).Op("||").Id("limit").Dot("Period").Op("<=").Lit(0)).Block(
+				Return(True(), Lit(0), Nil()),
+			),
+			Id("burst").Op(":=").Id("limit").Dot("Burst"),
+			If(Id("burst").Op("<=").Lit(0)).Block(
+				Id("burst").Op("=").Id("limit").Dot("Limit"),
+			),
+			Id("rate").Op(":=").Float64().Call(Id("limit").Dot("Limit")).Op("/").Float64().Call(Id("limit").Dot("Period")),
+			Id("now").Op(":=").Qual(PackageTime, "Now").Call(),
+			Line().Id("s").Dot("mu").Dot("Lock").Call(),
+			Defer().Id("s").Dot("mu").Dot("Unlock").Call(),
+			Line().If(Len(Id("s").Dot("buckets")).Op(">=").Id("maxMemoryRateLimitBuckets")).Block(
+				For(List(Id("bucketKey"), Id("bucket")).Op(":=").Range().Id("s").Dot("buckets")).Block(
+					If(Id("now").Dot("Sub").Call(Id("bucket").Dot("updated")).Op(">").Id("bucket").Dot("period")).Block(
+						Delete(Id("s").Dot("buckets"), Id("bucketKey")),
+					),
+				),
+			),
+			List(Id("bucket"), Id("found")).Op(":=").Id("s").Dot("buckets").Index(Id("key")),
+			If(Op("!").Id("found")).Block(
+				Id("bucket").Op("=").Op("&").Id("tokenBucket").Values(Dict{
+					Id("tokens"):  Float64().Call(Id("burst")),
+					Id("updated"): Id("now"),
+				}),
+				Id("s").Dot("buckets").Index(Id("key")).Op("=").Id("bucket"),
+			),
+			Id("bucket").Dot("period").Op("=").Qual(PackageTime, "Duration").Call(Float64().Call(Id("burst")).Op("/").Id("rate")),
+			Id("bucket").Dot("tokens").Op("=").Qual(PackageMath, "Min").Call(
+				Float64().Call(Id("burst")),
+				Id("bucket").Dot("tokens").Op("+").Float64().Call(Id("now").Dot("Sub").Call(Id("bucket").Dot("updated"))).Op("*").Id("rate"),
+			),
+			Id("bucket").Dot("updated").Op("=").Id("now"),
+			If(Id("bucket").Dot("tokens").Op(">=").Lit(1)).Block(
+				Id("bucket").Dot("tokens").Op("--"),
+				Return(True(), Lit(0), Nil()),
+			),
+			Return(False(), Qual(PackageTime, "Duration").Call(Parens(Lit(1).Op("-").Id("bucket").Dot("tokens")).Op("/").Id("rate")), Nil()),
+		)
+}
+
+// errorRejectedType генерирует тип ошибки отклонённого запроса.
+func (r *transportRenderer) errorRejectedType() Code {
+
+	return Type().Id("errorRejected").Struct(
+		Id("reason").String(),
+		Id("retryAfter").Qual(PackageTime, "Duration"),
+	)
+}
+
+// errorRejectedFuncs генерирует методы ошибки отклонённого запроса.
+func (r *transportRenderer) errorRejectedFuncs() Code {
+
+	return Func().Params(Id("err").Op("*").Id("errorRejected")).
+		Id("Error").
+		Params().
+		String().
+		Block(
+			If(Id("err").Dot("reason").Op("==").Id("rejectReasonMaxInflight")).Block(
+				Return(Lit("too many requests in flight")),
+			),
+			Return(Lit("rate limit exceeded")),
+		).
+		Line().Line().
+		Func().Params(Id("err").Op("*").Id("errorRejected")).
+		Id("Code").
+		Params().
+		Int().
+		Block(
+			Return(Qual(PackageFiber, "StatusTooManyRequests")),
+		).
+		Line().Line().
+		Func().Params(Id("err").Op("*").Id("errorRejected")).
+		Id("retryAfterSeconds").
+		Params().
+		Int().
+		Block(
+			Id("seconds").Op(":=").Int().Call(Qual(PackageMath, "Ceil").Call(Id("err").Dot("retryAfter").Dot("Seconds").Call())),
+			If(Id("seconds").Op("<").Lit(1)).Block(
+				Id("seconds").Op("=").Lit(1),
+			),
+			Return(Id("seconds")),
+		).
+		Line().Line().
+		Func().Params(Id("err").Op("*").Id("errorRejected")).
+		Id("RetryAfter").
+		Params().
+		String().
+		Block(
+			Return(Qual(PackageStrconv, "Itoa").Call(Id("err").Dot("retryAfterSeconds").Call())),
+		).
+		Line().Line().
+		Func().Params(Id("err").Op("*").Id("errorRejected")).
+		Id("data").
+		Params().
+		Map(String()).Int().
+		Block(
+			Return(Map(String()).Int().Values(Dict{
+				Lit("retryAfter"): Id("err").Dot("retryAfterSeconds").Call(),
+			})),
+		)
+}
+
+// allowRequestFunc генерирует проверку ограничителя частоты вызовов.
+func (r *transportRenderer) allowRequestFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("allowRequest").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), List(Id("service"), Id("method"), Id("key")).String(), Id("limit").Id("RateLimit")).
+		Params(Id("retryAfter").Qual(PackageTime, "Duration"), Id("allowed").Bool()).
+		Block(
+			Line().If(Id("srv").Op("==").Nil().Op("||").Id("srv").Dot("rateLimitStore").Op("==").Nil()).Block(
+				Return(Lit(0), True()),
+			),
+			Var().Err().Error(),
+			If(List(Id("allowed"), Id("retryAfter"), Err()).Op("=").Id("srv").Dot("rateLimitStore").Dot("Allow").Call(Id(VarNameCtx), Id("service").Op("+").Lit(".").Op("+").Id("method").Op("+").Lit(":").Op("+").Id("key"), Id("limit")).Op(";").Err().Op("!=").Nil()).Block(
+				Id("srv").Dot("log").Dot("Error").Call(Lit("rate limit store failed"), Qual(PackageSlog, "String").Call(Lit("method"), Id("service").Op("+").Lit(".").Op("+").Id("method")), Qual(PackageSlog, "Any").Call(Lit("error"), Err())),
+				Return(Lit(0), True()),
+			),
+			If(Op("!").Id("allowed")).Block(
+				Id("srv").Dot("rejectRequest").Call(Id("service"), Id("method"), Id("rejectReasonRateLimit")),
+			),
+			Return(),
+		)
+}
+
+// rejectRequestFunc генерирует учёт отклонённого запроса.
+func (r *transportRenderer) rejectRequestFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("rejectRequest").
+		Params(List(Id("service"), Id("method"), Id("reason")).String()).
+		BlockFunc(func(bg *Group) {
+			if !r.hasMetrics() {
+				return
+			}
+			inc := Id("srv").Dot("metrics").Dot("RequestRejected").Dot("WithLabelValues").Call(Id("service"), Id("method"), Id("reason")).Dot("Inc").Call()
+			if r.metricsOTel() {
+				inc = Id("srv").Dot("metrics").Dot("RequestRejected").Dot("Add").Call(Qual(PackageContext, "Background").Call(), Lit(1), Qual(PackageMetricOTEL, "WithAttributes").Call(
+					Qual(PackageAttributeOTEL, "String").Call(Id("metricAttrService"), Id("service")),
+					Qual(PackageAttributeOTEL, "String").Call(Id("metricAttrMethod"), Id("method")),
+					Qual(PackageAttributeOTEL, "String").Call(Lit("reason"), Id("reason")),
+				))
+			}
+			bg.If(Id("srv").Op("!=").Nil().Op("&&").Id("srv").Dot("metrics").Op("!=").Nil()).Block(inc)
+		})
+}
+
+// setRetryAfterJsonRPCFunc генерирует установку заголовка Retry-After для ответов JSON-RPC, отклонённых ограничителями.
+func (r *transportRenderer) setRetryAfterJsonRPCFunc() Code {
+
+	return Func().Id("setRetryAfterJsonRPC").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("responses").Op("...").Op("*").Id("baseJsonRPC")).
+		Block(
+			Line().Var().Id("retryAfter").Int(),
+			For(List(Id("_"), Id("response")).Op(":=").Range().Id("responses")).Block(
+				If(Id("response").Op("==").Nil().Op("||").Id("response").Dot("Error").Op("==").Nil().Op("||").Id("response").Dot("Error").Dot("Code").Op("!=").Id("rateLimitedError")).Block(
+					Continue(),
+				),
+				If(List(Id("data"), Id("ok")).Op(":=").Id("response").Dot("Error").Dot("Data").Assert(Map(String()).Int()).Op(";").Id("ok").Op("&&").Id("data").Index(Lit("retryAfter")).Op(">").Id("retryAfter")).Block(
+					Id("retryAfter").Op("=").Id("data").Index(Lit("retryAfter")),
+				),
+			),
+			If(Id("retryAfter").Op(">").Lit(0)).Block(
+				Id(VarNameFtx).Dot("Set").Call(Qual(PackageFiber, "HeaderRetryAfter"), Qual(PackageStrconv, "Itoa").Call(Id("retryAfter"))),
+			),
+		)
+}
+
+// rateLimitKeysHandlerFunc генерирует middleware, сохраняющий источники ключей ограничителей в контексте.
+func (r *transportRenderer) rateLimitKeysHandlerFunc() Code {
+
+	return Func().Id("rateLimitKeysHandler").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx")).
+		Params(Error()).
+		Block(
+			Line().Id("keys").Op(":=").Map(String()).String().Values(DictFunc(func(dict Dict) {
+				for _, source := range r.rateLimitKeySources() {
+					dict[Lit(source.value)] = Qual(PackageStrings, "Clone").Call(source.fromFiber(Id(VarNameFtx)))
+				}
+			})),
+			Id(VarNameFtx).Dot("SetUserContext").Call(Qual(PackageContext, "WithValue").Call(Id(VarNameFtx).Dot("UserContext").Call(), Id("rateLimitKeysCtx").Values(), Id("keys"))),
+			Return(Id(VarNameFtx).Dot("Next").Call()),
+		)
+}
+
+// rateLimitKeyFunc генерирует получение ключа ограничителя из контекста.
+func (r *transportRenderer) rateLimitKeyFunc() Code {
+
+	return Func().Id("rateLimitKey").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("source").String()).
+		String().
+		Block(
+			List(Id("keys"), Id("_")).Op(":=").Id(VarNameCtx).Dot("Value").Call(Id("rateLimitKeysCtx").Values()).Assert(Map(String()).String()),
+			Return(Id("keys").Index(Id("source"))),
+		)
+}
@@ -25,6 +25,9 @@ func (r *transportRenderer) RenderTransportMetrics() error {
 	if !hasMetrics {
 		return nil
 	}
+	if r.metricsOTel() {
+		return r.renderTransportMetricsOTel(metricsPath)
+	}
 
 	srcFile := NewSrcFile(filepath.Base(r.outDir))
 	srcFile.PackageComment(DoNotEdit)
@@ -48,6 +51,17 @@ func (r *transportRenderer) RenderTransportMetrics() error {
 		Id("RequestCount").Op("*").Qual(PackagePrometheus, "CounterVec"),
 		Id("RequestCountAll").Op("*").Qual(PackagePrometheus, "CounterVec"),
 		Id("RequestLatency").Op("*").Qual(PackagePrometheus, "HistogramVec"),
+		Id("RequestsInFlight").Qual(PackagePrometheus, "Gauge"),
+		Do(func(s *Statement) {
+			if r.hasLimits() {
+				s.Id("RequestRejected").Op("*").Qual(PackagePrometheus, "CounterVec")
+			}
+		}),
+		Do(func(s *Statement) {
+			if r.hasDeprecated() {
+				s.Id("RequestDeprecated").Op("*").Qual(PackagePrometheus, "CounterVec")
+			}
+		}),
 	)
 
 	srcFile.Line().Add(r.newMetricsFunc())
@@ -64,7 +78,7 @@ func (r *transportRenderer) newMetricsFunc() Code {
 		Params(Op("*").Id("Metrics")).
 		BlockFunc(func(bg *Group) {
 			bg.List(Id("hostname"), Id("_")).Op(":=").Qual("os", "Hostname").Call()
-			bg.Id("m").Op(":=").Op("&").Id("Metrics").Values(Dict{
+			metrics := Dict{
 				Id("RequestCount"): Qual("github.com/prometheus/client_golang/prometheus/promauto", "NewCounterVec").Call(
 					Qual(PackagePrometheus, "CounterOpts").Values(Dict{
 						Id("Help"):      Lit("Number of requests received"),
@@ -92,6 +106,14 @@ func (r *transportRenderer) newMetricsFunc() Code {
 					}),
 					Index().String().Values(Lit("service"), Lit("method"), Lit("success"), Lit("errCode")),
 				),
+				Id("RequestsInFlight"): Qual("github.com/prometheus/client_golang/prometheus/promauto", "NewGauge").Call(
+					Qual(PackagePrometheus, "GaugeOpts").Values(Dict{
+						Id("Help"):      Lit("Number of requests currently being served"),
+						Id("Name"):      Lit("in_flight"),
+						Id("Namespace"): Lit("service"),
+						Id("Subsystem"): Lit("requests"),
+					}),
+				),
 				Id("VersionGauge"): Qual("github.com/prometheus/client_golang/prometheus/promauto", "NewGaugeVec").Call(
 					Qual(PackagePrometheus, "GaugeOpts").Values(Dict{
 						Id("Help"):      Lit("Versions of service parts"),
@@ -101,7 +123,30 @@ func (r *transportRenderer) newMetricsFunc() Code {
 					}),
 					Index().String().Values(Lit("part"), Lit("version"), Lit("hostname")),
 				),
-			})
+			}
+			if r.hasLimits() {
+				metrics[Id("RequestRejected")] = Qual("github.com/prometheus/client_golang/prometheus/promauto", "NewCounterVec").Call(
+					Qual(PackagePrometheus, "CounterOpts").Values(Dict{
+						Id("Help"):      Lit("Number of requests rejected by rate and concurrency limits"),
+						Id("Name"):      Lit("rejected_count"),
+						Id("Namespace"): Lit("service"),
+						Id("Subsystem"): Lit("requests"),
+					}),
+					Index().String().Values(Lit("service"), Lit("method"), Lit("reason")),
+				)
+			}
+			if r.hasDeprecated() {
+				metrics[Id("RequestDeprecated")] = Qual("github.com/prometheus/client_golang/prometheus/promauto", "NewCounterVec").Call(
+					Qual(PackagePrometheus, "CounterOpts").Values(Dict{
+						Id("Help"):      Lit("Number of calls to deprecated methods"),
+						Id("Name"):      Lit("deprecated_count"),
+						Id("Namespace"): Lit("service"),
+						Id("Subsystem"): Lit("requests"),
+					}),
+					Index().String().Values(Lit("service"), Lit("method")),
+				)
+			}
+			bg.Id("m").Op(":=").Op("&").Id("Metrics").Values(metrics)
 			bg.Id("m").Dot("VersionGauge").Dot("WithLabelValues").Call(Lit("tg"), Id("VersionTg"), Id("hostname")).Dot("Set").Call(Lit(1))
 			bg.Return(Id("m"))
 		})
@@ -0,0 +1,133 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// renderTransportMetricsOTel генерирует транспортный metrics файл на OpenTelemetry metrics API.
+func (r *transportRenderer) renderTransportMetricsOTel(metricsPath string) error {
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName("os", "os")
+	srcFile.ImportName(PackageOTEL, "otel")
+	srcFile.ImportName(PackageAttributeOTEL, "attribute")
+	srcFile.ImportName(PackageMetricOTEL, "metric")
+
+	srcFile.Line().Comment("Имена метрик и атрибутов по семантическим соглашениям OpenTelemetry для RPC.")
+	srcFile.Const().Defs(
+		Id("metricNameRequests").Op("=").Lit("rpc.server.requests"),
+		Id("metricNameErrors").Op("=").Lit("rpc.server.errors"),
+		Id("metricNameDuration").Op("=").Lit("rpc.server.duration"),
+		Id("metricNameInFlight").Op("=").Lit("http.server.active_requests"),
+		Do(func(s *Statement) {
+			if r.hasLimits() {
+				s.Id("metricNameRejected").Op("=").Lit("rpc.server.rejected")
+			}
+		}),
+		Do(func(s *Statement) {
+			if r.hasDeprecated() {
+				s.Id("metricNameDeprecated").Op("=").Lit("rpc.server.deprecated")
+			}
+		}),
+		Id("metricNameVersion").Op("=").Lit("service.versions"),
+		Line(),
+		Id("metricAttrSystem").Op("=").Lit("rpc.system"),
+		Id("metricAttrService").Op("=").Lit("rpc.service"),
+		Id("metricAttrMethod").Op("=").Lit("rpc.method"),
+		Id("metricAttrErrorType").Op("=").Lit("error.type"),
+		Id("metricAttrJsonRPCErrorCode").Op("=").Lit("rpc.jsonrpc.error_code"),
+		Id("metricAttrHTTPStatusCode").Op("=").Lit("http.response.status_code"),
+		Line(),
+		Id("metricSystemJsonRPC").Op("=").Lit("jsonrpc"),
+		Id("metricSystemHTTP").Op("=").Lit("http"),
+	)
+
+	srcFile.Line().Type().Id("Metrics").Struct(
+		Id("VersionGauge").Qual(PackageMetricOTEL, "Int64Gauge"),
+		Id("RequestCount").Qual(PackageMetricOTEL, "Int64Counter"),
+		Id("RequestErrors").Qual(PackageMetricOTEL, "Int64Counter"),
+		Id("RequestDuration").Qual(PackageMetricOTEL, "Float64Histogram"),
+		Id("RequestsInFlight").Qual(PackageMetricOTEL, "Int64UpDownCounter"),
+		Do(func(s *Statement) {
+			if r.hasLimits() {
+				s.Id("RequestRejected").Qual(PackageMetricOTEL, "Int64Counter")
+			}
+		}),
+		Do(func(s *Statement) {
+			if r.hasDeprecated() {
+				s.Id("RequestDeprecated").Qual(PackageMetricOTEL, "Int64Counter")
+			}
+		}),
+	)
+
+	srcFile.Line().Comment("NewMetrics создаёт метрики методов через глобальный MeterProvider.")
+	srcFile.Func().Id("NewMetrics").
+		Params().
+		Params(Op("*").Id("Metrics")).
+		Block(
+			Return(Id("NewMetricsWithProvider").Call(Nil())),
+		)
+
+	srcFile.Line().Add(r.newMetricsWithProviderFunc())
+
+	return srcFile.Save(metricsPath)
+}
+
+// newMetricsWithProviderFunc генерирует функцию NewMetricsWithProvider.
+func (r *transportRenderer) newMetricsWithProviderFunc() Code {
+
+	instrument := func(field, kind, name, unit, description string) Code {
+		return If(List(Id("m").Dot(field), Err()).Op("=").Id("meter").Dot(kind).Call(
+			Id(name),
+			Qual(PackageMetricOTEL, "WithUnit").Call(Lit(unit)),
+			Qual(PackageMetricOTEL, "WithDescription").Call(Lit(description)),
+		).Op(";").Err().Op("!=").Nil()).Block(
+			Qual(PackageOTEL, "Handle").Call(Err()),
+		)
+	}
+
+	return Comment("NewMetricsWithProvider создаёт метрики методов через указанный MeterProvider.").Line().
+		Func().Id("NewMetricsWithProvider").
+		Params(Id("provider").Qual(PackageMetricOTEL, "MeterProvider")).
+		Params(Id("m").Op("*").Id("Metrics")).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.If(Id("provider").Op("==").Nil()).Block(
+				Id("provider").Op("=").Qual(PackageOTEL, "GetMeterProvider").Call(),
+			)
+			bg.Id("meter").Op(":=").Id("provider").Dot("Meter").Call(Lit(r.pkgPath(r.outDir)), Qual(PackageMetricOTEL, "WithInstrumentationVersion").Call(Id("VersionTg")))
+			bg.Line()
+			bg.Var().Err().Error()
+			bg.Id("m").Op("=").Op("&").Id("Metrics").Values()
+			bg.Add(instrument("RequestCount", "Int64Counter", "metricNameRequests", "{request}", "Number of requests received"))
+			bg.Add(instrument("RequestErrors", "Int64Counter", "metricNameErrors", "{request}", "Number of requests completed with error"))
+			bg.Add(instrument("RequestDuration", "Float64Histogram", "metricNameDuration", "ms", "Duration of requests"))
+			bg.Add(instrument("RequestsInFlight", "Int64UpDownCounter", "metricNameInFlight", "{request}", "Number of requests currently being served"))
+			if r.hasLimits() {
+				bg.Add(instrument("RequestRejected", "Int64Counter", "metricNameRejected", "{request}", "Number of requests rejected by rate and concurrency limits"))
+			}
+			if r.hasDeprecated() {
+				bg.Add(instrument("RequestDeprecated", "Int64Counter", "metricNameDeprecated", "{request}", "Number of calls to deprecated methods"))
+			}
+			bg.Add(instrument("VersionGauge", "Int64Gauge", "metricNameVersion", "1", "Versions of service parts"))
+			bg.Line()
+			bg.List(Id("hostname"), Id("_")).Op(":=").Qual("os", "Hostname").Call()
+			bg.Id("m").Dot("VersionGauge").Dot("Record").Call(
+				Qual(PackageContext, "Background").Call(),
+				Lit(1),
+				Qual(PackageMetricOTEL, "WithAttributes").Call(
+					Qual(PackageAttributeOTEL, "String").Call(Lit("part"), Lit("tg")),
+					Qual(PackageAttributeOTEL, "String").Call(Lit("version"), Id("VersionTg")),
+					Qual(PackageAttributeOTEL, "String").Call(Lit("host.name"), Id("hostname")),
+				),
+			)
+			bg.Return()
+		})
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// RenderTransportMiddleware генерирует универсальные middleware, применимые к методам всех контрактов.
+func (r *transportRenderer) RenderTransportMiddleware() error {
+
+	middlewarePath := path.Join(r.outDir, "middleware.go")
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	srcFile.ImportName(PackageContext, "context")
+
+	srcFile.Line().Comment("CallHandler - вызов метода контракта в обобщённом виде: имя метода (Contract.Method), аргументы и результаты без context.Context и error.")
+	srcFile.Type().Id("CallHandler").Func().
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("method").String(), Id("args").Index().Any()).
+		Params(Id("results").Index().Any(), Err().Error())
+
+	srcFile.Line().Comment("CallMiddleware - middleware, применимое к любому методу любого контракта.")
+	srcFile.Comment("Порядок вызова (снаружи внутрь): WithLog/WithTrace/WithMetrics сервера (в порядке вызова),")
+	srcFile.Comment("WithMiddleware, WithMethodMiddleware, Chain<Contract> применённые к реализации, реализация контракта.")
+	srcFile.Type().Id("CallMiddleware").Func().Params(Id(VarNameNext).Id("CallHandler")).Params(Id("CallHandler"))
+
+	srcFile.Line().Comment("ChainCall объединяет middleware в одно: первое в списке вызывается первым.")
+	srcFile.Func().Id("ChainCall").Params(Id("mws").Op("...").Id("CallMiddleware")).Params(Id("CallMiddleware")).Block(
+		Return(Func().Params(Id(VarNameNext).Id("CallHandler")).Params(Id("CallHandler")).Block(
+			For(Id("i").Op(":=").Len(Id("mws")).Op("-").Lit(1), Id("i").Op(">=").Lit(0), Id("i").Op("--")).Block(
+				Id(VarNameNext).Op("=").Id("mws").Index(Id("i")).Call(Id(VarNameNext)),
+			),
+			Return(Id(VarNameNext)),
+		)),
+	)
+
+	srcFile.Line().Comment("callMiddleware возвращает middleware, зарегистрированные для метода, или nil.")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("callMiddleware").Params(Id("method").String()).Params(Id("CallMiddleware")).Block(
+		Line().Id("mws").Op(":=").Append(Append(Index().Id("CallMiddleware").Values(), Id("srv").Dot("callMiddlewares").Op("...")), Id("srv").Dot("methodMiddlewares").Index(Id("method")).Op("...")),
+		If(Len(Id("mws")).Op("==").Lit(0)).Block(
+			Return(Nil()),
+		),
+		Return(Id("ChainCall").Call(Id("mws").Op("..."))),
+	)
+
+	srcFile.Line().Comment("callValue возвращает i-е значение обобщённого вызова или нулевое значение, если тип не совпадает.")
+	srcFile.Func().Id("callValue").Types(Id("T").Any()).Params(Id("values").Index().Any(), Id("i").Int()).Params(Id("value").Id("T")).Block(
+		Line().If(Id("i").Op("<").Len(Id("values"))).Block(
+			If(List(Id("typed"), Id("ok")).Op(":=").Id("values").Index(Id("i")).Assert(Id("T")).Op(";").Id("ok")).Block(
+				Id("value").Op("=").Id("typed"),
+			),
+		),
+		Return(),
+	)
+
+	return srcFile.Save(middlewarePath)
+}
+
+// renderOptionsMiddleware генерирует опции регистрации универсальных middleware.
+func (r *transportRenderer) renderOptionsMiddleware(srcFile *GoFile) {
+
+	srcFile.Line().Comment("WithMiddleware добавляет middleware ко всем методам всех контрактов (внутри WithLog/WithTrace/WithMetrics).")
+	srcFile.Func().Id("WithMiddleware").
+		Params(Id("mws").Op("...").Id("CallMiddleware")).
+		Id("Option").
+		Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("callMiddlewares").Op("=").Append(Id("srv").Dot("callMiddlewares"), Id("mws").Op("...")),
+			)),
+		)
+
+	srcFile.Line().Comment("WithMethodMiddleware добавляет middleware к методу по имени Contract.Method (внутри middleware из WithMiddleware).")
+	srcFile.Func().Id("WithMethodMiddleware").
+		Params(Id("method").String(), Id("mws").Op("...").Id("CallMiddleware")).
+		Id("Option").
+		Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				If(Id("srv").Dot("methodMiddlewares").Op("==").Nil()).Block(
+					Id("srv").Dot("methodMiddlewares").Op("=").Make(Map(String()).Index().Id("CallMiddleware")),
+				),
+				Id("srv").Dot("methodMiddlewares").Index(Id("method")).Op("=").Append(Id("srv").Dot("methodMiddlewares").Index(Id("method")), Id("mws").Op("...")),
+			)),
+		)
+}
@@ -29,6 +29,13 @@ func (r *transportRenderer) RenderTransportOptions() error {
 	r.renderOptionsConfig(&srcFile)
 	r.renderOptionsTimeouts(&srcFile)
 	r.renderOptionsHeaders(&srcFile)
+	r.renderOptionsLimits(&srcFile)
+	r.renderOptionsIdempotency(&srcFile)
+	r.renderOptionsCache(&srcFile)
+	r.renderOptionsMetrics(&srcFile)
+	r.renderOptionsHealth(&srcFile)
+	r.renderOptionsCodec(&srcFile)
+	r.renderOptionsMiddleware(&srcFile)
 	r.renderOptionsUse(&srcFile)
 
 	return srcFile.Save(optionsPath)
@@ -85,6 +92,10 @@ func (r *transportRenderer) renderOptionsForContracts(srcFile *GoFile) {
 							gr.Id("srv").Dot("httpHTTPService").Op("=").Id("httpSvc")
 							gr.Id("httpSvc").Dot("maxBatchSize").Op("=").Id("srv").Dot("maxBatchSize")
 							gr.Id("httpSvc").Dot("maxParallelBatch").Op("=").Id("srv").Dot("maxParallelBatch")
+							if contractNeedsServer(httpContract) {
+								gr.Id("httpSvc").Dot("srv").Op("=").Id("srv")
+							}
+							gr.Id("httpSvc").Dot("svc").Dot("withCallMiddleware").Call(Id("srv").Dot("callMiddleware"))
 							gr.Id("httpSvc").Dot("SetRoutes").Call(Id("srv").Dot("Fiber").Call())
 						}),
 					)),
@@ -104,6 +115,7 @@ func (r *transportRenderer) renderOptionsForContracts(srcFile *GoFile) {
 							gr.Id("httpSvc").Op(":=").Id("new" + contract.Name).Call(Id("svc"))
 							gr.Id("srv").Dot("http" + contract.Name).Op("=").Id("httpSvc")
 							gr.Id("httpSvc").Dot("srv").Op("=").Id("srv")
+							gr.Id("httpSvc").Dot("svc").Dot("withCallMiddleware").Call(Id("srv").Dot("callMiddleware"))
 							gr.Id("httpSvc").Dot("SetRoutes").Call(Id("srv").Dot("Fiber").Call())
 						}),
 					)),
@@ -205,6 +217,7 @@ func (r *transportRenderer) renderOptionsHeaders(srcFile *GoFile) {
 		Id("Option").
 		Block(
 			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("requestIDHeader").Op("=").Id("headerName"),
 				Id("srv").Dot("headerHandlers").Op("[").Id("headerName").Op("]").Op("=").
 					Func().Params(Id("value").String()).Params(Id("Header")).Block(
 					If(Id("value").Op("==").Lit("")).Block(
@@ -231,6 +244,113 @@ func (r *transportRenderer) renderOptionsHeaders(srcFile *GoFile) {
 		)
 }
 
+// renderOptionsLimits генерирует функции для ограничителей вызовов методов.
+func (r *transportRenderer) renderOptionsLimits(srcFile *GoFile) {
+
+	if !r.hasLimits() {
+		return
+	}
+	srcFile.Line().Func().Id("WithRateLimitStore").
+		Params(Id("store").Id("RateLimitStore")).
+		Id("Option").
+		Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("rateLimitStore").Op("=").Id("store"),
+			)),
+		)
+}
+
+// renderOptionsIdempotency генерирует функции для идемпотентных методов.
+func (r *transportRenderer) renderOptionsIdempotency(srcFile *GoFile) {
+
+	if !r.hasIdempotent() {
+		return
+	}
+	srcFile.Line().Func().Id("WithIdempotencyStore").
+		Params(Id("store").Id("IdempotencyStore"), Id("ttl").Qual(PackageTime, "Duration")).
+		Id("Option").
+		Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).BlockFunc(func(bg *Group) {
+				bg.Id("srv").Dot("idempotencyStore").Op("=").Id("store")
+				bg.If(Id("ttl").Op(">").Lit(0)).Block(
+					Id("srv").Dot("idempotencyTTL").Op("=").Id("ttl"),
+				)
+			})),
+		)
+}
+
+// renderOptionsCache генерирует функции для кэширования ответов REST методов.
+func (r *transportRenderer) renderOptionsCache(srcFile *GoFile) {
+
+	if !r.hasCache() {
+		return
+	}
+	srcFile.Line().Func().Id("WithResponseCache").
+		Params(Id("cache").Id("ResponseCache")).
+		Id("Option").
+		Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("responseCache").Op("=").Id("cache"),
+			)),
+		)
+}
+
+// renderOptionsMetrics генерирует функции для метрик OpenTelemetry.
+func (r *transportRenderer) renderOptionsMetrics(srcFile *GoFile) {
+
+	if !r.hasMetrics() || !r.metricsOTel() {
+		return
+	}
+	srcFile.Line().Func().Id("WithMeterProvider").
+		Params(Id("provider").Qual(PackageMetricOTEL, "MeterProvider")).
+		Id("Option").
+		Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("meterProvider").Op("=").Id("provider"),
+			)),
+		)
+}
+
+// renderOptionsHealth генерирует функции для проб готовности и вывода из балансировки.
+func (r *transportRenderer) renderOptionsHealth(srcFile *GoFile) {
+
+	srcFile.Line().Func().Id("WithHealthChecker").
+		Params(Id("name").String(), Id("checker").Id("HealthChecker")).
+		Id("Option").
+		Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("healthChecks").Op("=").Append(Id("srv").Dot("healthChecks"), Id("healthCheck").Values(Dict{
+					Id("name"):    Id("name"),
+					Id("checker"): Id("checker"),
+				})),
+			)),
+		)
+	srcFile.Line().Func().Id("WithDrainDelay").
+		Params(Id("delay").Qual(PackageTime, "Duration")).
+		Id("Option").
+		Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("drainDelay").Op("=").Id("delay"),
+			)),
+		)
+}
+
+// renderOptionsCodec генерирует функцию регистрации кодека.
+func (r *transportRenderer) renderOptionsCodec(srcFile *GoFile) {
+
+	srcFile.Line().Func().Id("WithCodec").
+		Params(Id("codec").Id("Codec")).
+		Id("Option").
+		Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				If(Id("srv").Dot("codecs").Op("==").Nil()).Block(
+					Id("srv").Dot("codecs").Op("=").Make(Map(String()).Id("Codec")),
+				),
+				Id("srv").Dot("codecs").Index(Id("mediaType").Call(Id("codec").Dot("ContentType").Call())).Op("=").Id("codec"),
+			)),
+		)
+}
+
 // renderOptionsUse генерирует функцию Use.
 func (r *transportRenderer) renderOptionsUse(srcFile *GoFile) {
 
@@ -0,0 +1,138 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// RenderTransportProblem генерирует транспортный файл ошибок в формате application/problem+json (RFC 9457).
+func (r *transportRenderer) RenderTransportProblem() error {
+
+	if !r.hasProblem() {
+		return nil
+	}
+
+	srcFile := NewSrcFile(filepath.Base(r.outDir))
+	srcFile.PackageComment(DoNotEdit)
+
+	jsonPkg := r.project.Annotations.Value(TagPackageJSON, PackageStdJSON)
+	srcFile.ImportName(jsonPkg, "json")
+	srcFile.ImportName(PackageNetHTTP, "http")
+	srcFile.ImportName(PackageFiber, "fiber")
+
+	srcFile.Line().Const().Id("contentTypeProblem").Op("=").Lit("application/problem+json")
+
+	srcFile.Line().Comment("Problem описание ошибки REST метода в формате RFC 9457.")
+	srcFile.Type().Id("Problem").Struct(
+		Id("Type").String(),
+		Id("Title").String(),
+		Id("Status").Int(),
+		Id("Detail").String(),
+		Id("Instance").String(),
+		Id("RequestID").String(),
+		Id("Extensions").Map(String()).Any(),
+	)
+
+	srcFile.Line().Comment("withProblemType позволяет ошибке задать поле type.")
+	srcFile.Type().Id("withProblemType").Interface(
+		Id("ProblemType").Params().String(),
+	)
+	srcFile.Line().Comment("withProblemTitle позволяет ошибке задать поле title.")
+	srcFile.Type().Id("withProblemTitle").Interface(
+		Id("ProblemTitle").Params().String(),
+	)
+	srcFile.Line().Comment("withProblemExtensions позволяет ошибке добавить поля расширения.")
+	srcFile.Type().Id("withProblemExtensions").Interface(
+		Id("ProblemExtensions").Params().Map(String()).Any(),
+	)
+
+	srcFile.Line().Add(r.problemMarshalJSONFunc(jsonPkg))
+	srcFile.Line().Add(r.newProblemFunc())
+	srcFile.Line().Add(r.sendProblemFunc(jsonPkg))
+
+	return srcFile.Save(path.Join(r.outDir, "problem.go"))
+}
+
+// problemMarshalJSONFunc генерирует сериализацию Problem с полями расширения на верхнем уровне.
+func (r *transportRenderer) problemMarshalJSONFunc(jsonPkg string) Code {
+
+	optional := func(field, key string) Code {
+		return If(Id("p").Dot(field).Op("!=").Lit("")).Block(
+			Id("fields").Index(Lit(key)).Op("=").Id("p").Dot(field),
+		)
+	}
+	return Comment("MarshalJSON сериализует описание ошибки вместе с полями расширения.").Line().
+		Func().Params(Id("p").Id("Problem")).
+		Id("MarshalJSON").
+		Params().
+		Params(Index().Byte(), Error()).
+		Block(
+			Line(),
+			Id("fields").Op(":=").Make(Map(String()).Any(), Len(Id("p").Dot("Extensions")).Op("+").Lit(6)),
+			For(List(Id("key"), Id("value")).Op(":=").Range().Id("p").Dot("Extensions")).Block(
+				Id("fields").Index(Id("key")).Op("=").Id("value"),
+			),
+			Id("fields").Index(Lit("type")).Op("=").Id("p").Dot("Type"),
+			Id("fields").Index(Lit("title")).Op("=").Id("p").Dot("Title"),
+			Id("fields").Index(Lit("status")).Op("=").Id("p").Dot("Status"),
+			optional("Detail", "detail"),
+			optional("Instance", "instance"),
+			optional("RequestID", "requestId"),
+			Return(Qual(jsonPkg, "Marshal").Call(Id("fields"))),
+		)
+}
+
+// newProblemFunc генерирует функцию newProblem.
+func (r *transportRenderer) newProblemFunc() Code {
+
+	return Func().Id("newProblem").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("status").Int(), Err().Error()).
+		Params(Id("problem").Id("Problem")).
+		Block(
+			Line(),
+			Id("problem").Op("=").Id("Problem").Values(Dict{
+				Id("Type"):      Lit("about:blank"),
+				Id("Title"):     Qual(PackageNetHTTP, "StatusText").Call(Id("status")),
+				Id("Status"):    Id("status"),
+				Id("Instance"):  Id(VarNameFtx).Dot("Path").Call(),
+				Id("RequestID"): Id("RequestIDFromContext").Call(Id(VarNameFtx).Dot("UserContext").Call()),
+			}),
+			If(Err().Op("==").Nil()).Block(
+				Return(),
+			),
+			Id("problem").Dot("Detail").Op("=").Err().Dot("Error").Call(),
+			If(List(Id("typed"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withProblemType")).Op(";").Id("ok")).Block(
+				Id("problem").Dot("Type").Op("=").Id("typed").Dot("ProblemType").Call(),
+			),
+			If(List(Id("titled"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withProblemTitle")).Op(";").Id("ok")).Block(
+				Id("problem").Dot("Title").Op("=").Id("titled").Dot("ProblemTitle").Call(),
+			),
+			If(List(Id("extended"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withProblemExtensions")).Op(";").Id("ok")).Block(
+				Id("problem").Dot("Extensions").Op("=").Id("extended").Dot("ProblemExtensions").Call(),
+			),
+			Return(),
+		)
+}
+
+// sendProblemFunc генерирует функцию sendProblem.
+func (r *transportRenderer) sendProblemFunc(jsonPkg string) Code {
+
+	return Func().Id("sendProblem").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("status").Int(), Err().Error()).
+		Params(Error()).
+		Block(
+			Line(),
+			List(Id("body"), Id("errMarshal")).Op(":=").Qual(jsonPkg, "Marshal").Call(Id("newProblem").Call(Id(VarNameFtx), Id("status"), Err())),
+			If(Id("errMarshal").Op("!=").Nil()).Block(
+				Return(Id("sendHTTPError").Call(Id(VarNameFtx), Qual(PackageFiber, "StatusInternalServerError"), Lit("problem could not be encoded: ").Op("+").Id("errMarshal").Dot("Error").Call())),
+			),
+			Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("SetContentType").Call(Id("contentTypeProblem")),
+			Id(VarNameFtx).Dot("Status").Call(Id("status")),
+			List(Id("_"), Err()).Op("=").Id(VarNameFtx).Dot("Write").Call(Id("body")),
+			Return(Err()),
+		)
+}
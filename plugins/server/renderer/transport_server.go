@@ -44,6 +44,7 @@ func (r *transportRenderer) renderServerImports(srcFile *GoFile) {
 	srcFile.ImportName(jsonPkg, "json")
 	srcFile.ImportName(PackageSlog, "slog")
 	srcFile.ImportName(PackageTime, "time")
+	srcFile.ImportName(PackageAtomic, "atomic")
 
 	// Локальные пакеты
 	srcFile.ImportName(fmt.Sprintf("%s/logger", r.pkgPath(r.outDir)), "logger")
@@ -54,6 +55,7 @@ func (r *transportRenderer) renderServerImports(srcFile *GoFile) {
 
 	// Внешние пакеты
 	srcFile.ImportName(PackageFiber, "fiber")
+	srcFile.ImportName(PackageFiberAdaptor, "adaptor")
 	srcFile.ImportName(PackagePrometheus, "prometheus")
 	srcFile.ImportName(PackagePrometheusAuto, "promauto")
 	srcFile.ImportName(PackagePrometheusHttp, "promhttp")
@@ -92,6 +94,7 @@ func (r *transportRenderer) renderServerFunctions(srcFile *GoFile) {
 	srcFile.Line().Add(r.serverNewFunc())
 	srcFile.Line().Add(r.requiresHTTPFunc())
 	srcFile.Line().Add(r.fiberFunc())
+	srcFile.Line().Add(r.handlerFunc())
 	srcFile.Line().Add(r.withLogFunc())
 	srcFile.Line().Add(r.serveHealthFunc())
 	srcFile.Line().Add(r.sendResponseFunc())
@@ -118,12 +121,25 @@ func (r *transportRenderer) transportServerType() Code {
 		bg.Id("srvMetrics").Op("*").Qual(PackageFiber, "App")
 		if r.hasMetrics() {
 			bg.Line().Id("metrics").Op("*").Id("Metrics")
+			if r.metricsOTel() {
+				bg.Id("meterProvider").Qual(PackageMetricOTEL, "MeterProvider")
+			}
 		}
 		if r.hasJsonRPC() {
 			bg.Line().Id("maxBatchSize").Int()
 			bg.Id("maxParallelBatch").Int()
 			bg.Id("methodTimeout").Qual(PackageTime, "Duration").Line()
 		}
+		if r.hasLimits() {
+			bg.Line().Id("rateLimitStore").Id("RateLimitStore")
+		}
+		if r.hasIdempotent() {
+			bg.Line().Id("idempotencyStore").Id("IdempotencyStore")
+			bg.Id("idempotencyTTL").Qual(PackageTime, "Duration")
+		}
+		if r.hasCache() {
+			bg.Line().Id("responseCache").Id("ResponseCache")
+		}
 		if r.hasHTTPService() {
 			bg.Line().Id("httpHTTPService").Op("*").Id("httpHTTPService")
 		}
@@ -136,6 +152,13 @@ func (r *transportRenderer) transportServerType() Code {
 		}
 		bg.Line()
 		bg.Id("headerHandlers").Map(String()).Id("HeaderHandler")
+		bg.Id("requestIDHeader").String()
+		bg.Id("codecs").Map(String()).Id("Codec")
+		bg.Line().Id("callMiddlewares").Index().Id("CallMiddleware")
+		bg.Id("methodMiddlewares").Map(String()).Index().Id("CallMiddleware")
+		bg.Line().Id("healthChecks").Index().Id("healthCheck")
+		bg.Id("draining").Qual(PackageAtomic, "Bool")
+		bg.Id("drainDelay").Qual(PackageTime, "Duration")
 	})
 }
 
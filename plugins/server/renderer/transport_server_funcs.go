@@ -20,6 +20,19 @@ func (r *transportRenderer) fiberFunc() Code {
 		)
 }
 
+// handlerFunc генерирует функцию Handler.
+func (r *transportRenderer) handlerFunc() Code {
+
+	return Comment("Handler возвращает обработчик транспорта для вызовов в том же процессе (цепочка middleware, валидация и логирование сохраняются).").Line().
+		Func().Params(Id("srv").Op("*").Id("Server")).
+		Id("Handler").
+		Params().
+		Params(Qual(PackageNetHTTP, "Handler")).
+		Block(
+			Return(Qual(PackageFiberAdaptor, "FiberApp").Call(Id("srv").Dot("srvHTTP"))),
+		)
+}
+
 // withLogFunc генерирует функцию WithLog.
 func (r *transportRenderer) withLogFunc() Code {
 
@@ -80,8 +93,12 @@ func (r *transportRenderer) withMetricsFunc() Code {
 		Params().
 		Params(Op("*").Id("Server")).
 		BlockFunc(func(bg *Group) {
+			newMetrics := Id("NewMetrics").Call()
+			if r.metricsOTel() {
+				newMetrics = Id("NewMetricsWithProvider").Call(Id("srv").Dot("meterProvider"))
+			}
 			bg.If(Id("srv").Dot("metrics").Op("==").Nil()).Block(
-				Id("srv").Dot("metrics").Op("=").Id("NewMetrics").Call(),
+				Id("srv").Dot("metrics").Op("=").Add(newMetrics),
 			)
 			if r.hasHTTPService() {
 				bg.If(Id("srv").Dot("httpHTTPService").Op("!=").Nil()).Block(
@@ -115,6 +132,13 @@ func (r *transportRenderer) serverNewFunc() Code {
 					dict[Id("maxParallelBatch")] = Id("defaultMaxParallelBatch")
 					dict[Id("methodTimeout")] = Lit(30).Op("*").Qual(PackageTime, "Second")
 				}
+				if r.hasLimits() {
+					dict[Id("rateLimitStore")] = Id("NewMemoryRateLimitStore").Call()
+				}
+				if r.hasIdempotent() {
+					dict[Id("idempotencyStore")] = Id("NewMemoryIdempotencyStore").Call()
+					dict[Id("idempotencyTTL")] = Id("defaultIdempotencyTTL")
+				}
 				dict[Id("headerHandlers")] = Make(Map(String()).Id("HeaderHandler"))
 				dict[Id("config")] = Qual(PackageFiber, "Config").Values(Dict{
 					Id("DisableStartupMessage"): True(),
@@ -146,11 +170,27 @@ func (r *transportRenderer) serverNewFunc() Code {
 			bg.Line()
 			bg.Id("srv").Dot("srvHTTP").Op("=").Qual(PackageFiber, "New").Call(Id("srv").Dot("config"))
 			bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("recoverHandler"))
+			bg.Id("srv").Dot("srvHTTP").Dot("Get").Call(Id("healthzPath"), Id("srv").Dot("serveHealthz"))
+			bg.Id("srv").Dot("srvHTTP").Dot("Get").Call(Id("readyzPath"), Id("srv").Dot("serveReadyz"))
+			if r.hasMetrics() {
+				bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("srv").Dot("inFlightHandler"))
+			}
 			if r.hasTrace() {
-				bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Qual(fmt.Sprintf("%s/tracer", r.pkgPath(r.outDir)), "Middleware").Call())
+				bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Qual(fmt.Sprintf("%s/tracer", r.pkgPath(r.outDir)), "Middleware").CallFunc(func(cg *Group) {
+					if r.hasMetrics() && r.metricsOTel() {
+						cg.Qual(fmt.Sprintf("%s/tracer", r.pkgPath(r.outDir)), "WithMeterProvider").Call(Id("srv").Dot("meterProvider"))
+					}
+				}))
 			}
 			bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("srv").Dot("setLogger"))
 			bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("srv").Dot("headersHandler"))
+			bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("srv").Dot("codecHandler"))
+			if len(r.rateLimitKeySources()) != 0 {
+				bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("rateLimitKeysHandler"))
+			}
+			if r.hasIdempotent() && r.hasJsonRPC() {
+				bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("idempotencyKeyHandler"))
+			}
 			if r.hasJsonRPC() {
 				bg.Id("srv").Dot("srvHTTP").Dot("Post").Call(Lit("/"), Id("srv").Dot("serveBatch"))
 			}
@@ -220,6 +260,10 @@ func (r *transportRenderer) shutdownFunc() Code {
 		Params().
 		Params(Id("err").Error()).
 		BlockFunc(func(bg *Group) {
+			bg.Id("srv").Dot("Drain").Call()
+			bg.If(Id("srv").Dot("drainDelay").Op(">").Lit(0)).Block(
+				Qual(PackageTime, "Sleep").Call(Id("srv").Dot("drainDelay")),
+			)
 			bg.If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).Block(
 				If(Err().Op(":=").Id("srv").Dot("srvHTTP").Dot("ShutdownWithTimeout").Call(Id("defaultShutdownTimeout")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(Err()),
@@ -249,6 +293,14 @@ func (r *transportRenderer) sendResponseFunc() Code {
 				Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusNoContent")),
 				Return(Nil()),
 			)
+			// Notification не получает ответа
+			bg.If(List(Id("response"), Id("ok")).Op(":=").Id("resp").Op(".").Call(Op("*").Id("baseJsonRPC")).Op(";").Id("ok").Op("&&").Id("response").Op("==").Nil()).Block(
+				Id(VarNameFtx).Dot("Status").Call(Qual(PackageFiber, "StatusNoContent")),
+				Return(Nil()),
+			)
+			bg.If(Id("codec").Op(":=").Id("responseCodec").Call(Id(VarNameFtx).Dot("UserContext").Call()).Op(";").Id("codec").Op("!=").Nil()).Block(
+				Return(Id("sendCodecResponse").Call(Id(VarNameFtx), Id("codec"), Id("resp"))),
+			)
 			bg.Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("SetContentType").Call(Id("contentTypeJson"))
 			// Используем sync.Pool для буферов
 			bg.Id("buf").Op(":=").Id("bufferPool").Dot("Get").Call().Op(".").Call(Op("*").Qual(PackageBytes, "Buffer"))
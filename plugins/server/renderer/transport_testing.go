@@ -0,0 +1,272 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"path"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// testingPkgName - имя пакета тестовых помощников транспорта.
+const testingPkgName = "testing"
+
+// RenderTransportTesting генерирует пакет testing с in-memory сервером транспорта и помощниками проверок.
+func (r *transportRenderer) RenderTransportTesting() error {
+
+	testingDir := path.Join(r.outDir, testingPkgName)
+
+	srcFile := NewSrcFile(testingPkgName)
+	srcFile.PackageComment(DoNotEdit)
+
+	jsonPkg := r.project.Annotations.Value(TagPackageJSON, PackageStdJSON)
+	srcFile.ImportName(r.pkgPath(r.outDir), "transport")
+	srcFile.ImportAlias(PackageTesting, "gotesting")
+	srcFile.ImportName(PackageFasthttputil, "fasthttputil")
+	srcFile.ImportName(PackageNetHTTP, "http")
+	srcFile.ImportName(PackageContext, "context")
+	srcFile.ImportName(PackageSlog, "slog")
+	srcFile.ImportName(PackageIO, "io")
+	srcFile.ImportName(PackageNet, "net")
+	srcFile.ImportName(PackageBytes, "bytes")
+	srcFile.ImportName(PackageFmt, "fmt")
+	srcFile.ImportName(PackageErrors, "errors")
+	srcFile.ImportName(jsonPkg, "json")
+
+	transportPkg := r.pkgPath(r.outDir)
+
+	srcFile.Line().Const().Id("baseURL").Op("=").Lit("http://transport.test")
+
+	srcFile.Line().Comment("Server - транспорт, запущенный на in-memory listener без сетевых портов.")
+	srcFile.Type().Id("Server").Struct(
+		Id("Transport").Op("*").Qual(transportPkg, "Server"),
+		Line().Id("listener").Op("*").Qual(PackageFasthttputil, "InmemoryListener"),
+		Id("client").Op("*").Qual(PackageNetHTTP, "Client"),
+	)
+
+	srcFile.Line().Comment("New запускает транспорт с переданными опциями (реализациями контрактов) и останавливает его по завершении теста.")
+	srcFile.Func().Id("New").
+		Params(Id("t").Qual(PackageTesting, "TB"), Id("options").Op("...").Qual(transportPkg, "Option")).
+		Params(Id("srv").Op("*").Id("Server")).
+		Block(
+			Line().Id("t").Dot("Helper").Call(),
+			Id("srv").Op("=").Op("&").Id("Server").Values(Dict{
+				Id("listener"): Qual(PackageFasthttputil, "NewInmemoryListener").Call(),
+			}),
+			Id("srv").Dot("Transport").Op("=").Qual(transportPkg, "New").Call(Qual(PackageSlog, "New").Call(Qual(PackageSlog, "NewTextHandler").Call(Qual(PackageIO, "Discard"), Nil())), Id("options").Op("...")),
+			Id("srv").Dot("client").Op("=").Op("&").Qual(PackageNetHTTP, "Client").Values(Dict{
+				Id("Transport"): Op("&").Qual(PackageNetHTTP, "Transport").Values(Dict{
+					Id("DialContext"): Func().Params(Id("_").Qual(PackageContext, "Context"), List(Id("_"), Id("_")).String()).Params(Qual(PackageNet, "Conn"), Error()).Block(
+						Return(Id("srv").Dot("listener").Dot("Dial").Call()),
+					),
+				}),
+			}),
+			Go().Func().Params().Block(
+				Id("_").Op("=").Id("srv").Dot("Transport").Dot("Fiber").Call().Dot("Listener").Call(Id("srv").Dot("listener")),
+			).Call(),
+			Id("t").Dot("Cleanup").Call(Func().Params().Block(
+				Id("srv").Dot("client").Dot("CloseIdleConnections").Call(),
+				Id("_").Op("=").Id("srv").Dot("Transport").Dot("Shutdown").Call(),
+				Id("_").Op("=").Id("srv").Dot("listener").Dot("Close").Call(),
+			)),
+			Return(),
+		)
+
+	srcFile.Line().Comment("URL возвращает базовый адрес транспорта для HTTP клиентов, созданных через Client.")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("URL").Params().String().Block(
+		Return(Id("baseURL")),
+	)
+
+	srcFile.Line().Comment("Client возвращает HTTP клиент, подключённый к транспорту.")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("Client").Params().Op("*").Qual(PackageNetHTTP, "Client").Block(
+		Return(Id("srv").Dot("client")),
+	)
+
+	srcFile.Line().Comment("Do выполняет произвольный HTTP запрос к транспорту.")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("Do").Params(Id("request").Op("*").Qual(PackageNetHTTP, "Request")).Params(Op("*").Qual(PackageNetHTTP, "Response"), Error()).Block(
+		Return(Id("srv").Dot("client").Dot("Do").Call(Id("request"))),
+	)
+
+	r.renderTestingError(&srcFile, jsonPkg)
+	r.renderTestingAsserts(&srcFile)
+	r.renderTestingCall(&srcFile, jsonPkg)
+
+	if r.hasJsonRPC() {
+		if err := r.renderTestingConformance(testingDir); err != nil {
+			return err
+		}
+	}
+	return srcFile.Save(path.Join(testingDir, "server.go"))
+}
+
+// renderTestingError генерирует тип ошибки вызова с HTTP статусом и кодом JSON-RPC.
+func (r *transportRenderer) renderTestingError(srcFile *GoFile, jsonPkg string) {
+
+	srcFile.Line().Comment("Error - ошибка вызова метода транспорта.")
+	srcFile.Type().Id("Error").Struct(
+		Id("HTTPCode").Int().Comment("HTTP статус ответа"),
+		Id("Code").Int().Comment("код ошибки JSON-RPC, 0 для REST методов"),
+		Id("Message").String(),
+		Id("Data").Qual(jsonPkg, "RawMessage"),
+		Id("Body").Index().Byte(),
+	)
+
+	srcFile.Line().Func().Params(Id("e").Op("*").Id("Error")).Id("Error").Params().String().Block(
+		If(Id("e").Dot("Code").Op("!=").Lit(0)).Block(
+			Return(Qual(PackageFmt, "Sprintf").Call(Lit("jsonrpc error %d: %s"), Id("e").Dot("Code"), Id("e").Dot("Message"))),
+		),
+		Return(Qual(PackageFmt, "Sprintf").Call(Lit("http error %d: %s"), Id("e").Dot("HTTPCode"), Id("e").Dot("Message"))),
+	)
+
+	srcFile.Line().Comment("AsError извлекает Error из цепочки ошибок.")
+	srcFile.Func().Id("AsError").Params(Err().Error()).Params(Id("callErr").Op("*").Id("Error"), Id("ok").Bool()).Block(
+		Id("ok").Op("=").Qual(PackageErrors, "As").Call(Err(), Op("&").Id("callErr")),
+		Return(),
+	)
+}
+
+// renderTestingAsserts генерирует помощники проверки ошибок по HTTP статусу и коду JSON-RPC.
+func (r *transportRenderer) renderTestingAsserts(srcFile *GoFile) {
+
+	srcFile.Line().Comment("AssertNoError проверяет, что вызов завершился без ошибки.")
+	srcFile.Func().Id("AssertNoError").Params(Id("t").Qual(PackageTesting, "TB"), Err().Error()).Block(
+		Line().Id("t").Dot("Helper").Call(),
+		If(Err().Op("!=").Nil()).Block(
+			Id("t").Dot("Fatalf").Call(Lit("unexpected error: %v"), Err()),
+		),
+	)
+
+	srcFile.Line().Comment("AssertHTTPCode проверяет, что вызов завершился ошибкой с указанным HTTP статусом.")
+	srcFile.Func().Id("AssertHTTPCode").Params(Id("t").Qual(PackageTesting, "TB"), Err().Error(), Id("code").Int()).Block(
+		Line().Id("t").Dot("Helper").Call(),
+		List(Id("callErr"), Id("ok")).Op(":=").Id("AsError").Call(Err()),
+		If(Op("!").Id("ok")).Block(
+			Id("t").Dot("Fatalf").Call(Lit("expected HTTP error %d, got: %v"), Id("code"), Err()),
+		),
+		If(Id("callErr").Dot("HTTPCode").Op("!=").Id("code")).Block(
+			Id("t").Dot("Fatalf").Call(Lit("expected HTTP status %d, got %d: %s"), Id("code"), Id("callErr").Dot("HTTPCode"), Id("callErr").Dot("Message")),
+		),
+	)
+
+	srcFile.Line().Comment("AssertRPCCode проверяет, что вызов завершился ошибкой JSON-RPC с указанным кодом.")
+	srcFile.Func().Id("AssertRPCCode").Params(Id("t").Qual(PackageTesting, "TB"), Err().Error(), Id("code").Int()).Block(
+		Line().Id("t").Dot("Helper").Call(),
+		List(Id("callErr"), Id("ok")).Op(":=").Id("AsError").Call(Err()),
+		If(Op("!").Id("ok")).Block(
+			Id("t").Dot("Fatalf").Call(Lit("expected JSON-RPC error %d, got: %v"), Id("code"), Err()),
+		),
+		If(Id("callErr").Dot("Code").Op("!=").Id("code")).Block(
+			Id("t").Dot("Fatalf").Call(Lit("expected JSON-RPC code %d, got %d: %s"), Id("code"), Id("callErr").Dot("Code"), Id("callErr").Dot("Message")),
+		),
+	)
+}
+
+// renderTestingCall генерирует выполнение JSON-RPC и REST вызовов с разбором ошибок.
+func (r *transportRenderer) renderTestingCall(srcFile *GoFile, jsonPkg string) {
+
+	srcFile.Line().Type().Id("responseJsonRPC").Struct(
+		Id("Error").Op("*").Struct(
+			Id("Code").Int().Tag(map[string]string{"json": "code"}),
+			Id("Message").String().Tag(map[string]string{"json": "message"}),
+			Id("Data").Qual(jsonPkg, "RawMessage").Tag(map[string]string{"json": "data,omitempty"}),
+		).Tag(map[string]string{"json": "error,omitempty"}),
+		Id("Result").Qual(jsonPkg, "RawMessage").Tag(map[string]string{"json": "result,omitempty"}),
+	)
+
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("callJsonRPC").
+		Params(Id(VarNameCtx).Qual(PackageContext, "Context"), Id("method").String(), List(Id("params"), Id("result")).Any()).
+		Params(Err().Error()).
+		Block(
+			Line().Var().Id("body").Index().Byte(),
+			If(List(Id("body"), Err()).Op("=").Qual(jsonPkg, "Marshal").Call(Map(String()).Any().Values(Dict{
+				Lit("jsonrpc"): Lit("2.0"),
+				Lit("id"):      Lit(1),
+				Lit("method"):  Id("method"),
+				Lit("params"):  Id("params"),
+			})).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			Var().Id("request").Op("*").Qual(PackageNetHTTP, "Request"),
+			If(List(Id("request"), Err()).Op("=").Qual(PackageNetHTTP, "NewRequestWithContext").Call(Id(VarNameCtx), Qual(PackageNetHTTP, "MethodPost"), Id("baseURL").Op("+").Lit("/"), Qual(PackageBytes, "NewReader").Call(Id("body"))).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			Id("request").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Lit("application/json")),
+			Var().Id("respBody").Index().Byte(),
+			If(List(Id("respBody"), Err()).Op("=").Id("srv").Dot("roundTrip").Call(Id("request"), Qual(PackageNetHTTP, "StatusOK")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			Var().Id("response").Id("responseJsonRPC"),
+			If(Err().Op("=").Qual(jsonPkg, "Unmarshal").Call(Id("respBody"), Op("&").Id("response")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(Qual(PackageFmt, "Errorf").Call(Lit("decode JSON-RPC response: %w"), Err())),
+			),
+			If(Id("response").Dot("Error").Op("!=").Nil()).Block(
+				Return(Op("&").Id("Error").Values(Dict{
+					Id("HTTPCode"): Qual(PackageNetHTTP, "StatusOK"),
+					Id("Code"):     Id("response").Dot("Error").Dot("Code"),
+					Id("Message"):  Id("response").Dot("Error").Dot("Message"),
+					Id("Data"):     Id("response").Dot("Error").Dot("Data"),
+					Id("Body"):     Id("respBody"),
+				})),
+			),
+			If(Id("result").Op("==").Nil().Op("||").Len(Id("response").Dot("Result")).Op("==").Lit(0)).Block(
+				Return(),
+			),
+			Return(Qual(jsonPkg, "Unmarshal").Call(Id("response").Dot("Result"), Id("result"))),
+		)
+
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("callHTTP").
+		Params(Id("request").Op("*").Qual(PackageNetHTTP, "Request"), Id("successCode").Int(), Id("result").Any()).
+		Params(Err().Error()).
+		Block(
+			Line().Var().Id("respBody").Index().Byte(),
+			If(List(Id("respBody"), Err()).Op("=").Id("srv").Dot("roundTrip").Call(Id("request"), Id("successCode")).Op(";").Err().Op("!=").Nil().Op("||").Id("result").Op("==").Nil()).Block(
+				Return(),
+			),
+			Return(Qual(jsonPkg, "Unmarshal").Call(Id("respBody"), Id("result"))),
+		)
+
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("roundTrip").
+		Params(Id("request").Op("*").Qual(PackageNetHTTP, "Request"), Id("successCode").Int()).
+		Params(Id("body").Index().Byte(), Err().Error()).
+		Block(
+			Line().Var().Id("response").Op("*").Qual(PackageNetHTTP, "Response"),
+			If(List(Id("response"), Err()).Op("=").Id("srv").Dot("client").Dot("Do").Call(Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			Defer().Id("response").Dot("Body").Dot("Close").Call(),
+			If(List(Id("body"), Err()).Op("=").Qual(PackageIO, "ReadAll").Call(Id("response").Dot("Body")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			If(Id("response").Dot("StatusCode").Op("!=").Id("successCode")).Block(
+				Return(Nil(), Id("newHTTPError").Call(Id("response").Dot("StatusCode"), Id("body"))),
+			),
+			Return(),
+		)
+
+	srcFile.Line().Func().Id("newHTTPError").Params(Id("status").Int(), Id("body").Index().Byte()).Params(Op("*").Id("Error")).Block(
+		Line().Id("callErr").Op(":=").Op("&").Id("Error").Values(Dict{
+			Id("HTTPCode"): Id("status"),
+			Id("Message"):  String().Call(Id("body")),
+			Id("Body"):     Id("body"),
+		}),
+		Var().Id("payload").Struct(
+			Id("Detail").String().Tag(map[string]string{"json": "detail"}),
+			Id("Title").String().Tag(map[string]string{"json": "title"}),
+			Id("Message").String().Tag(map[string]string{"json": "message"}),
+		),
+		If(Qual(jsonPkg, "Unmarshal").Call(Id("body"), Op("&").Id("payload")).Op("==").Nil()).Block(
+			Switch().Block(
+				Case(Id("payload").Dot("Detail").Op("!=").Lit("")).Block(
+					Id("callErr").Dot("Message").Op("=").Id("payload").Dot("Detail"),
+				),
+				Case(Id("payload").Dot("Message").Op("!=").Lit("")).Block(
+					Id("callErr").Dot("Message").Op("=").Id("payload").Dot("Message"),
+				),
+				Case(Id("payload").Dot("Title").Op("!=").Lit("")).Block(
+					Id("callErr").Dot("Message").Op("=").Id("payload").Dot("Title"),
+				),
+			),
+		),
+		Return(Id("callErr")),
+	)
+}
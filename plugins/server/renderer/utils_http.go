@@ -17,6 +17,9 @@ func (r *contractRenderer) batchPath() string {
 
 	prefix := r.contract.Annotations.Value(TagHttpPrefix, "")
 	pathValue := r.contract.Annotations.Value(TagHttpPath, "/"+toLowerCamel(r.contract.Name))
+	if version := contractVersion(r.contract); version != "" {
+		pathValue = "/" + version + pathValue
+	}
 	if prefix != "" {
 		return "/" + prefix + pathValue
 	}
@@ -51,6 +54,9 @@ func (r *contractRenderer) methodHTTPPath(method *parser.Method) string {
 
 	prefix := r.contract.Annotations.Value(TagHttpPrefix, "")
 	methodPath := method.Annotations.Value(TagHttpPath, "/"+toLowerCamel(r.contract.Name)+"/"+toLowerCamel(method.Name))
+	if version := methodVersion(r.contract, method); version != "" {
+		methodPath = "/" + version + methodPath
+	}
 	if prefix != "" {
 		return "/" + prefix + methodPath
 	}
@@ -65,7 +71,7 @@ func (r *contractRenderer) methodJsonRPCPath(method *parser.Method) string {
 	prefix := r.contract.Annotations.Value(TagHttpPrefix, "")
 	urlPath := method.Annotations.Value(TagHttpPath, "/"+toLowerCamel(r.contract.Name)+"/"+toLowerCamel(method.Name))
 	urlPath = strings.Split(urlPath, ":")[0]
-	return path.Join(append(elements, prefix, urlPath)...)
+	return path.Join(append(elements, prefix, methodVersion(r.contract, method), urlPath)...)
 }
 
 // methodIsJsonRPC проверяет, является ли метод JSON-RPC методом.
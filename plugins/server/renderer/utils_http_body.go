@@ -0,0 +1,218 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+	"tgp/plugins/server/renderer/types"
+)
+
+// Режимы тела HTTP запроса
+const (
+	bodyModeJSON      = ""
+	bodyModeRaw       = "raw"
+	bodyModeMultipart = "multipart"
+	bodyModeNone      = "none"
+)
+
+const (
+	typeIDReader     = "io:Reader"
+	typeIDReadCloser = "io:ReadCloser"
+)
+
+// methodBodyMode возвращает режим тела HTTP запроса метода.
+func (r *contractRenderer) methodBodyMode(method *parser.Method) string {
+
+	if method.Annotations.IsSet(TagHttpMultipart) {
+		return bodyModeMultipart
+	}
+	if method.Annotations.Value(TagHttpBody, "") == bodyModeRaw {
+		return bodyModeRaw
+	}
+	return bodyModeJSON
+}
+
+// isStreamVar проверяет, является ли переменная потоком (io.Reader, io.ReadCloser).
+func isStreamVar(v *parser.Variable) bool {
+	return !v.IsSlice && v.NumberOfPointers == 0 && (v.TypeID == typeIDReader || v.TypeID == typeIDReadCloser)
+}
+
+// isBinaryVar проверяет, является ли переменная бинарными данными (поток или []byte).
+func isBinaryVar(v *parser.Variable) bool {
+
+	if isStreamVar(v) {
+		return true
+	}
+	return v.IsSlice && v.ArrayLen == 0 && !v.IsEllipsis && (v.TypeID == "byte" || v.TypeID == "uint8")
+}
+
+// binaryArgs возвращает бинарные аргументы, передаваемые в теле запроса.
+func (r *contractRenderer) binaryArgs(method *parser.Method) []*parser.Variable {
+
+	vars := make([]*parser.Variable, 0)
+	for _, arg := range r.argsWithoutSpecialArgs(method) {
+		if isBinaryVar(arg) {
+			vars = append(vars, arg)
+		}
+	}
+	return vars
+}
+
+// streamResult возвращает результат метода, отдаваемый как файл, или nil.
+func (r *contractRenderer) streamResult(method *parser.Method) *parser.Variable {
+
+	for _, ret := range resultsWithoutError(method) {
+		if isStreamVar(ret) {
+			return ret
+		}
+	}
+	return nil
+}
+
+// allowedContentTypes возвращает список допустимых Content-Type для бинарных данных.
+func (r *contractRenderer) allowedContentTypes(method *parser.Method) []string {
+
+	var contentTypes []string
+	for _, item := range strings.FieldsFunc(method.Annotations.Value(TagHttpContentType, ""), func(c rune) bool { return c == ',' || c == '|' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			contentTypes = append(contentTypes, item)
+		}
+	}
+	return contentTypes
+}
+
+// checkContentType генерирует проверку Content-Type бинарных данных.
+func (r *contractRenderer) checkContentType(method *parser.Method, contentType Code) Code {
+
+	allowed := r.allowedContentTypes(method)
+	if len(allowed) == 0 {
+		return Null()
+	}
+	cond := Id("mediaType").Op("!=").Lit(allowed[0])
+	for _, ct := range allowed[1:] {
+		cond.Op("&&").Id("mediaType").Op("!=").Lit(ct)
+	}
+	return If(List(Id("mediaType"), Id("_"), Id("_")).Op(":=").Qual(PackageMime, "ParseMediaType").Call(contentType).Op(";").Add(cond)).Block(
+		r.httpErrorResponse(Qual(PackageFiber, "StatusUnsupportedMediaType"), Lit("unsupported content type: ").Op("+").Id("mediaType")),
+	)
+}
+
+// checkMaxSize генерирует проверку размера бинарных данных.
+func (r *contractRenderer) checkMaxSize(method *parser.Method, size Code) Code {
+
+	maxSize := method.Annotations.ValueInt(TagHttpMaxSize, 0)
+	if maxSize <= 0 {
+		return Null()
+	}
+	return If(Add(size).Op(">").Lit(maxSize)).Block(
+		r.httpErrorResponse(Qual(PackageFiber, "StatusRequestEntityTooLarge"), Lit("request body too large")),
+	)
+}
+
+// httpRawBody генерирует привязку тела запроса как есть к бинарному аргументу.
+func (r *contractRenderer) httpRawBody(method *parser.Method) Code {
+
+	binArgs := r.binaryArgs(method)
+	if len(binArgs) == 0 {
+		return Null()
+	}
+	arg := binArgs[0]
+	bg := Line()
+	bg.Add(r.checkContentType(method, Id(VarNameFtx).Dot("Get").Call(Qual(PackageFiber, "HeaderContentType")))).Line()
+	bg.Add(r.checkMaxSize(method, Len(Id(VarNameFtx).Dot("Body").Call()))).Line()
+	bg.Add(r.binaryFromBytes(arg, Qual(PackageBytes, "Clone").Call(Id(VarNameFtx).Dot("Body").Call())))
+	return bg
+}
+
+// httpMultipartBody генерирует привязку частей multipart формы к аргументам.
+func (r *contractRenderer) httpMultipartBody(srcFile *GoFile, typeGen *types.Generator, method *parser.Method) Code {
+
+	bg := Line()
+	formValues := make(map[string]string)
+	var orderedArgs []string
+	for _, arg := range r.argsWithoutSpecialArgs(method) {
+		if isBinaryVar(arg) {
+			continue
+		}
+		formValues[arg.Name] = arg.Name
+		orderedArgs = append(orderedArgs, arg.Name)
+	}
+	bg.Add(r.argFromStringOrdered(srcFile, typeGen, method, "formValue", formValues, orderedArgs,
+		func(srcName string) Code {
+			return Id(VarNameFtx).Dot("FormValue").Call(Lit(srcName))
+		},
+		func(arg, header string) *Statement {
+			return Line().If(Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+				ig.Add(r.httpErrorResponse(Qual(PackageFiber, "StatusBadRequest"), Lit("form value could not be decoded: ").Op("+").Err().Dot("Error").Call()))
+			})
+		},
+	))
+	for _, arg := range r.binaryArgs(method) {
+		partVar := "_" + toLowerCamel(arg.Name) + "Part"
+		bg.BlockFunc(func(ig *Group) {
+			ig.List(Id(partVar), Err()).Op(":=").Id(VarNameFtx).Dot("FormFile").Call(Lit(arg.Name))
+			ig.If(Err().Op("!=").Nil()).Block(
+				r.httpErrorResponse(Qual(PackageFiber, "StatusBadRequest"), Lit("multipart part '"+arg.Name+"' could not be read: ").Op("+").Err().Dot("Error").Call()),
+			)
+			ig.Add(r.checkContentType(method, Id(partVar).Dot("Header").Dot("Get").Call(Qual(PackageFiber, "HeaderContentType"))))
+			ig.Add(r.checkMaxSize(method, Id(partVar).Dot("Size")))
+			ig.List(Id("file"), Err()).Op(":=").Id(partVar).Dot("Open").Call()
+			ig.If(Err().Op("!=").Nil()).Block(
+				r.httpErrorResponse(Qual(PackageFiber, "StatusBadRequest"), Lit("multipart part '"+arg.Name+"' could not be opened: ").Op("+").Err().Dot("Error").Call()),
+			)
+			if isStreamVar(arg) {
+				ig.Defer().Id("file").Dot("Close").Call()
+				ig.Id("request").Dot(toCamel(arg.Name)).Op("=").Id("file")
+				return
+			}
+			ig.List(Id("request").Dot(toCamel(arg.Name)), Err()).Op("=").Qual(PackageIO, "ReadAll").Call(Id("file"))
+			ig.Id("_").Op("=").Id("file").Dot("Close").Call()
+			ig.If(Err().Op("!=").Nil()).Block(
+				r.httpErrorResponse(Qual(PackageFiber, "StatusBadRequest"), Lit("multipart part '"+arg.Name+"' could not be read: ").Op("+").Err().Dot("Error").Call()),
+			)
+		})
+	}
+	return bg
+}
+
+// binaryFromBytes генерирует присваивание бинарного аргумента из среза байт.
+func (r *contractRenderer) binaryFromBytes(arg *parser.Variable, data Code) Code {
+
+	field := Id("request").Dot(toCamel(arg.Name))
+	switch arg.TypeID {
+	case typeIDReader:
+		return field.Op("=").Qual(PackageBytes, "NewReader").Call(data)
+	case typeIDReadCloser:
+		return field.Op("=").Qual(PackageIO, "NopCloser").Call(Qual(PackageBytes, "NewReader").Call(data))
+	default:
+		return field.Op("=").Add(data)
+	}
+}
+
+// httpStreamResponse генерирует отдачу потокового результата как файла.
+func (r *contractRenderer) httpStreamResponse(method *parser.Method, ret *parser.Variable) Code {
+
+	bg := Line()
+	bg.If(Len(Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("ContentType").Call()).Op("==").Lit(0).Op("||").
+		String().Call(Id(VarNameFtx).Dot("Response").Call().Dot("Header").Dot("ContentType").Call()).Op("==").Qual(PackageFiber, "MIMETextPlainCharsetUTF8")).Block(
+		Id(VarNameFtx).Dot("Set").Call(Qual(PackageFiber, "HeaderContentType"), Qual(PackageFiber, "MIMEOctetStream")),
+	).Line()
+	disposition := Lit("attachment")
+	if fileName := method.Annotations.Value(TagHttpDownload, ""); fileName != "" {
+		if fileRet := r.resultByName(method, fileName); fileRet != nil && fileRet.TypeID == "string" && fileRet.NumberOfPointers == 0 {
+			disposition = Qual(PackageMime, "FormatMediaType").Call(Lit("attachment"), Map(String()).String().Values(Dict{
+				Lit("filename"): Id("response").Dot(toCamel(fileRet.Name)),
+			}))
+		}
+	}
+	bg.Id(VarNameFtx).Dot("Set").Call(Qual(PackageFiber, "HeaderContentDisposition"), disposition).Line()
+	bg.If(Id("response").Dot(toCamel(ret.Name)).Op("==").Nil()).Block(
+		Return().Id(VarNameFtx).Dot("Send").Call(Nil()),
+	).Line()
+	bg.Return().Id(VarNameFtx).Dot("SendStream").Call(Id("response").Dot(toCamel(ret.Name)))
+	return bg
+}
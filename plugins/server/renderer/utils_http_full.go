@@ -647,9 +647,10 @@ func (r *contractRenderer) urlArgs(srcFile *GoFile, typeGen *types.Generator, me
 	)
 }
 
-// urlParams генерирует код для извлечения аргументов из query параметров.
-func (r *contractRenderer) urlParams(srcFile *GoFile, typeGen *types.Generator, method *parser.Method, errStatement func(arg, header string) *Statement) *Statement {
-	queryParams := make(map[string]string)
+// argQueryMap возвращает маппинг аргументов на query параметры в порядке объявления.
+func (r *contractRenderer) argQueryMap(method *parser.Method) (queryParams map[string]string, orderedArgs []string) {
+
+	queryParams = make(map[string]string)
 	if urlArgs := method.Annotations.Value(TagHttpArg, ""); urlArgs != "" {
 		paramPairs := strings.Split(urlArgs, ",")
 		for _, pair := range paramPairs {
@@ -675,7 +676,6 @@ func (r *contractRenderer) urlParams(srcFile *GoFile, typeGen *types.Generator,
 			}
 		}
 	}
-	var orderedArgs []string
 	if urlArgs := method.Annotations.Value(TagHttpArg, ""); urlArgs != "" {
 		paramPairs := strings.Split(urlArgs, ",")
 		for _, pair := range paramPairs {
@@ -697,6 +697,12 @@ func (r *contractRenderer) urlParams(srcFile *GoFile, typeGen *types.Generator,
 			}
 		}
 	}
+	return
+}
+
+// urlParams генерирует код для извлечения аргументов из query параметров.
+func (r *contractRenderer) urlParams(srcFile *GoFile, typeGen *types.Generator, method *parser.Method, errStatement func(arg, header string) *Statement) *Statement {
+	queryParams, orderedArgs := r.argQueryMap(method)
 	return r.argFromStringOrdered(srcFile, typeGen, method, "queryParam", queryParams, orderedArgs,
 		func(srcName string) Code {
 			return Id(VarNameFtx).Dot("Query").Call(Lit(srcName))
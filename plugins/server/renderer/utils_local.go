@@ -3,6 +3,7 @@
 package renderer
 
 import (
+	"sort"
 	"strings"
 	"unicode"
 
@@ -149,3 +150,14 @@ func toLowerCamel(s string) string {
 	}
 	return result.String()
 }
+
+// sortedKeys возвращает ключи маппинга в отсортированном порядке.
+func sortedKeys(m map[string]string) []string {
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
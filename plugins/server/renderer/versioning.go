@@ -0,0 +1,131 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package renderer
+
+import (
+	"net/http"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"tgp/internal/parser"
+	"tgp/plugins/server/utils"
+)
+
+// Заголовки вывода метода из эксплуатации (RFC 9745, RFC 8594)
+const (
+	headerDeprecation = "Deprecation"
+	headerSunset      = "Sunset"
+)
+
+// contractVersion возвращает версию API контракта.
+func contractVersion(contract *parser.Contract) string {
+	return strings.TrimSpace(contract.Annotations.Value(TagVersion, ""))
+}
+
+// methodVersion возвращает версию API метода (аннотация метода переопределяет аннотацию контракта).
+func methodVersion(contract *parser.Contract, method *parser.Method) string {
+
+	if version := strings.TrimSpace(method.Annotations.Value(TagVersion, "")); version != "" {
+		return version
+	}
+	return contractVersion(contract)
+}
+
+// methodDeprecated проверяет, выводится ли метод из эксплуатации (сам по себе или вместе с контрактом).
+func methodDeprecated(contract *parser.Contract, method *parser.Method) bool {
+	return method.Annotations.IsSet(TagDeprecated) || contract.Annotations.IsSet(TagDeprecated)
+}
+
+// methodSunset возвращает дату отключения метода в формате HTTP заголовка Sunset.
+func methodSunset(contract *parser.Contract, method *parser.Method) string {
+
+	value := method.Annotations.Value(TagSunset, contract.Annotations.Value(TagSunset, ""))
+	if value == "" {
+		return ""
+	}
+	sunset, err := utils.ParseSunset(value)
+	if err != nil {
+		return ""
+	}
+	return sunset.Format(http.TimeFormat)
+}
+
+// jsonRPCMethodName возвращает имя JSON-RPC метода с пространством имён версии в нижнем регистре.
+func jsonRPCMethodName(contract *parser.Contract, method *parser.Method) string {
+
+	name := contract.Name + "." + method.Name
+	if version := methodVersion(contract, method); version != "" {
+		name = version + "." + name
+	}
+	return strings.ToLower(name)
+}
+
+// contractHasDeprecated проверяет, есть ли в контракте выводимые из эксплуатации методы.
+func contractHasDeprecated(contract *parser.Contract) bool {
+
+	for _, method := range contract.Methods {
+		if methodDeprecated(contract, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDeprecated проверяет, есть ли контракты с выводимыми из эксплуатации методами.
+func (r *baseRenderer) hasDeprecated() bool {
+
+	for _, contract := range r.project.Contracts {
+		if contractHasDeprecated(contract) {
+			return true
+		}
+	}
+	return false
+}
+
+// deprecationHeaders генерирует установку заголовков Deprecation и Sunset для выводимого из эксплуатации метода.
+func (r *contractRenderer) deprecationHeaders(method *parser.Method) Code {
+
+	if !methodDeprecated(r.contract, method) {
+		return Null()
+	}
+	code := Id(VarNameFtx).Dot("Set").Call(Lit(headerDeprecation), Lit("true"))
+	if sunset := methodSunset(r.contract, method); sunset != "" {
+		code.Line().Id(VarNameFtx).Dot("Set").Call(Lit(headerSunset), Lit(sunset))
+	}
+	return code
+}
+
+// jsonRPCDeprecatedMap генерирует карту выводимых из эксплуатации JSON-RPC методов с датами отключения.
+func (r *transportRenderer) jsonRPCDeprecatedMap() Code {
+
+	return Var().Id("jsonRPCDeprecated").Op("=").Map(String()).String().Values(DictFunc(func(dict Dict) {
+		for _, contract := range r.project.Contracts {
+			if !contract.Annotations.Contains(TagServerJsonRPC) {
+				continue
+			}
+			for _, method := range contract.Methods {
+				if r.methodIsJsonRPCForContract(contract, method) && methodDeprecated(contract, method) {
+					dict[Lit(jsonRPCMethodName(contract, method))] = Lit(methodSunset(contract, method))
+				}
+			}
+		}
+	}))
+}
+
+// setDeprecationHeadersFunc генерирует установку заголовков Deprecation и Sunset для пакета JSON-RPC запросов.
+func (r *transportRenderer) setDeprecationHeadersFunc() Code {
+
+	return Func().Id("setDeprecationHeaders").
+		Params(Id(VarNameFtx).Op("*").Qual(PackageFiber, "Ctx"), Id("requests").Op("[]").Id("baseJsonRPC")).
+		Block(
+			For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
+				If(List(Id("sunset"), Id("deprecated")).Op(":=").Id("jsonRPCDeprecated").Index(Id("toLowercaseMethod").Call(Id("request").Dot("Method"))).Op(";").Id("deprecated")).Block(
+					Id(VarNameFtx).Dot("Set").Call(Lit(headerDeprecation), Lit("true")),
+					If(Id("sunset").Op("!=").Lit("")).Block(
+						Id(VarNameFtx).Dot("Set").Call(Lit(headerSunset), Id("sunset")),
+					),
+				),
+			),
+		)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tgp/internal/parser"
+)
+
+// Теги кэширования ответов REST методов
+const (
+	tagHttpCache  = "http-cache"
+	tagEtag       = "etag"
+	tagHttpMethod = "http-method"
+)
+
+// ParseCacheControl разбирает значение аннотации http-cache (директивы Cache-Control)
+// и возвращает время хранения ответа в серверном кэше (0 - ответ не кэшируется на сервере).
+func ParseCacheControl(value string) (ttl time.Duration, err error) {
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("invalid http cache %q: empty Cache-Control directives", value)
+	}
+	var maxAge, sharedMaxAge = -1, -1
+	var noStore bool
+	for _, directive := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return 0, fmt.Errorf("invalid http cache %q: empty directive", value)
+		}
+		switch name {
+		case "max-age", "s-maxage":
+			seconds, errAtoi := strconv.Atoi(strings.TrimSpace(arg))
+			if errAtoi != nil || seconds < 0 {
+				return 0, fmt.Errorf("invalid http cache %q: %s expects non-negative number of seconds", value, name)
+			}
+			if name == "max-age" {
+				maxAge = seconds
+			} else {
+				sharedMaxAge = seconds
+			}
+		case "no-store", "no-cache", "private":
+			noStore = true
+		}
+	}
+	if noStore {
+		return 0, nil
+	}
+	if sharedMaxAge >= 0 {
+		return time.Duration(sharedMaxAge) * time.Second, nil
+	}
+	if maxAge > 0 {
+		return time.Duration(maxAge) * time.Second, nil
+	}
+	return 0, nil
+}
+
+// validateMethodCache проверяет, что кэширование задано для REST GET метода с сериализуемым ответом.
+func validateMethodCache(method *parser.Method) error {
+
+	if !method.Annotations.IsSet(tagHttpCache) && !method.Annotations.IsSet(tagEtag) {
+		return nil
+	}
+	if !strings.EqualFold(method.Annotations.Value(tagHttpMethod, ""), "GET") {
+		return fmt.Errorf("http cache and etag require %s=GET", tagHttpMethod)
+	}
+	if method.Annotations.IsSet(tagHttpCache) {
+		if _, err := ParseCacheControl(method.Annotations.Value(tagHttpCache)); err != nil {
+			return err
+		}
+	}
+	for _, result := range method.Results {
+		if result.TypeID == "io:Reader" || result.TypeID == "io:ReadCloser" {
+			return fmt.Errorf("cached method cannot return stream %q", result.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCacheControl(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		value   string
+		wantTTL time.Duration
+		wantErr bool
+	}{
+		{name: "max age", value: "max-age=60", wantTTL: time.Minute},
+		{name: "public max age", value: "public, max-age=30", wantTTL: 30 * time.Second},
+		{name: "shared max age wins", value: "max-age=60, s-maxage=10", wantTTL: 10 * time.Second},
+		{name: "private", value: "private, max-age=60", wantTTL: 0},
+		{name: "no store", value: "no-store", wantTTL: 0},
+		{name: "zero max age", value: "max-age=0", wantTTL: 0},
+		{name: "empty", value: "", wantErr: true},
+		{name: "bad max age", value: "max-age=soon", wantErr: true},
+		{name: "negative max age", value: "max-age=-1", wantErr: true},
+		{name: "empty directive", value: "public,,max-age=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := ParseCacheControl(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCacheControl() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ttl != tt.wantTTL {
+				t.Errorf("ParseCacheControl() = %s, want %s", ttl, tt.wantTTL)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"fmt"
+
+	"tgp/internal/parser"
+)
+
+// tagIdempotent тег идемпотентного метода
+const tagIdempotent = "idempotent"
+
+// validateMethodIdempotency проверяет, что ответ идемпотентного метода можно сохранить для повтора.
+func validateMethodIdempotency(method *parser.Method) error {
+
+	if !method.Annotations.IsSet(tagIdempotent) {
+		return nil
+	}
+	for _, result := range method.Results {
+		if result.TypeID == "io:Reader" || result.TypeID == "io:ReadCloser" {
+			return fmt.Errorf("idempotent method cannot return stream %q", result.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tgp/internal/parser"
+)
+
+// Теги ограничений метода
+const (
+	tagRateLimit      = "rate-limit"
+	tagRateLimitBurst = "burst"
+	tagRateLimitKey   = "key"
+	tagMaxInflight    = "max-inflight"
+)
+
+// ParseRateLimit разбирает значение аннотации rate-limit вида "100/s", "10/1m" или "5".
+func ParseRateLimit(value string) (limit int, period time.Duration, err error) {
+
+	value = strings.TrimSpace(value)
+	countStr, periodStr, found := strings.Cut(value, "/")
+	if limit, err = strconv.Atoi(strings.TrimSpace(countStr)); err != nil || limit <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate limit %q: expected positive number of requests", value)
+	}
+	period = time.Second
+	if found {
+		periodStr = strings.TrimSpace(periodStr)
+		if periodStr == "" {
+			return 0, 0, fmt.Errorf("invalid rate limit %q: empty period", value)
+		}
+		if periodStr[0] < '0' || periodStr[0] > '9' {
+			periodStr = "1" + periodStr
+		}
+		if period, err = time.ParseDuration(periodStr); err != nil || period <= 0 {
+			return 0, 0, fmt.Errorf("invalid rate limit %q: bad period", value)
+		}
+	}
+	return limit, period, nil
+}
+
+// ParseRateLimitKey разбирает значение аннотации key и возвращает источник ключа ограничителя.
+func ParseRateLimitKey(value string) (source, name string, err error) {
+
+	value = strings.TrimSpace(value)
+	if value == "" || value == "ip" {
+		return value, "", nil
+	}
+	source, name, found := strings.Cut(value, ":")
+	if !found || strings.TrimSpace(name) == "" || (source != "header" && source != "cookie") {
+		return "", "", fmt.Errorf("invalid rate limit key %q: expected ip, header:<name> or cookie:<name>", value)
+	}
+	return source, strings.TrimSpace(name), nil
+}
+
+// validateMethodLimits проверяет аннотации ограничений метода.
+func validateMethodLimits(method *parser.Method) error {
+
+	if method.Annotations.IsSet(tagRateLimit) {
+		if _, _, err := ParseRateLimit(method.Annotations.Value(tagRateLimit)); err != nil {
+			return err
+		}
+		if method.Annotations.IsSet(tagRateLimitBurst) && method.Annotations.ValueInt(tagRateLimitBurst) <= 0 {
+			return fmt.Errorf("invalid burst %q: expected positive number", method.Annotations.Value(tagRateLimitBurst))
+		}
+		if _, _, err := ParseRateLimitKey(method.Annotations.Value(tagRateLimitKey)); err != nil {
+			return err
+		}
+	}
+	if method.Annotations.IsSet(tagMaxInflight) && method.Annotations.ValueInt(tagMaxInflight) <= 0 {
+		return fmt.Errorf("invalid max-inflight %q: expected positive number", method.Annotations.Value(tagMaxInflight))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		value      string
+		wantLimit  int
+		wantPeriod time.Duration
+		wantErr    bool
+	}{
+		{
+			name:       "per second",
+			value:      "100/s",
+			wantLimit:  100,
+			wantPeriod: time.Second,
+		},
+		{
+			name:       "per minute with count",
+			value:      "10/5m",
+			wantLimit:  10,
+			wantPeriod: 5 * time.Minute,
+		},
+		{
+			name:       "default period",
+			value:      "5",
+			wantLimit:  5,
+			wantPeriod: time.Second,
+		},
+		{
+			name:    "zero limit",
+			value:   "0/s",
+			wantErr: true,
+		},
+		{
+			name:    "bad period",
+			value:   "10/week",
+			wantErr: true,
+		},
+		{
+			name:    "empty period",
+			value:   "10/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, period, err := ParseRateLimit(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRateLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if limit != tt.wantLimit || period != tt.wantPeriod {
+				t.Errorf("ParseRateLimit() = %d/%s, want %d/%s", limit, period, tt.wantLimit, tt.wantPeriod)
+			}
+		})
+	}
+}
+
+func TestParseRateLimitKey(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		value      string
+		wantSource string
+		wantName   string
+		wantErr    bool
+	}{
+		{name: "empty", value: ""},
+		{name: "ip", value: "ip", wantSource: "ip"},
+		{name: "header", value: "header:X-Client-Id", wantSource: "header", wantName: "X-Client-Id"},
+		{name: "cookie", value: "cookie:session", wantSource: "cookie", wantName: "session"},
+		{name: "unknown source", value: "query:id", wantErr: true},
+		{name: "empty name", value: "header:", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, name, err := ParseRateLimitKey(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRateLimitKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if source != tt.wantSource || name != tt.wantName {
+				t.Errorf("ParseRateLimitKey() = %q, %q, want %q, %q", source, name, tt.wantSource, tt.wantName)
+			}
+		})
+	}
+}
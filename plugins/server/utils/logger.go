@@ -0,0 +1,95 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"tgp/internal/parser"
+	"tgp/internal/tags"
+)
+
+// Теги настройки логирования
+const (
+	tagLogMask     = "log-mask"
+	tagLogSample   = "log-sample"
+	tagLogTruncate = "log-truncate"
+	tagLogMaxLen   = "log-max-len"
+)
+
+// ParseLogMask разбирает значение аннотации log-mask вида "password,card.number" в список путей.
+func ParseLogMask(value string) (paths []string, err error) {
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		for _, segment := range strings.Split(item, ".") {
+			if strings.TrimSpace(segment) == "" {
+				return nil, fmt.Errorf("invalid log mask %q: empty path segment", item)
+			}
+		}
+		paths = append(paths, item)
+	}
+	return paths, nil
+}
+
+// ParseLogSample разбирает значение аннотации log-sample (доля логируемых успешных вызовов от 0 до 1).
+func ParseLogSample(value string) (rate float64, err error) {
+
+	value = strings.TrimSpace(value)
+	if rate, err = strconv.ParseFloat(value, 64); err != nil || rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("invalid log sample %q: expected number from 0 to 1", value)
+	}
+	return rate, nil
+}
+
+// validateLogAnnotations проверяет аннотации логирования контракта или метода.
+func validateLogAnnotations(annotations tags.DocTags) error {
+
+	if annotations.IsSet(tagLogMask) {
+		if _, err := ParseLogMask(annotations.Value(tagLogMask)); err != nil {
+			return err
+		}
+	}
+	if annotations.IsSet(tagLogSample) {
+		if _, err := ParseLogSample(annotations.Value(tagLogSample)); err != nil {
+			return err
+		}
+	}
+	for _, tag := range []string{tagLogTruncate, tagLogMaxLen} {
+		if annotations.IsSet(tag) && annotations.ValueInt(tag) <= 0 {
+			return fmt.Errorf("invalid %s %q: expected positive number", tag, annotations.Value(tag))
+		}
+	}
+	return nil
+}
+
+// validateMethodLogMask проверяет, что пути log-mask метода начинаются с имени аргумента или результата.
+func validateMethodLogMask(method *parser.Method) error {
+
+	paths, _ := ParseLogMask(method.Annotations.Value(tagLogMask, ""))
+	for _, path := range paths {
+		root, _, _ := strings.Cut(path, ".")
+		if !methodHasVariable(method, root) {
+			return fmt.Errorf("log mask %q: method has no argument or result %q", path, root)
+		}
+	}
+	return nil
+}
+
+// methodHasVariable проверяет, есть ли у метода аргумент или результат с указанным именем.
+func methodHasVariable(method *parser.Method, name string) bool {
+
+	for _, vars := range [][]*parser.Variable{method.Args, method.Results} {
+		for _, v := range vars {
+			if v.Name != "" && strings.EqualFold(v.Name, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
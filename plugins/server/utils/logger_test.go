@@ -0,0 +1,65 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLogMask(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		value     string
+		wantPaths []string
+		wantErr   bool
+	}{
+		{name: "empty", value: ""},
+		{name: "single", value: "password", wantPaths: []string{"password"}},
+		{name: "nested with spaces", value: "password, card.number", wantPaths: []string{"password", "card.number"}},
+		{name: "empty segment", value: "card..number", wantErr: true},
+		{name: "trailing dot", value: "card.", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := ParseLogMask(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLogMask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("ParseLogMask() = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestParseLogSample(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		value    string
+		wantRate float64
+		wantErr  bool
+	}{
+		{name: "fraction", value: "0.1", wantRate: 0.1},
+		{name: "never", value: "0", wantRate: 0},
+		{name: "always", value: "1", wantRate: 1},
+		{name: "above one", value: "1.5", wantErr: true},
+		{name: "negative", value: "-0.1", wantErr: true},
+		{name: "not a number", value: "half", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := ParseLogSample(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLogSample() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if rate != tt.wantRate {
+				t.Errorf("ParseLogSample() = %v, want %v", rate, tt.wantRate)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"tgp/internal/parser"
+)
+
+// Бэкенды метрик методов
+const (
+	tagMetrics = "metrics"
+
+	MetricsPrometheus = "prometheus"
+	MetricsOTel       = "otel"
+)
+
+// MetricsBackend возвращает бэкенд метрик контракта из значения аннотации metrics.
+func MetricsBackend(contract *parser.Contract) (backend string, err error) {
+
+	value := strings.TrimSpace(contract.Annotations.Value(tagMetrics, ""))
+	switch value {
+	case "", "true", MetricsPrometheus:
+		return MetricsPrometheus, nil
+	case MetricsOTel:
+		return MetricsOTel, nil
+	}
+	return "", fmt.Errorf("invalid metrics %q: expected %s or %s", value, MetricsPrometheus, MetricsOTel)
+}
+
+// validateMetricsBackends проверяет, что все контракты с метриками используют один бэкенд.
+func validateMetricsBackends(project *parser.Project) error {
+
+	var first *parser.Contract
+	var firstBackend string
+	for _, contract := range project.Contracts {
+		if !contract.Annotations.IsSet(tagMetrics) {
+			continue
+		}
+		backend, err := MetricsBackend(contract)
+		if err != nil {
+			return fmt.Errorf("contract %q: %w", contract.Name, err)
+		}
+		if first == nil {
+			first, firstBackend = contract, backend
+			continue
+		}
+		if backend != firstBackend {
+			return fmt.Errorf("contract %q: metrics backend %q differs from %q in contract %q", contract.Name, backend, firstBackend, first.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"fmt"
+
+	"tgp/internal/parser"
+)
+
+// Теги курсорной пагинации метода (итераторы по страницам в клиентах)
+const (
+	tagPaginate         = "paginate"
+	tagPaginateCursor   = "cursor"
+	tagPaginateNext     = "next"
+	tagPaginateItems    = "items"
+	tagPaginatePageSize = "page-size"
+)
+
+// integerTypes - встроенные целочисленные типы, допустимые для размера страницы.
+var integerTypes = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+}
+
+// validateMethodPagination проверяет, что сигнатура метода с paginate позволяет обходить страницы:
+// курсор - строковый аргумент, следующий курсор - строковый результат, элементы - слайс в результатах, размер страницы - целое.
+func validateMethodPagination(method *parser.Method) error {
+
+	if !method.Annotations.IsSet(tagPaginate) {
+		return nil
+	}
+	find := func(vars []*parser.Variable, name string) *parser.Variable {
+		for _, v := range vars {
+			if v.Name == name {
+				return v
+			}
+		}
+		return nil
+	}
+	isString := func(v *parser.Variable) bool {
+		return v.TypeID == "string" && !v.IsSlice && v.ArrayLen == 0 && v.NumberOfPointers == 0 && v.MapKeyID == ""
+	}
+
+	cursorName := method.Annotations.Value(tagPaginateCursor, "cursor")
+	cursor := find(method.Args, cursorName)
+	if cursor == nil || !isString(cursor) {
+		return fmt.Errorf("paginate: cursor argument %q must be string", cursorName)
+	}
+	nextName := method.Annotations.Value(tagPaginateNext, "nextCursor")
+	next := find(method.Results, nextName)
+	if next == nil || !isString(next) {
+		return fmt.Errorf("paginate: next cursor result %q must be string", nextName)
+	}
+	itemsName := method.Annotations.Value(tagPaginateItems, "items")
+	items := find(method.Results, itemsName)
+	if items == nil || !items.IsSlice {
+		return fmt.Errorf("paginate: items result %q must be slice", itemsName)
+	}
+	if !IsErrorLast(method.Results) {
+		return fmt.Errorf("paginate: method must return error as the last result")
+	}
+	if pageSizeName := method.Annotations.Value(tagPaginatePageSize, ""); pageSizeName != "" {
+		pageSize := find(method.Args, pageSizeName)
+		if pageSize == nil || pageSize.IsSlice || pageSize.NumberOfPointers != 0 || !integerTypes[pageSize.TypeID] {
+			return fmt.Errorf("paginate: page size argument %q must be integer", pageSizeName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"tgp/internal/tags"
+)
+
+// Тег режима ответов REST методов с ошибкой
+const (
+	tagHttpErrors     = "http-errors"
+	httpErrorsProblem = "problem"
+)
+
+// validateHttpErrors проверяет значение аннотации http-errors контракта.
+func validateHttpErrors(annotations tags.DocTags) error {
+
+	if !annotations.IsSet(tagHttpErrors) {
+		return nil
+	}
+	if value := annotations.Value(tagHttpErrors); !strings.EqualFold(value, httpErrorsProblem) {
+		return fmt.Errorf("invalid %s %q: expected %q", tagHttpErrors, value, httpErrorsProblem)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"fmt"
+
+	"tgp/internal/parser"
+)
+
+const (
+	tagHttpMultipart = "http-multipart"
+	tagHttpBody      = "http-body"
+)
+
+// isStreamVariable проверяет, является ли переменная потоком (io.Reader, io.ReadCloser).
+func isStreamVariable(v *parser.Variable) bool {
+	return !v.IsSlice && v.NumberOfPointers == 0 && (v.TypeID == "io:Reader" || v.TypeID == "io:ReadCloser")
+}
+
+// validateMethodStreams проверяет, что потоки используются только там, где транспорт их передаёт:
+// аргументы - в REST методах с http-multipart или http-body=raw, результаты - в REST методах.
+func validateMethodStreams(method *parser.Method) error {
+
+	isREST := method.Annotations.IsSet(tagHttpMethod)
+	binaryBody := method.Annotations.IsSet(tagHttpMultipart) || method.Annotations.Value(tagHttpBody, "") == "raw"
+	for _, arg := range method.Args {
+		if isStreamVariable(arg) && (!isREST || !binaryBody) {
+			return fmt.Errorf("stream argument %q requires REST method with %s or %s=raw", arg.Name, tagHttpMultipart, tagHttpBody)
+		}
+	}
+	var streams int
+	for _, result := range method.Results {
+		if !isStreamVariable(result) {
+			continue
+		}
+		if !isREST {
+			return fmt.Errorf("stream result %q requires REST method (%s)", result.Name, tagHttpMethod)
+		}
+		if streams++; streams > 1 {
+			return fmt.Errorf("stream result %q: method can return only one stream", result.Name)
+		}
+	}
+	return nil
+}
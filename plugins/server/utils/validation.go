@@ -18,7 +18,7 @@ func ValidateProject(project *parser.Project) error {
 	if project.ModulePath == "" {
 		return fmt.Errorf("project.ModulePath cannot be empty")
 	}
-	return nil
+	return validateMetricsBackends(project)
 }
 
 // ValidateContractID проверяет корректность ID контракта.
@@ -65,6 +65,21 @@ func ValidateContract(contract *parser.Contract, project *parser.Project) error
 		return fmt.Errorf("contract cannot be nil")
 	}
 
+	// Проверяем аннотации логирования контракта
+	if err := validateLogAnnotations(contract.Annotations); err != nil {
+		return fmt.Errorf("contract %q: %w", contract.Name, err)
+	}
+
+	// Проверяем аннотации версии и вывода из эксплуатации контракта
+	if err := validateVersionAnnotations(contract.Annotations); err != nil {
+		return fmt.Errorf("contract %q: %w", contract.Name, err)
+	}
+
+	// Проверяем режим ответов REST методов с ошибкой
+	if err := validateHttpErrors(contract.Annotations); err != nil {
+		return fmt.Errorf("contract %q: %w", contract.Name, err)
+	}
+
 	for _, method := range contract.Methods {
 		// Проверяем именование параметров (кроме context.Context)
 		for i, arg := range method.Args {
@@ -80,19 +95,63 @@ func ValidateContract(contract *parser.Contract, project *parser.Project) error
 			}
 		}
 
-		// Проверяем аргументы на наличие неподдерживаемых типов (рекурсивно)
+		// Проверяем, что потоки передаются транспортом метода
+		if err := validateMethodStreams(method); err != nil {
+			return fmt.Errorf("contract %q: method %q: %w", contract.Name, method.Name, err)
+		}
+
+		// Проверяем аргументы на наличие неподдерживаемых типов (рекурсивно, потоки проверены выше)
 		for _, arg := range method.Args {
+			if isStreamVariable(arg) {
+				continue
+			}
 			if err := validateVariable(arg, project, contract.Name, method.Name, "argument"); err != nil {
 				return err
 			}
 		}
 
-		// Проверяем результаты на наличие неподдерживаемых типов (рекурсивно)
+		// Проверяем результаты на наличие неподдерживаемых типов (рекурсивно, потоки проверены выше)
 		for _, result := range method.Results {
+			if isStreamVariable(result) {
+				continue
+			}
 			if err := validateVariable(result, project, contract.Name, method.Name, "result"); err != nil {
 				return err
 			}
 		}
+
+		// Проверяем аннотации ограничений частоты и параллельности вызовов
+		if err := validateMethodLimits(method); err != nil {
+			return fmt.Errorf("contract %q: method %q: %w", contract.Name, method.Name, err)
+		}
+
+		// Проверяем совместимость идемпотентности с результатами метода
+		if err := validateMethodIdempotency(method); err != nil {
+			return fmt.Errorf("contract %q: method %q: %w", contract.Name, method.Name, err)
+		}
+
+		// Проверяем сигнатуру метода с курсорной пагинацией
+		if err := validateMethodPagination(method); err != nil {
+			return fmt.Errorf("contract %q: method %q: %w", contract.Name, method.Name, err)
+		}
+
+		// Проверяем аннотации маскирования, сэмплирования и усечения логов метода
+		if err := validateLogAnnotations(method.Annotations); err != nil {
+			return fmt.Errorf("contract %q: method %q: %w", contract.Name, method.Name, err)
+		}
+		if err := validateMethodLogMask(method); err != nil {
+			return fmt.Errorf("contract %q: method %q: %w", contract.Name, method.Name, err)
+		}
+
+		// Проверяем аннотации кэширования ответов метода
+		if err := validateMethodCache(method); err != nil {
+			return fmt.Errorf("contract %q: method %q: %w", contract.Name, method.Name, err)
+		}
+
+		// Проверяем аннотации версии и вывода из эксплуатации метода
+		if err := validateVersionAnnotations(method.Annotations); err != nil {
+			return fmt.Errorf("contract %q: method %q: %w", contract.Name, method.Name, err)
+		}
 	}
 
 	return nil
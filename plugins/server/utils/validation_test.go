@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"tgp/internal/parser"
+	"tgp/internal/tags"
 )
 
 func TestValidateProject(t *testing.T) {
@@ -34,6 +35,38 @@ func TestValidateProject(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "same metrics backend",
+			project: &parser.Project{
+				ModulePath: "github.com/example/project",
+				Contracts: []*parser.Contract{
+					{Name: "Users", Annotations: tags.DocTags{"metrics": "otel"}},
+					{Name: "Orders", Annotations: tags.DocTags{"metrics": "otel"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "mixed metrics backends",
+			project: &parser.Project{
+				ModulePath: "github.com/example/project",
+				Contracts: []*parser.Contract{
+					{Name: "Users", Annotations: tags.DocTags{"metrics": "otel"}},
+					{Name: "Orders", Annotations: tags.DocTags{"metrics": ""}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown metrics backend",
+			project: &parser.Project{
+				ModulePath: "github.com/example/project",
+				Contracts: []*parser.Contract{
+					{Name: "Users", Annotations: tags.DocTags{"metrics": "statsd"}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -160,3 +193,456 @@ func TestFindContract(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateContractStreams(t *testing.T) {
+
+	project := &parser.Project{
+		Types: map[string]*parser.Type{
+			"io:Reader":      {Kind: parser.TypeKindInterface},
+			"io:ReadCloser":  {Kind: parser.TypeKindInterface},
+			"example:Writer": {Kind: parser.TypeKindInterface},
+			"example:File":   {Kind: parser.TypeKindStruct, StructFields: []*parser.StructField{{Name: "Body", TypeID: "io:Reader"}}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		annotations tags.DocTags
+		args        []*parser.Variable
+		results     []*parser.Variable
+		wantErr     bool
+	}{
+		{
+			name:        "io.Reader argument of multipart method",
+			annotations: tags.DocTags{"http-method": "POST", "http-multipart": ""},
+			args:        []*parser.Variable{{Name: "file", TypeID: "io:Reader"}},
+			wantErr:     false,
+		},
+		{
+			name:        "io.ReadCloser argument of raw body method",
+			annotations: tags.DocTags{"http-method": "PUT", "http-body": "raw"},
+			args:        []*parser.Variable{{Name: "file", TypeID: "io:ReadCloser"}},
+			wantErr:     false,
+		},
+		{
+			name:    "io.Reader argument of JSON-RPC method",
+			args:    []*parser.Variable{{Name: "file", TypeID: "io:Reader"}},
+			wantErr: true,
+		},
+		{
+			name:        "io.Reader argument of JSON body REST method",
+			annotations: tags.DocTags{"http-method": "POST"},
+			args:        []*parser.Variable{{Name: "file", TypeID: "io:Reader"}},
+			wantErr:     true,
+		},
+		{
+			name:        "raw body without REST method",
+			annotations: tags.DocTags{"http-body": "raw"},
+			args:        []*parser.Variable{{Name: "file", TypeID: "io:Reader"}},
+			wantErr:     true,
+		},
+		{
+			name:        "slice of io.Reader argument",
+			annotations: tags.DocTags{"http-method": "POST", "http-multipart": ""},
+			args:        []*parser.Variable{{Name: "files", TypeID: "io:Reader", IsSlice: true}},
+			wantErr:     true,
+		},
+		{
+			name:        "io.Reader in struct field",
+			annotations: tags.DocTags{"http-method": "POST", "http-multipart": ""},
+			args:        []*parser.Variable{{Name: "file", TypeID: "example:File"}},
+			wantErr:     true,
+		},
+		{
+			name:        "stream result of REST method",
+			annotations: tags.DocTags{"http-method": "GET"},
+			results:     []*parser.Variable{{Name: "body", TypeID: "io:ReadCloser"}},
+			wantErr:     false,
+		},
+		{
+			name:    "stream result of JSON-RPC method",
+			results: []*parser.Variable{{Name: "body", TypeID: "io:ReadCloser"}},
+			wantErr: true,
+		},
+		{
+			name:        "two stream results",
+			annotations: tags.DocTags{"http-method": "GET"},
+			results:     []*parser.Variable{{Name: "body", TypeID: "io:ReadCloser"}, {Name: "preview", TypeID: "io:Reader"}},
+			wantErr:     true,
+		},
+		{
+			name:        "named interface argument",
+			annotations: tags.DocTags{"http-method": "POST", "http-multipart": ""},
+			args:        []*parser.Variable{{Name: "file", TypeID: "example:Writer"}},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract := &parser.Contract{
+				Name: "Files",
+				Methods: []*parser.Method{
+					{Name: "Upload", Annotations: tt.annotations, Args: tt.args, Results: tt.results},
+				},
+			}
+			err := ValidateContract(contract, project)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateContract() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateContractIdempotent(t *testing.T) {
+
+	project := &parser.Project{
+		Types: map[string]*parser.Type{
+			"io:ReadCloser": {Kind: parser.TypeKindInterface},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		typeID  string
+		wantErr bool
+	}{
+		{
+			name:    "json result",
+			typeID:  "string",
+			wantErr: false,
+		},
+		{
+			name:    "stream result",
+			typeID:  "io:ReadCloser",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract := &parser.Contract{
+				Name: "Orders",
+				Methods: []*parser.Method{
+					{
+						Name:        "Create",
+						Annotations: tags.DocTags{"http-method": "POST", "idempotent": ""},
+						Results:     []*parser.Variable{{Name: "result", TypeID: tt.typeID}},
+					},
+				},
+			}
+			err := ValidateContract(contract, project)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateContract() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateContractLogAnnotations(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		annotations tags.DocTags
+		wantErr     bool
+	}{
+		{
+			name:        "mask argument and nested field",
+			annotations: tags.DocTags{"log-mask": "password,card.number", "log-sample": "0.5", "log-truncate": "256"},
+			wantErr:     false,
+		},
+		{
+			name:        "mask result",
+			annotations: tags.DocTags{"log-mask": "token"},
+			wantErr:     false,
+		},
+		{
+			name:        "mask unknown variable",
+			annotations: tags.DocTags{"log-mask": "secret"},
+			wantErr:     true,
+		},
+		{
+			name:        "bad sample",
+			annotations: tags.DocTags{"log-sample": "2"},
+			wantErr:     true,
+		},
+		{
+			name:        "bad max length",
+			annotations: tags.DocTags{"log-max-len": "0"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract := &parser.Contract{
+				Name: "Users",
+				Methods: []*parser.Method{
+					{
+						Name:        "Login",
+						Annotations: tt.annotations,
+						Args: []*parser.Variable{
+							{Name: "password", TypeID: "string"},
+							{Name: "card", TypeID: "string"},
+						},
+						Results: []*parser.Variable{{Name: "token", TypeID: "string"}},
+					},
+				},
+			}
+			err := ValidateContract(contract, &parser.Project{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateContract() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateContractCache(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		annotations tags.DocTags
+		typeID      string
+		wantErr     bool
+	}{
+		{
+			name:        "get with cache and etag",
+			annotations: tags.DocTags{"http-method": "GET", "http-cache": "max-age=60", "etag": ""},
+			typeID:      "string",
+			wantErr:     false,
+		},
+		{
+			name:        "etag only",
+			annotations: tags.DocTags{"http-method": "get", "etag": ""},
+			typeID:      "string",
+			wantErr:     false,
+		},
+		{
+			name:        "post method",
+			annotations: tags.DocTags{"http-method": "POST", "etag": ""},
+			typeID:      "string",
+			wantErr:     true,
+		},
+		{
+			name:        "bad cache control",
+			annotations: tags.DocTags{"http-method": "GET", "http-cache": "max-age=x"},
+			typeID:      "string",
+			wantErr:     true,
+		},
+		{
+			name:        "stream result",
+			annotations: tags.DocTags{"http-method": "GET", "etag": ""},
+			typeID:      "io:ReadCloser",
+			wantErr:     true,
+		},
+	}
+
+	project := &parser.Project{
+		Types: map[string]*parser.Type{
+			"io:ReadCloser": {Kind: parser.TypeKindInterface},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract := &parser.Contract{
+				Name: "Articles",
+				Methods: []*parser.Method{
+					{
+						Name:        "Get",
+						Annotations: tt.annotations,
+						Results:     []*parser.Variable{{Name: "result", TypeID: tt.typeID}},
+					},
+				},
+			}
+			err := ValidateContract(contract, project)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateContract() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateContractVersioning(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		contract    tags.DocTags
+		annotations tags.DocTags
+		wantErr     bool
+	}{
+		{
+			name:        "contract version and deprecated method",
+			contract:    tags.DocTags{"version": "v2"},
+			annotations: tags.DocTags{"deprecated": "use CreateV2", "sunset": "2027-01-01"},
+			wantErr:     false,
+		},
+		{
+			name:        "method version",
+			annotations: tags.DocTags{"version": "v3"},
+			wantErr:     false,
+		},
+		{
+			name:     "bad contract version",
+			contract: tags.DocTags{"version": "v2.1"},
+			wantErr:  true,
+		},
+		{
+			name:        "bad sunset",
+			annotations: tags.DocTags{"deprecated": "", "sunset": "soon"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract := &parser.Contract{
+				Name:        "Users",
+				Annotations: tt.contract,
+				Methods:     []*parser.Method{{Name: "Create", Annotations: tt.annotations}},
+			}
+			err := ValidateContract(contract, &parser.Project{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateContract() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateContractHttpErrors(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		contract tags.DocTags
+		wantErr  bool
+	}{
+		{
+			name:     "default errors",
+			contract: tags.DocTags{"http-server": ""},
+			wantErr:  false,
+		},
+		{
+			name:     "problem errors",
+			contract: tags.DocTags{"http-server": "", "http-errors": "problem"},
+			wantErr:  false,
+		},
+		{
+			name:     "unknown errors mode",
+			contract: tags.DocTags{"http-server": "", "http-errors": "xml"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract := &parser.Contract{
+				Name:        "Users",
+				Annotations: tt.contract,
+				Methods:     []*parser.Method{{Name: "Create"}},
+			}
+			err := ValidateContract(contract, &parser.Project{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateContract() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateContractPaginate(t *testing.T) {
+
+	listArgs := []*parser.Variable{{Name: "cursor", TypeID: "string"}, {Name: "limit", TypeID: "int"}}
+	listResults := []*parser.Variable{{Name: "items", TypeID: "string", IsSlice: true}, {Name: "nextCursor", TypeID: "string"}, {Name: "err", TypeID: "error"}}
+
+	tests := []struct {
+		name        string
+		annotations tags.DocTags
+		args        []*parser.Variable
+		results     []*parser.Variable
+		wantErr     bool
+	}{
+		{
+			name:        "default names",
+			annotations: tags.DocTags{"paginate": ""},
+			args:        listArgs,
+			results:     listResults,
+			wantErr:     false,
+		},
+		{
+			name:        "custom names and page size",
+			annotations: tags.DocTags{"paginate": "", "cursor": "after", "next": "next", "items": "users", "page-size": "limit"},
+			args:        []*parser.Variable{{Name: "after", TypeID: "string"}, {Name: "limit", TypeID: "int32"}},
+			results:     []*parser.Variable{{Name: "users", TypeID: "example:User", IsSlice: true}, {Name: "next", TypeID: "string"}, {Name: "err", TypeID: "error"}},
+			wantErr:     false,
+		},
+		{
+			name:        "missing cursor argument",
+			annotations: tags.DocTags{"paginate": "", "cursor": "after"},
+			args:        listArgs,
+			results:     listResults,
+			wantErr:     true,
+		},
+		{
+			name:        "cursor is not string",
+			annotations: tags.DocTags{"paginate": "", "cursor": "limit"},
+			args:        listArgs,
+			results:     listResults,
+			wantErr:     true,
+		},
+		{
+			name:        "missing next cursor result",
+			annotations: tags.DocTags{"paginate": "", "next": "next"},
+			args:        listArgs,
+			results:     listResults,
+			wantErr:     true,
+		},
+		{
+			name:        "items is not slice",
+			annotations: tags.DocTags{"paginate": "", "items": "nextCursor"},
+			args:        listArgs,
+			results:     listResults,
+			wantErr:     true,
+		},
+		{
+			name:        "no error result",
+			annotations: tags.DocTags{"paginate": ""},
+			args:        listArgs,
+			results:     listResults[:2],
+			wantErr:     true,
+		},
+		{
+			name:        "missing page size argument",
+			annotations: tags.DocTags{"paginate": "", "page-size": "size"},
+			args:        listArgs,
+			results:     listResults,
+			wantErr:     true,
+		},
+		{
+			name:        "page size is not integer",
+			annotations: tags.DocTags{"paginate": "", "page-size": "cursor"},
+			args:        listArgs,
+			results:     listResults,
+			wantErr:     true,
+		},
+		{
+			name:    "without paginate",
+			args:    []*parser.Variable{{Name: "id", TypeID: "int"}},
+			results: []*parser.Variable{{Name: "name", TypeID: "string"}, {Name: "err", TypeID: "error"}},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract := &parser.Contract{
+				Name: "Users",
+				Methods: []*parser.Method{
+					{Name: "List", Annotations: tt.annotations, Args: tt.args, Results: tt.results},
+				},
+			}
+			err := ValidateContract(contract, &parser.Project{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateContract() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
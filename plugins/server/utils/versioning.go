@@ -0,0 +1,60 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"tgp/internal/tags"
+)
+
+// Теги версионирования и вывода методов из эксплуатации
+const (
+	tagVersion    = "version"
+	tagDeprecated = "deprecated"
+	tagSunset     = "sunset"
+)
+
+var reVersion = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ParseVersion проверяет значение аннотации version (сегмент пути и пространство имён JSON-RPC, например v2).
+func ParseVersion(value string) (version string, err error) {
+
+	version = strings.TrimSpace(value)
+	if !reVersion.MatchString(version) {
+		return "", fmt.Errorf("invalid version %q: expected letters, digits, '-' or '_'", value)
+	}
+	return version, nil
+}
+
+// ParseSunset разбирает значение аннотации sunset в формате 2006-01-02 или RFC3339.
+func ParseSunset(value string) (sunset time.Time, err error) {
+
+	value = strings.TrimSpace(value)
+	if sunset, err = time.Parse(time.DateOnly, value); err == nil {
+		return sunset.UTC(), nil
+	}
+	if sunset, err = time.Parse(time.RFC3339, value); err == nil {
+		return sunset.UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid sunset %q: expected date 2006-01-02 or RFC3339 time", value)
+}
+
+// validateVersionAnnotations проверяет аннотации версии и вывода из эксплуатации контракта или метода.
+func validateVersionAnnotations(annotations tags.DocTags) error {
+
+	if annotations.IsSet(tagVersion) {
+		if _, err := ParseVersion(annotations.Value(tagVersion)); err != nil {
+			return err
+		}
+	}
+	if annotations.IsSet(tagSunset) {
+		if _, err := ParseSunset(annotations.Value(tagSunset)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
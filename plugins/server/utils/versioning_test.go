@@ -0,0 +1,63 @@
+// Copyright (c) 2020 Khramtsov Aleksei (seniorGolang@gmail.com).
+// This file is subject to the terms and conditions defined in file 'LICENSE', which is part of this project source code.
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseVersion(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "simple", value: "v2", want: "v2"},
+		{name: "trimmed", value: " v2-beta ", want: "v2-beta"},
+		{name: "empty", value: "", wantErr: true},
+		{name: "dot", value: "v2.1", wantErr: true},
+		{name: "slash", value: "api/v2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := ParseVersion(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if version != tt.want {
+				t.Errorf("ParseVersion() = %q, want %q", version, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSunset(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "date", value: "2027-01-01", want: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "rfc3339", value: "2027-01-01T12:00:00+03:00", want: time.Date(2027, 1, 1, 9, 0, 0, 0, time.UTC)},
+		{name: "empty", value: "", wantErr: true},
+		{name: "bad date", value: "01.01.2027", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sunset, err := ParseSunset(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSunset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !sunset.Equal(tt.want) {
+				t.Errorf("ParseSunset() = %s, want %s", sunset, tt.want)
+			}
+		})
+	}
+}